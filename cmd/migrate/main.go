@@ -0,0 +1,196 @@
+// Command migrate manages the Postgres schema using the migrations embedded
+// in the server binary.
+//
+// Usage:
+//
+//	migrate [-dir DIR] COMMAND [ARG]
+//
+// Commands:
+//
+//	up           apply all pending migrations
+//	down N       roll back the last N migrations
+//	goto V       migrate up or down to version V
+//	status       print the schema version and whether it is behind
+//	force V      mark version V as applied and clean, without running it
+//	create NAME  scaffold the next numbered up and down migration in DIR
+//
+// Database commands read the connection settings from the .env file in
+// CONFIG_PATH, or the working directory if unset.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres"
+)
+
+const defaultMigrationsDir = "internal/adapters/outbound/postgres/migrations"
+
+func main() {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dir := flags.String("dir", defaultMigrationsDir, "migrations directory used by create")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: migrate [-dir DIR] up | down N | goto V | status | force V | create NAME")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	if err := run(flags.Args(), *dir); err != nil {
+		if errors.Is(err, errUsage) {
+			flags.Usage()
+			os.Exit(2)
+		}
+		log.Fatalf("migrate: %v", err)
+	}
+}
+
+var errUsage = errors.New("invalid usage")
+
+func run(args []string, dir string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	command, args := args[0], args[1:]
+
+	if command == "create" {
+		if len(args) != 1 {
+			return errUsage
+		}
+		up, down, err := createMigration(dir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s\ncreated %s\n", up, down)
+		return nil
+	}
+
+	wantArgs := map[string]int{"up": 0, "status": 0, "down": 1, "goto": 1, "force": 1}
+	n, ok := wantArgs[command]
+	if !ok || len(args) != n {
+		return errUsage
+	}
+
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := migrator.Close(); closeErr != nil {
+			log.Printf("error closing migrator: %v", closeErr)
+		}
+	}()
+
+	switch command {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down":
+		steps, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("parse number of migrations %q: %w", args[0], err)
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+	case "goto":
+		version, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("parse version %q: %w", args[0], err)
+		}
+		if err := migrator.Goto(uint(version)); err != nil {
+			return err
+		}
+	case "force":
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("parse version %q: %w", args[0], err)
+		}
+		if err := migrator.Force(version); err != nil {
+			return err
+		}
+	}
+
+	return printStatus(migrator)
+}
+
+func newMigrator() (*postgres.Migrator, error) {
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "."
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	return postgres.NewMigrator(postgres.NewURL(cfg))
+}
+
+func printStatus(migrator *postgres.Migrator) error {
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version: %d\nlatest: %d\n", status.Version, status.Latest)
+	if status.Dirty {
+		fmt.Println("dirty: repair the schema by hand, then force the version")
+	} else if status.Pending() {
+		fmt.Println("pending: run migrate up")
+	}
+
+	return nil
+}
+
+var migrationName = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// createMigration writes empty up and down migrations named `name` to `dir`,
+// numbered after the latest migration there, and returns their paths.
+func createMigration(dir, name string) (up, down string, err error) {
+	if !migrationName.MatchString(name) {
+		return "", "", fmt.Errorf(
+			"migration name %q must be lower snake case, like add_user_bio",
+			name,
+		)
+	}
+
+	latest, err := postgres.LatestMigrationVersionIn(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", latest+1, name))
+	up, down = base+".up.sql", base+".down.sql"
+
+	if err := createFile(up); err != nil {
+		return "", "", err
+	}
+	if err := createFile(down); err != nil {
+		_ = os.Remove(up)
+		return "", "", err
+	}
+
+	return up, down, nil
+}
+
+// createFile creates an empty file at `path`, failing if it already exists.
+func createFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("create migration file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close migration file %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMigration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("numbers after the latest migration", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		for _, name := range []string{"000001_users.up.sql", "000001_users.down.sql", "000007_sessions.up.sql"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+		}
+
+		up, down, err := createMigration(dir, "add_user_bio")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "000008_add_user_bio.up.sql"), up)
+		assert.Equal(t, filepath.Join(dir, "000008_add_user_bio.down.sql"), down)
+		assert.FileExists(t, up)
+		assert.FileExists(t, down)
+	})
+
+	t.Run("starts at one", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		up, _, err := createMigration(dir, "users")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "000001_users.up.sql"), up)
+	})
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		for _, name := range []string{"", "Add-Bio", "../escape", "trailing_"} {
+			_, _, err := createMigration(dir, name)
+			assert.Error(t, err, name)
+		}
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
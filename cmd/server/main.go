@@ -2,21 +2,50 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"log"
+	"maps"
 	"os"
 	"os/signal"
-	"strconv"
+	"slices"
 	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/admin"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc"
+	adminGRPC "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/admin"
+	auditGRPC "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/audit"
+	authGRPC "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
 	userGRPC "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/web"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/memory"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/metrics"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/publisher"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
 	authService "github.com/teamkweku/code-odessey-hex-arch/internal/core/application/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/dispatch"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/jobs"
+	outboxRelay "github.com/teamkweku/code-odessey-hex-arch/internal/core/application/outbox"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/user"
+	domainOutbox "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
 	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tlsconfig"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
 )
 
 func main() {
@@ -29,28 +58,148 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
+	if err = cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
 
-	isPrettyPrint := cfg.Environment == "development"
+	isPrettyPrint := cfg.Environment == config.EnvironmentDevelopment
 
 	// initialize logger
 	zeroLogger := logger.NewZerologLogger(isPrettyPrint)
 
-	// initialize database connection
-	postgresURL := postgres.NewURL(cfg)
-	postgresAdapter, err := postgres.New(context.Background(), postgresURL)
+	// initialize tracing
+	tracerProvider, err := tracing.NewProvider(context.Background(), tracing.Options{
+		ServiceName: cfg.AppName,
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingEndpoint,
+		Insecure:    cfg.TracingInsecure,
+		FilePath:    cfg.TracingFilePath,
+	})
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		log.Fatalf("failed to initialize tracing: %v", err)
 	}
 
 	defer func() {
-		if err = postgresAdapter.Close(); err != nil {
-			log.Printf("error closing postgres client %v", err)
+		if err = tracerProvider.Shutdown(context.Background()); err != nil {
+			log.Printf("error shutting down tracer provider %v", err)
 		}
 	}()
 
-	// create sessions service
-	sessionSrv := session.NewSessionService(postgresAdapter)
-	userService := user.NewUserService(postgresAdapter)
+	// initialize metrics registry
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	domainMetrics := metrics.NewDomainMetrics(registry)
+
+	// initialize the store selected by the database driver
+	var (
+		userRepo         outbound.UserRepository
+		sessionRepo      outbound.SessionRepository
+		unitOfWork       outbound.UnitOfWork
+		outboxStore      outbound.OutboxRelayStore
+		idempotencyStore outbound.IdempotencyStore
+		auditLog         outbound.AuditLogger
+		sessionCleaner   outbound.SessionCleaner
+		outboxCleaner    outbound.OutboxCleaner
+		jobLocker        outbound.JobLocker
+		postgresAdapter  *postgres.Client
+	)
+	switch cfg.DBDriver {
+	case config.DBDriverPostgres:
+		connectCtx, cancel := context.WithTimeout(context.Background(), cfg.DBConnectTimeout)
+		postgresURL := postgres.NewURL(cfg)
+		postgresAdapter, err = postgres.New(connectCtx, postgresURL, postgresOptions(cfg, zeroLogger))
+		cancel()
+		if err != nil {
+			log.Fatalf("failed to connect to database: %v", err)
+		}
+
+		defer func() {
+			if err = postgresAdapter.Close(); err != nil {
+				log.Printf("error closing postgres client %v", err)
+			}
+		}()
+
+		registry.MustRegister(metrics.NewPoolCollector(postgresAdapter))
+		userRepo, sessionRepo, idempotencyStore = postgresAdapter, postgresAdapter, postgresAdapter
+		unitOfWork, outboxStore, auditLog = postgresAdapter, postgresAdapter, postgresAdapter
+		sessionCleaner, outboxCleaner, jobLocker = postgresAdapter, postgresAdapter, postgresAdapter
+	case config.DBDriverSQLite:
+		sqliteAdapter, err := sqlite.New(context.Background(), cfg.SQLitePath)
+		if err != nil {
+			log.Fatalf("failed to open database: %v", err)
+		}
+
+		defer func() {
+			if err = sqliteAdapter.Close(); err != nil {
+				log.Printf("error closing sqlite client %v", err)
+			}
+		}()
+
+		// A single node has no peers to share idempotency records with, so
+		// they are kept in process memory.
+		userRepo, sessionRepo, idempotencyStore = sqliteAdapter, sqliteAdapter, memory.New()
+		unitOfWork, outboxStore, auditLog = sqliteAdapter, sqliteAdapter, sqliteAdapter
+		// Only this process runs jobs against the file, so their locks are
+		// held in process memory too.
+		sessionCleaner, outboxCleaner, jobLocker = sqliteAdapter, sqliteAdapter, memory.New()
+	case config.DBDriverMemory:
+		memoryAdapter := memory.New()
+		userRepo, sessionRepo, idempotencyStore = memoryAdapter, memoryAdapter, memoryAdapter
+		unitOfWork, outboxStore, auditLog = memoryAdapter, memoryAdapter, memoryAdapter
+		sessionCleaner, outboxCleaner, jobLocker = memoryAdapter, memoryAdapter, memoryAdapter
+	default:
+		log.Fatalf("unknown database driver %q", cfg.DBDriver)
+	}
+
+	// create the audit, sessions and user services
+	auditSrv := audit.NewAuditService(auditLog, domainMetrics, zeroLogger)
+	sessionSrv := session.NewSessionService(sessionRepo, unitOfWork, domainMetrics, auditSrv)
+	userService := user.NewUserService(userRepo, unitOfWork, domainMetrics, auditSrv)
+
+	// initialize the dispatcher delivering domain events to in-process
+	// handlers and to the configured publisher
+	dispatcher := dispatch.NewDispatcher(dispatchOptions(cfg), zeroLogger)
+
+	eventPublisher, closePublisher, err := newEventPublisher(cfg, zeroLogger)
+	if err != nil {
+		log.Fatalf("failed to create event publisher: %v", err)
+	}
+
+	defer func() {
+		if err = closePublisher(); err != nil {
+			log.Printf("error closing event publisher %v", err)
+		}
+	}()
+
+	if eventPublisher != nil {
+		dispatcher.SubscribeAll(string(cfg.EventPublisher)+" publisher", dispatch.Sync, eventPublisher.Publish)
+	}
+
+	// initialize the relay publishing domain events from the outbox
+	relay := outboxRelay.NewRelay(outboxStore, dispatcher, relayOptions(cfg), zeroLogger)
+
+	// initialize the background jobs, run by one replica at a time
+	jobRunner := jobs.NewRunner(jobLocker, metrics.NewJobMetrics(registry), zeroLogger)
+	jobRunner.Register(jobs.Job{
+		Name:     session.CleanupJobName,
+		Interval: cfg.SessionCleanupInterval,
+		Run:      session.NewCleaner(sessionCleaner, sessionCleanupOptions(cfg)).Run,
+	})
+	jobRunner.Register(jobs.Job{
+		Name:     "idempotency-cleanup",
+		Interval: cfg.IdempotencyCleanupInterval,
+		Run: func(ctx context.Context) (int64, error) {
+			return idempotencyStore.DeleteExpired(ctx, time.Now())
+		},
+	})
+	jobRunner.Register(jobs.Job{
+		Name:     outboxRelay.CleanupJobName,
+		Interval: cfg.OutboxCleanupInterval,
+		Run:      outboxRelay.NewCleaner(outboxCleaner, outboxCleanupOptions(cfg)).Run,
+	})
 
 	// initialize token service
 	tokenService, err := auth.NewPasetoToken()
@@ -59,17 +208,101 @@ func main() {
 	}
 
 	// initialize the auth service
-	authService := authService.NewAuthService(tokenService, sessionSrv)
+	authService := authService.NewAuthService(tokenService, sessionSrv, userService, auditSrv)
 
-	userServer := userGRPC.NewServer(userService, cfg, authService, sessionSrv)
+	// initialize rate limiting
+	metadataExtractor := metadata.NewMetadataExtractor(cfg.TrustedProxies...)
 
-	// Convert RPCPort to int
-	port, err := strconv.Atoi(cfg.RPCPort)
-	if err != nil {
-		log.Fatalf("invalid port number: %v", err)
+	var rateLimitStore ratelimit.Store
+	switch cfg.RateLimitStore {
+	case config.RateLimitStoreMemory:
+		rateLimitStore = ratelimit.NewMemoryStore()
+	case config.RateLimitStorePostgres:
+		postgresRateLimitStore := postgres.NewRateLimitStore(postgresAdapter)
+		jobRunner.Register(jobs.Job{
+			Name:     "rate-limit-prune",
+			Interval: cfg.RateLimitPruneInterval,
+			Run:      postgresRateLimitStore.Prune,
+		})
+		rateLimitStore = postgresRateLimitStore
+	default:
+		log.Fatalf("unknown rate limit store %q", cfg.RateLimitStore)
+	}
+
+	userServer := userGRPC.NewServer(userService, cfg, authService, sessionSrv, metadataExtractor)
+
+	// initialize TLS
+	var tlsConfig *tls.Config
+	if cfg.TLSCertFile != "" {
+		nextProtos := []string{"h2"}
+		if cfg.GRPCWebEnabled {
+			nextProtos = append(nextProtos, "http/1.1")
+		}
+
+		certReloader, err := tlsconfig.New(tlsconfig.Options{
+			CertFile:     cfg.TLSCertFile,
+			KeyFile:      cfg.TLSKeyFile,
+			ClientCAFile: cfg.TLSClientCAFile,
+			MinVersion:   cfg.TLSMinVersion,
+			NextProtos:   nextProtos,
+			OnReloadError: func(err error) {
+				zeroLogger.Error(context.Background(), err, "failed to reload TLS certificates", map[string]interface{}{
+					"cert_file": cfg.TLSCertFile,
+				})
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to load TLS certificates: %v", err)
+		}
+		tlsConfig = certReloader.TLSConfig()
+	}
+
+	grpcOpts := grpcServerOptions(cfg)
+	if cfg.GRPCWebEnabled {
+		schemas := userGRPC.Schemas()
+		maps.Copy(schemas, auditGRPC.Schemas())
+		maps.Copy(schemas, adminGRPC.Schemas())
+
+		grpcOpts = append(grpcOpts, grpc.WithWebProtocols(web.Options{
+			AllowedOrigins: cfg.CORSAllowedOrigins,
+			OpenAPI: openapi.NewDocument(openapi.Spec{
+				Info:    openapi.Info{Title: cfg.AppName, Version: "v1"},
+				Routes:  slices.Concat(userGRPC.Routes(), authGRPC.Routes(), auditGRPC.Routes(), adminGRPC.Routes()),
+				Schemas: schemas,
+				Error:   web.ErrorSchema(),
+			}),
+		}))
 	}
 
-	grpcServer := grpc.NewServer(port, userServer, zeroLogger)
+	unaryDeadline, streamDeadline := middleware.GrpcDeadline(deadlineOptions(cfg))
+
+	grpcServer := grpc.NewServer(
+		cfg.RPCPort,
+		userServer,
+		zeroLogger,
+		append(
+			grpcOpts,
+			grpc.WithMetrics(registry),
+			grpc.WithTLS(tlsConfig),
+			grpc.WithServices(
+				authGRPC.NewServer(authService, sessionSrv, cfg),
+				auditGRPC.NewServer(auditSrv),
+				adminGRPC.NewServer(userService),
+			),
+			grpc.WithUnaryInterceptors(
+				unaryDeadline,
+				middleware.GrpcClientRateLimit(rateLimitStore, metadataExtractor, cfg.RateLimitPerClient, zeroLogger),
+				middleware.GrpcAuth(tokenService),
+				middleware.GrpcReadYourWrites(readYourWritesOptions(cfg)),
+				middleware.GrpcAuditSource(metadataExtractor),
+				middleware.GrpcRateLimit(rateLimitStore, metadataExtractor, rateLimitOptions(cfg), zeroLogger),
+				middleware.GrpcIdempotency(idempotencyStore, idempotencyOptions(cfg), zeroLogger),
+			),
+			grpc.WithStreamInterceptors(streamDeadline),
+		)...,
+	)
+
+	adminServer := admin.NewServer(cfg.AdminPort, registry, zeroLogger)
 
 	// start gRPC server
 	go func() {
@@ -78,6 +311,29 @@ func main() {
 		}
 	}()
 
+	// start admin server
+	go func() {
+		if err := adminServer.Run(); err != nil {
+			log.Fatalf("failed to serve admin: %v", err)
+		}
+	}()
+
+	// start outbox relay
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(relayCtx)
+	}()
+
+	// start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		jobRunner.Run(jobsCtx)
+	}()
+
 	// graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -85,5 +341,157 @@ func main() {
 	log.Println("Shutting down server...")
 
 	grpcServer.Close()
+	adminServer.Close()
+	stopRelay()
+	stopJobs()
+	<-relayDone
+	<-jobsDone
+
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), dispatchDrainTimeout)
+	if err := dispatcher.Close(drainCtx); err != nil {
+		log.Printf("error draining event dispatcher %v", err)
+	}
+	cancelDrain()
+
 	log.Println("server exited properly")
 }
+
+func grpcServerOptions(cfg config.Config) []grpc.Option {
+	params := keepalive.ServerParameters{
+		Time:                  cfg.GRPCKeepaliveTime,
+		Timeout:               cfg.GRPCKeepaliveTimeout,
+		MaxConnectionIdle:     cfg.GRPCMaxConnIdle,
+		MaxConnectionAge:      cfg.GRPCMaxConnAge,
+		MaxConnectionAgeGrace: cfg.GRPCMaxConnAgeGrace,
+	}
+	policy := keepalive.EnforcementPolicy{MinTime: cfg.GRPCKeepaliveMinTime}
+
+	loggerOpts := middleware.LoggerOptions{
+		PayloadSampleRate: cfg.LogPayloadSampleRate,
+		SlowThreshold:     cfg.LogSlowThreshold,
+		RedactFields:      cfg.LogRedactFields,
+	}
+
+	return []grpc.Option{
+		grpc.WithAccessLog(loggerOpts),
+		grpc.WithMaxMessageSizes(cfg.GRPCMaxRecvMsgBytes, cfg.GRPCMaxSendMsgBytes),
+		grpc.WithMaxConcurrentStreams(uint32(cfg.GRPCMaxStreams)),
+		grpc.WithKeepalive(params, policy),
+	}
+}
+
+func postgresOptions(cfg config.Config, queryLogger logger.Logger) postgres.Options {
+	opts := postgres.Options{
+		ApplicationName:   cfg.AppName,
+		ReplicaURL:        postgres.NewReplicaURL(cfg),
+		SkipMigrations:    !cfg.DBAutoMigrate,
+		MaxConns:          int32(cfg.DBMaxConns),
+		MinConns:          int32(cfg.DBMinConns),
+		MaxConnLifetime:   cfg.DBMaxConnLifetime,
+		MaxConnIdleTime:   cfg.DBMaxConnIdleTime,
+		HealthCheckPeriod: cfg.DBHealthCheckPeriod,
+		StatementTimeout:  cfg.DBStatementTimeout,
+		MaxTxAttempts:     cfg.DBTxMaxAttempts,
+	}
+	if cfg.DBLogQueries {
+		opts.QueryLogger = queryLogger
+	}
+
+	return opts
+}
+
+func deadlineOptions(cfg config.Config) middleware.DeadlineOptions {
+	return middleware.DeadlineOptions{
+		Methods: cfg.RPCDeadlines,
+		Default: cfg.RPCDeadlineDefault,
+	}
+}
+
+func idempotencyOptions(cfg config.Config) middleware.IdempotencyOptions {
+	return middleware.IdempotencyOptions{
+		Methods: cfg.IdempotentMethods,
+		TTL:     cfg.IdempotencyTTL,
+		Lease:   cfg.IdempotencyLease,
+	}
+}
+
+func readYourWritesOptions(cfg config.Config) middleware.ReadYourWritesOptions {
+	return middleware.ReadYourWritesOptions{
+		Window:          cfg.ReadYourWritesWindow,
+		ReadOnlyMethods: cfg.ReadOnlyMethods,
+	}
+}
+
+func rateLimitOptions(cfg config.Config) middleware.RateLimitOptions {
+	opts := middleware.RateLimitOptions{Limits: cfg.RateLimits}
+	// Parsed limits always have a burst, so the zero limit means none is set.
+	if cfg.RateLimitDefault != (ratelimit.Limit{}) {
+		limit := cfg.RateLimitDefault
+		opts.Default = &limit
+	}
+	return opts
+}
+
+// dispatchDrainTimeout bounds the wait for asynchronous event handlers on
+// shutdown.
+const dispatchDrainTimeout = 10 * time.Second
+
+func dispatchOptions(cfg config.Config) dispatch.Options {
+	return dispatch.Options{
+		Workers:   cfg.EventWorkers,
+		QueueSize: cfg.EventQueueSize,
+	}
+}
+
+// newEventPublisher returns the publisher selected by the config, or nil if
+// events are only handled in process, and a function releasing its
+// resources.
+func newEventPublisher(
+	cfg config.Config,
+	eventLogger logger.Logger,
+) (outbound.EventPublisher, func() error, error) {
+	noop := func() error { return nil }
+
+	switch cfg.EventPublisher {
+	case config.EventPublisherLog:
+		return publisher.NewLogPublisher(eventLogger), noop, nil
+	case config.EventPublisherFile:
+		filePublisher, err := publisher.NewFilePublisher(cfg.EventFilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return filePublisher, filePublisher.Close, nil
+	case config.EventPublisherBus:
+		return nil, noop, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown event publisher %q", cfg.EventPublisher)
+	}
+}
+
+func relayOptions(cfg config.Config) outboxRelay.RelayOptions {
+	opts := outboxRelay.RelayOptions{
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+	}
+	if cfg.OutboxMaxAttempts > 0 {
+		opts.RetryPolicy = domainOutbox.DefaultRetryPolicy
+		opts.RetryPolicy.MaxAttempts = cfg.OutboxMaxAttempts
+	}
+
+	return opts
+}
+
+func sessionCleanupOptions(cfg config.Config) session.CleanerOptions {
+	return session.CleanerOptions{
+		BatchSize:        cfg.SessionCleanupBatchSize,
+		BlockedRetention: cfg.SessionBlockedRetention,
+	}
+}
+
+func outboxCleanupOptions(cfg config.Config) outboxRelay.CleanerOptions {
+	return outboxRelay.CleanerOptions{
+		BatchSize:     cfg.OutboxCleanupBatchSize,
+		SentRetention: cfg.OutboxSentRetention,
+		DeadRetention: cfg.OutboxDeadRetention,
+	}
+}
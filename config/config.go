@@ -2,50 +2,491 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"net/netip"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/deadline"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tlsconfig"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/trustedproxy"
+)
+
+// Environment is the environment the service is deployed to.
+type Environment string
+
+const (
+	EnvironmentDevelopment Environment = "development"
+	EnvironmentTest        Environment = "test"
+	EnvironmentProduction  Environment = "production"
 )
 
+// DBDriver selects the store backing the service.
+type DBDriver string
+
+const (
+	DBDriverPostgres DBDriver = "postgres"
+	// DBDriverSQLite is for single-node deployments.
+	DBDriverSQLite DBDriver = "sqlite"
+	// DBDriverMemory keeps all data in process memory, which is lost on
+	// restart.
+	DBDriverMemory DBDriver = "memory"
+)
+
+// RateLimitStore selects where rate limit buckets are kept.
+type RateLimitStore string
+
+const (
+	RateLimitStoreMemory RateLimitStore = "memory"
+	// RateLimitStorePostgres shares buckets between replicas. It requires
+	// [DBDriverPostgres].
+	RateLimitStorePostgres RateLimitStore = "postgres"
+)
+
+// EventPublisher selects where relayed domain events are published, in
+// addition to in-process handlers.
+type EventPublisher string
+
+const (
+	EventPublisherLog  EventPublisher = "log"
+	EventPublisherFile EventPublisher = "file"
+	// EventPublisherBus delivers events to in-process handlers only.
+	EventPublisherBus EventPublisher = "bus"
+)
+
+// Config is the service configuration. Values missing or empty in the
+// environment keep their defaults from [Default]. Zero durations and counts
+// without a default select the defaults of the component they configure.
 type Config struct {
-	Environment          string `mapstructure:"CODE_ODESSEY_ENVIRONMENT"`
-	AccessTokenDuration  string `mapstructure:"CODE_ODESSEY_TOKEN_DURATION"`
-	RefreshTokenDuration string `mapstructure:"CODE_ODESSEY_REFRESH_TOKEN_DURATION"`
-	DBDriver             string `mapstructure:"CODE_ODESSEY_DB_DRIVER"`
-	DBSource             string `mapstructure:"CODE_ODESSEY_DATABASE_URI"`
-	DBHost               string `mapstructure:"CODE_ODESSEY_DB_HOST"`
-	DBPassword           string `mapstructure:"CODE_ODESSEY_DB_PASSWORD"`
-	DBPort               string `mapstructure:"CODE_ODESSEY_DB_PORT"`
-	DBName               string `mapstructure:"CODE_ODESSEY_DB_NAME"`
-	DBSslMode            string `mapstructure:"CODE_ODESSEY_DB_SSL_MODE"`
-	DBUser               string `mapstructure:"CODE_ODESSEY_DB_USER"`
-	RPCPort              string `mapstructure:"CODE_ODESSEY_PORT"`
+	Environment                Environment                  `mapstructure:"CODE_ODESSEY_ENVIRONMENT"`
+	AppName                    string                       `mapstructure:"CODE_ODESSEY_APP_NAME"`
+	AccessTokenDuration        time.Duration                `mapstructure:"CODE_ODESSEY_TOKEN_DURATION"`
+	RefreshTokenDuration       time.Duration                `mapstructure:"CODE_ODESSEY_REFRESH_TOKEN_DURATION"`
+	DBDriver                   DBDriver                     `mapstructure:"CODE_ODESSEY_DB_DRIVER"`
+	DBSource                   string                       `mapstructure:"CODE_ODESSEY_DATABASE_URI"`
+	DBHost                     string                       `mapstructure:"CODE_ODESSEY_DB_HOST"`
+	DBPassword                 string                       `mapstructure:"CODE_ODESSEY_DB_PASSWORD"`
+	DBPort                     int                          `mapstructure:"CODE_ODESSEY_DB_PORT"`
+	DBName                     string                       `mapstructure:"CODE_ODESSEY_DB_NAME"`
+	DBSslMode                  string                       `mapstructure:"CODE_ODESSEY_DB_SSL_MODE"`
+	DBUser                     string                       `mapstructure:"CODE_ODESSEY_DB_USER"`
+	DBReplicaHost              string                       `mapstructure:"CODE_ODESSEY_DB_REPLICA_HOST"`
+	DBReplicaPort              int                          `mapstructure:"CODE_ODESSEY_DB_REPLICA_PORT"`
+	DBAutoMigrate              bool                         `mapstructure:"CODE_ODESSEY_DB_AUTO_MIGRATE"`
+	DBMaxConns                 int                          `mapstructure:"CODE_ODESSEY_DB_MAX_CONNS"`
+	DBMinConns                 int                          `mapstructure:"CODE_ODESSEY_DB_MIN_CONNS"`
+	DBMaxConnLifetime          time.Duration                `mapstructure:"CODE_ODESSEY_DB_MAX_CONN_LIFETIME"`
+	DBMaxConnIdleTime          time.Duration                `mapstructure:"CODE_ODESSEY_DB_MAX_CONN_IDLE_TIME"`
+	DBHealthCheckPeriod        time.Duration                `mapstructure:"CODE_ODESSEY_DB_HEALTH_CHECK_PERIOD"`
+	DBStatementTimeout         time.Duration                `mapstructure:"CODE_ODESSEY_DB_STATEMENT_TIMEOUT"`
+	DBConnectTimeout           time.Duration                `mapstructure:"CODE_ODESSEY_DB_CONNECT_TIMEOUT"`
+	DBTxMaxAttempts            int                          `mapstructure:"CODE_ODESSEY_DB_TX_MAX_ATTEMPTS"`
+	DBLogQueries               bool                         `mapstructure:"CODE_ODESSEY_DB_LOG_QUERIES"`
+	SQLitePath                 string                       `mapstructure:"CODE_ODESSEY_SQLITE_PATH"`
+	RPCPort                    int                          `mapstructure:"CODE_ODESSEY_PORT"`
+	AdminPort                  int                          `mapstructure:"CODE_ODESSEY_ADMIN_PORT"`
+	RPCDeadlineDefault         deadline.Deadline            `mapstructure:"CODE_ODESSEY_RPC_DEADLINE_DEFAULT"`
+	RPCDeadlines               map[string]deadline.Deadline `mapstructure:"CODE_ODESSEY_RPC_DEADLINES"`
+	IdempotentMethods          []string                     `mapstructure:"CODE_ODESSEY_IDEMPOTENT_METHODS"`
+	IdempotencyTTL             time.Duration                `mapstructure:"CODE_ODESSEY_IDEMPOTENCY_TTL"`
+	IdempotencyLease           time.Duration                `mapstructure:"CODE_ODESSEY_IDEMPOTENCY_LEASE"`
+	IdempotencyCleanupInterval time.Duration                `mapstructure:"CODE_ODESSEY_IDEMPOTENCY_CLEANUP_INTERVAL"`
+	ReadOnlyMethods            []string                     `mapstructure:"CODE_ODESSEY_READ_ONLY_METHODS"`
+	ReadYourWritesWindow       time.Duration                `mapstructure:"CODE_ODESSEY_READ_YOUR_WRITES_WINDOW"`
+	GRPCWebEnabled             bool                         `mapstructure:"CODE_ODESSEY_GRPC_WEB_ENABLED"`
+	CORSAllowedOrigins         []string                     `mapstructure:"CODE_ODESSEY_CORS_ALLOWED_ORIGINS"`
+	GRPCMaxRecvMsgBytes        int                          `mapstructure:"CODE_ODESSEY_GRPC_MAX_RECV_MSG_BYTES"`
+	GRPCMaxSendMsgBytes        int                          `mapstructure:"CODE_ODESSEY_GRPC_MAX_SEND_MSG_BYTES"`
+	GRPCMaxStreams             int                          `mapstructure:"CODE_ODESSEY_GRPC_MAX_CONCURRENT_STREAMS"`
+	GRPCKeepaliveTime          time.Duration                `mapstructure:"CODE_ODESSEY_GRPC_KEEPALIVE_TIME"`
+	GRPCKeepaliveTimeout       time.Duration                `mapstructure:"CODE_ODESSEY_GRPC_KEEPALIVE_TIMEOUT"`
+	GRPCKeepaliveMinTime       time.Duration                `mapstructure:"CODE_ODESSEY_GRPC_KEEPALIVE_MIN_TIME"`
+	GRPCMaxConnIdle            time.Duration                `mapstructure:"CODE_ODESSEY_GRPC_MAX_CONNECTION_IDLE"`
+	GRPCMaxConnAge             time.Duration                `mapstructure:"CODE_ODESSEY_GRPC_MAX_CONNECTION_AGE"`
+	GRPCMaxConnAgeGrace        time.Duration                `mapstructure:"CODE_ODESSEY_GRPC_MAX_CONNECTION_AGE_GRACE"`
+	LogPayloadSampleRate       float64                      `mapstructure:"CODE_ODESSEY_LOG_PAYLOAD_SAMPLE_RATE"`
+	LogRedactFields            []string                     `mapstructure:"CODE_ODESSEY_LOG_REDACT_FIELDS"`
+	LogSlowThreshold           time.Duration                `mapstructure:"CODE_ODESSEY_LOG_SLOW_THRESHOLD"`
+	TracingExporter            tracing.Exporter             `mapstructure:"CODE_ODESSEY_OTEL_EXPORTER"`
+	TracingEndpoint            string                       `mapstructure:"CODE_ODESSEY_OTEL_ENDPOINT"`
+	TracingInsecure            bool                         `mapstructure:"CODE_ODESSEY_OTEL_INSECURE"`
+	TracingFilePath            string                       `mapstructure:"CODE_ODESSEY_OTEL_FILE"`
+	TLSCertFile                string                       `mapstructure:"CODE_ODESSEY_TLS_CERT_FILE"`
+	TLSKeyFile                 string                       `mapstructure:"CODE_ODESSEY_TLS_KEY_FILE"`
+	TLSClientCAFile            string                       `mapstructure:"CODE_ODESSEY_TLS_CLIENT_CA_FILE"`
+	TLSMinVersion              tlsconfig.Version            `mapstructure:"CODE_ODESSEY_TLS_MIN_VERSION"`
+	TrustedProxies             []netip.Prefix               `mapstructure:"CODE_ODESSEY_TRUSTED_PROXIES"`
+	RateLimitStore             RateLimitStore               `mapstructure:"CODE_ODESSEY_RATE_LIMIT_STORE"`
+	RateLimits                 map[string]ratelimit.Limit   `mapstructure:"CODE_ODESSEY_RATE_LIMITS"`
+	RateLimitDefault           ratelimit.Limit              `mapstructure:"CODE_ODESSEY_RATE_LIMIT_DEFAULT"`
+	RateLimitPerClient         ratelimit.Limit              `mapstructure:"CODE_ODESSEY_RATE_LIMIT_PER_CLIENT"`
+	RateLimitPruneInterval     time.Duration                `mapstructure:"CODE_ODESSEY_RATE_LIMIT_PRUNE_INTERVAL"`
+	EventPublisher             EventPublisher               `mapstructure:"CODE_ODESSEY_EVENT_PUBLISHER"`
+	EventFilePath              string                       `mapstructure:"CODE_ODESSEY_EVENT_FILE"`
+	EventWorkers               int                          `mapstructure:"CODE_ODESSEY_EVENT_WORKERS"`
+	EventQueueSize             int                          `mapstructure:"CODE_ODESSEY_EVENT_QUEUE_SIZE"`
+	OutboxPollInterval         time.Duration                `mapstructure:"CODE_ODESSEY_OUTBOX_POLL_INTERVAL"`
+	OutboxBatchSize            int                          `mapstructure:"CODE_ODESSEY_OUTBOX_BATCH_SIZE"`
+	OutboxMaxAttempts          int                          `mapstructure:"CODE_ODESSEY_OUTBOX_MAX_ATTEMPTS"`
+	SessionCleanupInterval     time.Duration                `mapstructure:"CODE_ODESSEY_SESSION_CLEANUP_INTERVAL"`
+	SessionCleanupBatchSize    int                          `mapstructure:"CODE_ODESSEY_SESSION_CLEANUP_BATCH_SIZE"`
+	SessionBlockedRetention    time.Duration                `mapstructure:"CODE_ODESSEY_SESSION_BLOCKED_RETENTION"`
+	OutboxCleanupInterval      time.Duration                `mapstructure:"CODE_ODESSEY_OUTBOX_CLEANUP_INTERVAL"`
+	OutboxCleanupBatchSize     int                          `mapstructure:"CODE_ODESSEY_OUTBOX_CLEANUP_BATCH_SIZE"`
+	OutboxSentRetention        time.Duration                `mapstructure:"CODE_ODESSEY_OUTBOX_SENT_RETENTION"`
+	OutboxDeadRetention        time.Duration                `mapstructure:"CODE_ODESSEY_OUTBOX_DEAD_RETENTION"`
+}
+
+// Default returns the configuration used for values that are not set.
+func Default() Config {
+	return Config{
+		Environment:                EnvironmentProduction,
+		AppName:                    "codeodessey",
+		AccessTokenDuration:        15 * time.Minute,
+		RefreshTokenDuration:       24 * time.Hour,
+		DBDriver:                   DBDriverPostgres,
+		DBPort:                     5432,
+		DBSslMode:                  "prefer",
+		DBAutoMigrate:              true,
+		DBConnectTimeout:           30 * time.Second,
+		SQLitePath:                 "codeodessey.db",
+		RPCPort:                    8080,
+		IdempotencyCleanupInterval: time.Hour,
+		ReadYourWritesWindow:       10 * time.Second,
+		AdminPort:                  9090,
+		TracingExporter:            tracing.ExporterNone,
+		RateLimitStore:             RateLimitStoreMemory,
+		RateLimitPruneInterval:     10 * time.Minute,
+		RateLimitPerClient:         ratelimit.Limit{Rate: 20, Burst: 100},
+		EventPublisher:             EventPublisherLog,
+		EventFilePath:              "events.jsonl",
+		SessionCleanupInterval:     time.Hour,
+		OutboxCleanupInterval:      time.Hour,
+	}
 }
 
 func LoadConfig(path string) (config Config, err error) {
+	v := viper.New()
+
 	// Add the directory where the .env file is located
-	viper.AddConfigPath(path)
+	v.AddConfigPath(path)
 
 	// For docker build
-	viper.AddConfigPath("/app")
+	v.AddConfigPath("/app")
 
-	viper.SetConfigName(".env")
+	v.SetConfigName(".env")
 
 	// Set the config type to env
-	viper.SetConfigType("env")
+	v.SetConfigType("env")
 
 	// Automatically override values with environment variables
-	viper.AutomaticEnv()
+	v.AutomaticEnv()
 
 	// Read in the config file
-	err = viper.ReadInConfig()
+	err = v.ReadInConfig()
 	if err != nil {
 		return Config{}, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Unmarshal the config into the Config struct
-	err = viper.Unmarshal(&config)
+	// Unmarshal the config over the defaults
+	config = Default()
+	err = v.Unmarshal(&config, viper.DecodeHook(decodeHook))
 	if err != nil {
 		return Config{}, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 	return config, nil
 }
+
+var stringsType = reflect.TypeOf([]string(nil))
+
+// parsers convert the strings read from the environment to the types of the
+// fields holding them.
+var parsers = map[reflect.Type]func(raw string) (interface{}, error){
+	reflect.TypeOf(time.Duration(0)): func(raw string) (interface{}, error) {
+		return time.ParseDuration(raw)
+	},
+	reflect.TypeOf(deadline.Deadline{}): func(raw string) (interface{}, error) {
+		return deadline.ParseDeadline(raw)
+	},
+	reflect.TypeOf(map[string]deadline.Deadline(nil)): func(raw string) (interface{}, error) {
+		return deadline.ParseDeadlines(raw)
+	},
+	reflect.TypeOf(ratelimit.Limit{}): func(raw string) (interface{}, error) {
+		return ratelimit.ParseLimit(raw)
+	},
+	reflect.TypeOf(map[string]ratelimit.Limit(nil)): func(raw string) (interface{}, error) {
+		return ratelimit.ParseLimits(raw)
+	},
+	reflect.TypeOf(tlsconfig.Version(0)): func(raw string) (interface{}, error) {
+		return tlsconfig.ParseVersion(raw)
+	},
+	reflect.TypeOf([]netip.Prefix(nil)): func(raw string) (interface{}, error) {
+		return trustedproxy.Parse(raw)
+	},
+}
+
+// decodeHook converts the strings read from the environment. Empty strings
+// keep the current, default value, lists are comma-separated and the types
+// in [parsers] are parsed by their package.
+func decodeHook(from, to reflect.Value) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return from.Interface(), nil
+	}
+	raw := strings.TrimSpace(from.String())
+
+	if parse, ok := parsers[to.Type()]; ok && raw != "" {
+		return parse(raw)
+	}
+	switch {
+	case raw == "" && to.Type() != stringsType:
+		return to.Interface(), nil
+	case to.Type() == stringsType:
+		return splitList(raw), nil
+	default:
+		return raw, nil
+	}
+}
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(raw string) []string {
+	values := []string{}
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// ValidationError lists every problem found by [Config.Validate].
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the whole configuration and returns a [*ValidationError]
+// listing every problem found, or nil if there are none.
+func (c Config) Validate() error {
+	var problems []string
+	addf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	nonNegative := func(name string, d time.Duration) {
+		if d < 0 {
+			addf("%s %s is negative", name, d)
+		}
+	}
+
+	switch c.Environment {
+	case EnvironmentDevelopment, EnvironmentTest, EnvironmentProduction:
+	default:
+		addf("unknown environment %q", c.Environment)
+	}
+	if c.AppName == "" {
+		addf("app name is empty")
+	}
+
+	if c.AccessTokenDuration <= 0 {
+		addf("access token duration %s is not positive", c.AccessTokenDuration)
+	}
+	if c.RefreshTokenDuration <= 0 {
+		addf("refresh token duration %s is not positive", c.RefreshTokenDuration)
+	}
+	if c.RefreshTokenDuration < c.AccessTokenDuration {
+		addf(
+			"refresh token duration %s is shorter than access token duration %s",
+			c.RefreshTokenDuration,
+			c.AccessTokenDuration,
+		)
+	}
+
+	switch c.DBDriver {
+	case DBDriverPostgres:
+		if c.DBHost == "" {
+			addf("database host is empty")
+		}
+		if c.DBName == "" {
+			addf("database name is empty")
+		}
+		if c.DBUser == "" {
+			addf("database user is empty")
+		}
+		if !validPort(c.DBPort) {
+			addf("invalid database port %d", c.DBPort)
+		}
+		if c.DBReplicaPort != 0 && !validPort(c.DBReplicaPort) {
+			addf("invalid database replica port %d", c.DBReplicaPort)
+		}
+		switch c.DBSslMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			addf("unknown database SSL mode %q", c.DBSslMode)
+		}
+		if c.DBMaxConns < 0 || c.DBMaxConns > math.MaxInt32 {
+			addf("invalid maximum database connections %d", c.DBMaxConns)
+		}
+		if c.DBMinConns < 0 || c.DBMinConns > math.MaxInt32 {
+			addf("invalid minimum database connections %d", c.DBMinConns)
+		}
+		if c.DBMaxConns > 0 && c.DBMinConns > c.DBMaxConns {
+			addf(
+				"minimum database connections %d exceed the maximum %d",
+				c.DBMinConns,
+				c.DBMaxConns,
+			)
+		}
+		nonNegative("database connection lifetime", c.DBMaxConnLifetime)
+		nonNegative("database connection idle time", c.DBMaxConnIdleTime)
+		nonNegative("database health check period", c.DBHealthCheckPeriod)
+		nonNegative("database statement timeout", c.DBStatementTimeout)
+		if c.DBConnectTimeout <= 0 {
+			addf("database connect timeout %s is not positive", c.DBConnectTimeout)
+		}
+		if c.DBTxMaxAttempts < 0 {
+			addf("database transaction max attempts %d is negative", c.DBTxMaxAttempts)
+		}
+	case DBDriverSQLite:
+		if c.SQLitePath == "" {
+			addf("sqlite path is empty")
+		}
+	case DBDriverMemory:
+	default:
+		addf("unknown database driver %q", c.DBDriver)
+	}
+
+	if !validPort(c.RPCPort) {
+		addf("invalid port %d", c.RPCPort)
+	}
+	if !validPort(c.AdminPort) {
+		addf("invalid admin port %d", c.AdminPort)
+	}
+	if c.RPCPort == c.AdminPort {
+		addf("port and admin port are both %d", c.RPCPort)
+	}
+
+	nonNegative("idempotency TTL", c.IdempotencyTTL)
+	nonNegative("idempotency lease", c.IdempotencyLease)
+	if c.IdempotencyCleanupInterval <= 0 {
+		addf("idempotency cleanup interval %s is not positive", c.IdempotencyCleanupInterval)
+	}
+	nonNegative("read-your-writes window", c.ReadYourWritesWindow)
+
+	limits := []struct {
+		name  string
+		value int
+	}{
+		{"gRPC maximum received message size", c.GRPCMaxRecvMsgBytes},
+		{"gRPC maximum sent message size", c.GRPCMaxSendMsgBytes},
+		{"gRPC maximum concurrent streams", c.GRPCMaxStreams},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 || limit.value > math.MaxInt32 {
+			addf("invalid %s %d", limit.name, limit.value)
+		}
+	}
+	nonNegative("gRPC keepalive time", c.GRPCKeepaliveTime)
+	nonNegative("gRPC keepalive timeout", c.GRPCKeepaliveTimeout)
+	nonNegative("gRPC keepalive minimum time", c.GRPCKeepaliveMinTime)
+	nonNegative("gRPC maximum connection idle time", c.GRPCMaxConnIdle)
+	nonNegative("gRPC maximum connection age", c.GRPCMaxConnAge)
+	nonNegative("gRPC maximum connection age grace", c.GRPCMaxConnAgeGrace)
+
+	if c.LogPayloadSampleRate < 0 || c.LogPayloadSampleRate > 1 {
+		addf("payload sample rate %v is not between 0 and 1", c.LogPayloadSampleRate)
+	}
+	nonNegative("slow request threshold", c.LogSlowThreshold)
+
+	switch c.TracingExporter {
+	case tracing.ExporterNone, tracing.ExporterStdout:
+	case tracing.ExporterOTLP:
+		if c.TracingEndpoint == "" {
+			addf("the otlp tracing exporter requires an endpoint")
+		}
+	case tracing.ExporterFile:
+		if c.TracingFilePath == "" {
+			addf("the file tracing exporter requires a file")
+		}
+	default:
+		addf("unknown tracing exporter %q", c.TracingExporter)
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		addf("TLS certificate and key files must be set together")
+	}
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		addf("TLS client CA file requires a certificate")
+	}
+
+	switch c.RateLimitStore {
+	case RateLimitStoreMemory:
+	case RateLimitStorePostgres:
+		if c.DBDriver != DBDriverPostgres {
+			addf("rate limit store %q requires the postgres database driver", c.RateLimitStore)
+		}
+	default:
+		addf("unknown rate limit store %q", c.RateLimitStore)
+	}
+	if c.RateLimitPruneInterval <= 0 {
+		addf("rate limit prune interval %s is not positive", c.RateLimitPruneInterval)
+	}
+	if c.RateLimitPerClient.Rate <= 0 || c.RateLimitPerClient.Burst < 1 {
+		addf("per-client rate limit %v:%d is not positive", c.RateLimitPerClient.Rate, c.RateLimitPerClient.Burst)
+	}
+
+	switch c.EventPublisher {
+	case EventPublisherLog, EventPublisherBus:
+	case EventPublisherFile:
+		if c.EventFilePath == "" {
+			addf("the file event publisher requires a file")
+		}
+	default:
+		addf("unknown event publisher %q", c.EventPublisher)
+	}
+	if c.EventWorkers < 0 {
+		addf("event workers %d is negative", c.EventWorkers)
+	}
+	if c.EventQueueSize < 0 {
+		addf("event queue size %d is negative", c.EventQueueSize)
+	}
+	nonNegative("outbox poll interval", c.OutboxPollInterval)
+	if c.OutboxBatchSize < 0 {
+		addf("outbox batch size %d is negative", c.OutboxBatchSize)
+	}
+	if c.OutboxMaxAttempts < 0 {
+		addf("outbox max attempts %d is negative", c.OutboxMaxAttempts)
+	}
+
+	if c.SessionCleanupInterval <= 0 {
+		addf("session cleanup interval %s is not positive", c.SessionCleanupInterval)
+	}
+	if c.SessionCleanupBatchSize < 0 {
+		addf("session cleanup batch size %d is negative", c.SessionCleanupBatchSize)
+	}
+	nonNegative("blocked session retention", c.SessionBlockedRetention)
+
+	if c.OutboxCleanupInterval <= 0 {
+		addf("outbox cleanup interval %s is not positive", c.OutboxCleanupInterval)
+	}
+	if c.OutboxCleanupBatchSize < 0 {
+		addf("outbox cleanup batch size %d is negative", c.OutboxCleanupBatchSize)
+	}
+	nonNegative("sent outbox message retention", c.OutboxSentRetention)
+	nonNegative("dead outbox message retention", c.OutboxDeadRetention)
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= math.MaxUint16
+}
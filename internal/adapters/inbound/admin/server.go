@@ -0,0 +1,69 @@
+// Package admin provides the operational HTTP listener, which is kept separate
+// from the public gRPC port.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+type Server struct {
+	server *http.Server
+	port   int
+	logger logger.Logger
+}
+
+// NewServer returns an admin server that exposes the metrics gathered by
+// `gatherer` at /metrics.
+func NewServer(port int, gatherer prometheus.Gatherer, logger logger.Logger) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+
+	return &Server{
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+		port:   port,
+		logger: logger,
+	}
+}
+
+func (s *Server) Run() error {
+	s.logger.Info(
+		context.Background(),
+		fmt.Sprintf("admin server listening on :%d", s.port),
+		map[string]interface{}{"port": s.port},
+	)
+
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve admin: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.Error(context.Background(), err, "error shutting down admin server", nil)
+		return
+	}
+	s.logger.Info(context.Background(), "admin server stopped gracefully", nil)
+}
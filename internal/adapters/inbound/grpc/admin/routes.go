@@ -0,0 +1,69 @@
+package admin
+
+import (
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/admin/v1"
+)
+
+// Routes describes the JSON surface of the admin service, as served over the
+// Connect protocol. Field names follow the protobuf JSON mapping.
+func Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Path:        pb.AdminService_GetUserRole_FullMethodName,
+			OperationID: "getUserRole",
+			Summary:     "Get the role of a user",
+			Description: "Only admins may get roles. The response's etag is required to change the role.",
+			Tags:        []string{"Admin"},
+			Request: &openapi.Schema{
+				Type:       "object",
+				Required:   []string{"userId"},
+				Properties: map[string]*openapi.Schema{"userId": {Type: "string", Format: "uuid"}},
+			},
+			Response: &openapi.Schema{
+				Type:       "object",
+				Properties: map[string]*openapi.Schema{"userRole": openapi.Ref("UserRole")},
+			},
+		},
+		{
+			Path:        pb.AdminService_UpdateUserRole_FullMethodName,
+			OperationID: "updateUserRole",
+			Summary:     "Change the role of a user",
+			Description: "Only admins may change roles. Fails if the user was modified since the etag was read.",
+			Tags:        []string{"Admin"},
+			Request: &openapi.Schema{
+				Type:     "object",
+				Required: []string{"userId", "etag", "role"},
+				Properties: map[string]*openapi.Schema{
+					"userId": {Type: "string", Format: "uuid"},
+					"etag":   {Type: "string", Description: "The etag returned by getUserRole."},
+					"role":   roleSchema(),
+				},
+			},
+			Response: &openapi.Schema{
+				Type:       "object",
+				Properties: map[string]*openapi.Schema{"userRole": openapi.Ref("UserRole")},
+			},
+		},
+	}
+}
+
+// Schemas returns the schemas shared by [Routes].
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"UserRole": {
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"userId":    {Type: "string", Format: "uuid"},
+				"role":      roleSchema(),
+				"etag":      {Type: "string"},
+				"updatedAt": {Type: "string", Format: "date-time"},
+			},
+		},
+	}
+}
+
+func roleSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Enum: []string{domainUser.RoleReader.String(), domainUser.RoleAdmin.String()}}
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/admin/v1"
+)
+
+// TestRoutes_MatchDomainRoles checks that the request schema accepts exactly
+// the roles that the domain accepts.
+func TestRoutes_MatchDomainRoles(t *testing.T) {
+	t.Parallel()
+
+	doc := openapi.NewDocument(openapi.Spec{Routes: Routes(), Schemas: Schemas()})
+
+	for _, role := range []string{"Reader", "Admin", "Owner", "admin", ""} {
+		role := role
+		t.Run(role, func(t *testing.T) {
+			t.Parallel()
+
+			body := map[string]interface{}{
+				"userId": uuid.NewString(),
+				"etag":   etag.Random().String(),
+				"role":   role,
+			}
+			schemaErr := doc.ValidateRequest(http.MethodPost, pb.AdminService_UpdateUserRole_FullMethodName, body)
+
+			_, domainErr := domainUser.RoleStringToInt(role)
+			assert.Equal(t, domainErr == nil, schemaErr == nil, "schema error: %v", schemaErr)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package admin lets admins manage other users over gRPC.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/grpcstatus"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/admin/v1"
+)
+
+type Server struct {
+	pb.UnimplementedAdminServiceServer
+	userService inbound.UserService
+}
+
+func NewServer(userService inbound.UserService) *Server {
+	return &Server{userService: userService}
+}
+
+func (s *Server) RegisterServer(server *grpc.Server) {
+	pb.RegisterAdminServiceServer(server, s)
+}
+
+// GetUserRole returns the role of a user, along with the etag needed to change
+// it.
+func (s *Server) GetUserRole(
+	ctx context.Context,
+	req *pb.GetUserRoleRequest,
+) (*pb.GetUserRoleResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid input: userId: %v", err)
+	}
+
+	usr, err := s.userService.GetUser(ctx, userID)
+	if err != nil {
+		var notFoundErr *domainUser.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, status.Errorf(codes.NotFound, "user %q not found", userID)
+		}
+		return nil, grpcstatus.Internal(err, "failed to get user")
+	}
+
+	return &pb.GetUserRoleResponse{UserRole: userRole(usr)}, nil
+}
+
+// UpdateUserRole changes the role of a user, provided that the user has not
+// been modified since the request's etag was read.
+func (s *Server) UpdateUserRole(
+	ctx context.Context,
+	req *pb.UpdateUserRoleRequest,
+) (*pb.UpdateUserRoleResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	updateReq, err := parseUpdateUserRoleRequest(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid input: %v", err)
+	}
+
+	usr, err := s.userService.UpdateUser(ctx, updateReq)
+	if err != nil {
+		var notFoundErr *domainUser.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, status.Errorf(codes.NotFound, "user %q not found", updateReq.UserID())
+		}
+		var concurrentModificationErr *domainUser.ConcurrentModificationError
+		if errors.As(err, &concurrentModificationErr) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", concurrentModificationErr)
+		}
+		return nil, grpcstatus.Internal(err, "failed to update user role")
+	}
+
+	return &pb.UpdateUserRoleResponse{UserRole: userRole(usr)}, nil
+}
+
+func parseUpdateUserRoleRequest(req *pb.UpdateUserRoleRequest) (*domainUser.UpdateRequest, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, fmt.Errorf("userId: %w", err)
+	}
+
+	eTag, err := etag.Parse(req.GetEtag())
+	if err != nil {
+		return nil, fmt.Errorf("etag: %w", err)
+	}
+
+	role, err := domainUser.RoleStringToInt(req.GetRole())
+	if err != nil {
+		return nil, fmt.Errorf("role: %w", err)
+	}
+
+	return domainUser.ParseUpdateRequest(
+		userID,
+		eTag,
+		option.None[string](),
+		option.None[string](),
+		option.None[string](),
+		option.Some(role),
+	)
+}
+
+func requireAdmin(ctx context.Context) error {
+	payload, ok := middleware.PayloadFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "an access token is required")
+	}
+	if payload.Role != domainUser.RoleAdmin.String() {
+		return status.Error(codes.PermissionDenied, "only admins may manage users")
+	}
+	return nil
+}
+
+func userRole(usr *domainUser.User) *pb.UserRole {
+	return &pb.UserRole{
+		UserId:    usr.ID().String(),
+		Role:      usr.Role().String(),
+		Etag:      usr.ETag().String(),
+		UpdatedAt: timestamppb.New(usr.UpdatedAt()),
+	}
+}
@@ -0,0 +1,194 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	mock_inbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/admin/v1"
+)
+
+var anyError = errors.New("any error")
+
+var (
+	admin  = &auth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: domainUser.RoleAdmin.String()}
+	reader = &auth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: domainUser.RoleReader.String()}
+)
+
+func TestServer_GetUserRole(t *testing.T) {
+	t.Parallel()
+
+	usr := domainUser.RandomUser(t)
+
+	tests := []struct {
+		name     string
+		payload  *auth.Payload
+		userID   string
+		getErr   error
+		wantCode codes.Code
+	}{
+		{name: "Unauthenticated", userID: usr.ID().String(), wantCode: codes.Unauthenticated},
+		{name: "Not an admin", payload: reader, userID: usr.ID().String(), wantCode: codes.PermissionDenied},
+		{name: "Invalid user ID", payload: admin, userID: "nobody", wantCode: codes.InvalidArgument},
+		{
+			name:     "User not found",
+			payload:  admin,
+			userID:   usr.ID().String(),
+			getErr:   fmt.Errorf("get user: %w", domainUser.NewNotFoundByIDError(usr.ID())),
+			wantCode: codes.NotFound,
+		},
+		{name: "Lookup fails", payload: admin, userID: usr.ID().String(), getErr: anyError, wantCode: codes.Internal},
+		{name: "Found", payload: admin, userID: usr.ID().String()},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			users := mock_inbound.NewMockUserService(ctrl)
+			if tt.payload == admin && tt.userID == usr.ID().String() {
+				if tt.getErr != nil {
+					users.EXPECT().GetUser(gomock.Any(), usr.ID()).Return(nil, tt.getErr)
+				} else {
+					users.EXPECT().GetUser(gomock.Any(), usr.ID()).Return(usr, nil)
+				}
+			}
+
+			ctx := context.Background()
+			if tt.payload != nil {
+				ctx = middleware.ContextWithPayload(ctx, tt.payload)
+			}
+			resp, err := NewServer(users).GetUserRole(ctx, &pb.GetUserRoleRequest{UserId: tt.userID})
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, usr.ID().String(), resp.GetUserRole().GetUserId())
+			assert.Equal(t, usr.Role().String(), resp.GetUserRole().GetRole())
+			assert.Equal(t, usr.ETag().String(), resp.GetUserRole().GetEtag())
+		})
+	}
+}
+
+func TestServer_UpdateUserRole(t *testing.T) {
+	t.Parallel()
+
+	usr := domainUser.RandomUser(t)
+	validReq := func() *pb.UpdateUserRoleRequest {
+		return &pb.UpdateUserRoleRequest{
+			UserId: usr.ID().String(),
+			Etag:   usr.ETag().String(),
+			Role:   domainUser.RoleAdmin.String(),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		payload   *auth.Payload
+		req       func() *pb.UpdateUserRoleRequest
+		updateErr error
+		wantCode  codes.Code
+	}{
+		{name: "Unauthenticated", req: validReq, wantCode: codes.Unauthenticated},
+		{name: "Not an admin", payload: reader, req: validReq, wantCode: codes.PermissionDenied},
+		{
+			name:    "Invalid user ID",
+			payload: admin,
+			req: func() *pb.UpdateUserRoleRequest {
+				req := validReq()
+				req.UserId = "nobody"
+				return req
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "Invalid etag",
+			payload: admin,
+			req: func() *pb.UpdateUserRoleRequest {
+				req := validReq()
+				req.Etag = "latest"
+				return req
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "Unknown role",
+			payload: admin,
+			req: func() *pb.UpdateUserRoleRequest {
+				req := validReq()
+				req.Role = "Owner"
+				return req
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:      "User not found",
+			payload:   admin,
+			req:       validReq,
+			updateErr: fmt.Errorf("update user: %w", domainUser.NewNotFoundByIDError(usr.ID())),
+			wantCode:  codes.NotFound,
+		},
+		{
+			name:      "Modified since read",
+			payload:   admin,
+			req:       validReq,
+			updateErr: fmt.Errorf("update user: %w", &domainUser.ConcurrentModificationError{ID: usr.ID(), ETag: usr.ETag()}),
+			wantCode:  codes.FailedPrecondition,
+		},
+		{name: "Update fails", payload: admin, req: validReq, updateErr: anyError, wantCode: codes.Internal},
+		{name: "Updated", payload: admin, req: validReq},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			users := mock_inbound.NewMockUserService(ctrl)
+			if tt.updateErr != nil || tt.wantCode == codes.OK {
+				users.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domainUser.UpdateRequest) (*domainUser.User, error) {
+						assert.Equal(t, usr.ID(), req.UserID())
+						assert.Equal(t, usr.ETag(), req.ETag())
+						assert.Equal(t, domainUser.RoleAdmin, req.Role().UnwrapOrZero())
+						assert.False(t, req.Email().IsSome())
+						if tt.updateErr != nil {
+							return nil, tt.updateErr
+						}
+						return usr, nil
+					})
+			}
+
+			ctx := context.Background()
+			if tt.payload != nil {
+				ctx = middleware.ContextWithPayload(ctx, tt.payload)
+			}
+			resp, err := NewServer(users).UpdateUserRole(ctx, tt.req())
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, usr.ID().String(), resp.GetUserRole().GetUserId())
+		})
+	}
+}
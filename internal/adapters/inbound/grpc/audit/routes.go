@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/audit/v1"
+)
+
+// Routes describes the JSON surface of the audit service, as served over the
+// Connect protocol. Field names follow the protobuf JSON mapping, which
+// encodes 64-bit integers as strings.
+func Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Path:        pb.AuditService_QueryEvents_FullMethodName,
+			OperationID: "queryAuditEvents",
+			Summary:     "Query the audit log",
+			Description: "Only admins may query the audit log, and every query is itself recorded.",
+			Tags:        []string{"Audit"},
+			Request: &openapi.Schema{
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"actorId": {Type: "string", Format: "uuid"},
+					"target":  {Type: "string"},
+					"from":    {Type: "string", Format: "date-time", Description: "Inclusive."},
+					"to":      {Type: "string", Format: "date-time", Description: "Exclusive."},
+					"afterSequence": sequenceSchema(
+						"Returns entries after this sequence. Pass the nextCursor of the previous page to get the next one.",
+					),
+					"beforeSequence": sequenceSchema(
+						"Returns entries before this sequence. Pass the nextCursor of the previous page to get the next one when newestFirst is set.",
+					),
+					"newestFirst": {Type: "boolean", Description: "Orders entries by descending rather than ascending sequence."},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Defaults to %d, and is clamped to %d.", audit.DefaultQueryLimit, audit.MaxQueryLimit),
+					},
+				},
+			},
+			Response: &openapi.Schema{
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"events": {Type: "array", Items: openapi.Ref("AuditEvent")},
+					"nextCursor": sequenceSchema(
+						"The afterSequence, or beforeSequence when newestFirst is set, of the next page. Absent if no entries follow.",
+					),
+				},
+			},
+		},
+	}
+}
+
+// Schemas returns the schemas shared by [Routes].
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"AuditEvent": {
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"sequence":   sequenceSchema("The entry's position in the hash chain, starting at 1."),
+				"id":         {Type: "string", Format: "uuid"},
+				"action":     {Type: "string"},
+				"actorId":    {Type: "string", Format: "uuid", Description: "Absent if the action was taken by an unauthenticated caller."},
+				"target":     {Type: "string"},
+				"clientIp":   {Type: "string"},
+				"userAgent":  {Type: "string"},
+				"requestId":  {Type: "string"},
+				"details":    {Type: "object"},
+				"occurredAt": {Type: "string", Format: "date-time"},
+				"prevHash":   {Type: "string", Description: "The hex-encoded hash of the previous entry."},
+				"hash":       {Type: "string", Description: "The hex-encoded hash of the entry."},
+			},
+		},
+	}
+}
+
+// sequenceSchema describes a sequence of the audit log, which is an int64 and
+// so encoded as a string.
+func sequenceSchema(description string) *openapi.Schema {
+	return &openapi.Schema{Type: "string", Pattern: "^[0-9]+$", Description: description}
+}
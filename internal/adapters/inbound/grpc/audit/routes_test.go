@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/audit/v1"
+)
+
+// TestRoutes_MatchParseFilter checks that the request schema accepts exactly
+// the sequences that parseFilter accepts.
+func TestRoutes_MatchParseFilter(t *testing.T) {
+	t.Parallel()
+
+	doc := openapi.NewDocument(openapi.Spec{Routes: Routes(), Schemas: Schemas()})
+
+	testCases := []struct {
+		name      string
+		body      map[string]interface{}
+		wantValid bool
+	}{
+		{"Empty", map[string]interface{}{}, true},
+		{"After sequence", map[string]interface{}{"afterSequence": "42"}, true},
+		{"Negative after sequence", map[string]interface{}{"afterSequence": "-1"}, false},
+		{"Before sequence", map[string]interface{}{"beforeSequence": "42", "newestFirst": true}, true},
+		{"Non-numeric before sequence", map[string]interface{}{"beforeSequence": "latest"}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := doc.ValidateRequest(http.MethodPost, pb.AuditService_QueryEvents_FullMethodName, tc.body)
+			assert.Equal(t, tc.wantValid, err == nil, "schema error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package audit serves the audit log to admins over gRPC.
+package audit
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/grpcstatus"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/audit/v1"
+)
+
+type Server struct {
+	pb.UnimplementedAuditServiceServer
+	auditService *audit.AuditService
+}
+
+func NewServer(auditService *audit.AuditService) *Server {
+	return &Server{auditService: auditService}
+}
+
+func (s *Server) RegisterServer(server *grpc.Server) {
+	pb.RegisterAuditServiceServer(server, s)
+}
+
+// QueryEvents returns the page of audit log entries matching the request.
+// Only admins may query the log.
+func (s *Server) QueryEvents(ctx context.Context, req *pb.QueryEventsRequest) (*pb.QueryEventsResponse, error) {
+	payload, ok := middleware.PayloadFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "an access token is required")
+	}
+	if payload.Role != user.RoleAdmin.String() {
+		return nil, status.Error(codes.PermissionDenied, "only admins may query the audit log")
+	}
+
+	filter, err := parseFilter(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid input: %v", err)
+	}
+
+	page, err := s.auditService.Query(ctx, filter)
+	if err != nil {
+		return nil, grpcstatus.Internal(err, "failed to query audit log")
+	}
+
+	events := make([]*pb.AuditEvent, 0, len(page.Entries))
+	for _, entry := range page.Entries {
+		events = append(events, auditEvent(entry))
+	}
+	return &pb.QueryEventsResponse{Events: events, NextCursor: page.NextCursor}, nil
+}
+
+func parseFilter(req *pb.QueryEventsRequest) (domainAudit.Filter, error) {
+	var filter domainAudit.Filter
+
+	if req.GetActorId() != "" {
+		actorID, err := uuid.Parse(req.GetActorId())
+		if err != nil {
+			return filter, fmt.Errorf("actorId: %w", err)
+		}
+		filter.ActorID = option.Some(actorID)
+	}
+	if req.GetTarget() != "" {
+		filter.Target = option.Some(req.GetTarget())
+	}
+	bounds := []struct {
+		name string
+		ts   *timestamppb.Timestamp
+		dst  *time.Time
+	}{{"from", req.GetFrom(), &filter.From}, {"to", req.GetTo(), &filter.To}}
+	for _, bound := range bounds {
+		if bound.ts == nil {
+			continue
+		}
+		if err := bound.ts.CheckValid(); err != nil {
+			return filter, fmt.Errorf("%s: %w", bound.name, err)
+		}
+		*bound.dst = bound.ts.AsTime()
+	}
+	if req.GetAfterSequence() < 0 {
+		return filter, fmt.Errorf("afterSequence: %d is negative", req.GetAfterSequence())
+	}
+	filter.AfterSequence = req.GetAfterSequence()
+	if req.GetBeforeSequence() < 0 {
+		return filter, fmt.Errorf("beforeSequence: %d is negative", req.GetBeforeSequence())
+	}
+	filter.BeforeSequence = req.GetBeforeSequence()
+	filter.NewestFirst = req.GetNewestFirst()
+	if req.GetLimit() < 0 {
+		return filter, fmt.Errorf("limit: %d is negative", req.GetLimit())
+	}
+	filter.Limit = int(req.GetLimit())
+
+	return filter, nil
+}
+
+func auditEvent(entry *domainAudit.Entry) *pb.AuditEvent {
+	var actorID string
+	if entry.ActorID != uuid.Nil {
+		actorID = entry.ActorID.String()
+	}
+
+	return &pb.AuditEvent{
+		Sequence:   entry.Sequence,
+		Id:         entry.ID.String(),
+		Action:     string(entry.Action),
+		ActorId:    actorID,
+		Target:     entry.Target,
+		ClientIp:   entry.ClientIP,
+		UserAgent:  entry.UserAgent,
+		RequestId:  entry.RequestID,
+		Details:    entry.Details,
+		OccurredAt: timestamppb.New(entry.OccurredAt),
+		PrevHash:   hex.EncodeToString(entry.PrevHash),
+		Hash:       hex.EncodeToString(entry.Hash),
+	}
+}
@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/audit/v1"
+)
+
+func TestServer_QueryEvents(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	admin := &auth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: user.RoleAdmin.String()}
+	reader := &auth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: user.RoleReader.String()}
+
+	entries := make([]*domainAudit.Entry, 2)
+	for i := range entries {
+		entries[i] = domainAudit.NewEntry(
+			domainAudit.ActionLoginSucceeded,
+			domainAudit.Source{ActorID: actorID},
+			domainAudit.UserTarget(actorID),
+			nil,
+			time.Now(),
+		)
+		entries[i].Seal(int64(i), nil)
+	}
+
+	tests := []struct {
+		name       string
+		payload    *auth.Payload
+		req        *pb.QueryEventsRequest
+		wantCode   codes.Code
+		wantFilter domainAudit.Filter
+		wantEvents int
+		wantCursor int64
+	}{
+		{
+			name:     "Unauthenticated",
+			req:      &pb.QueryEventsRequest{},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "Not an admin",
+			payload:  reader,
+			req:      &pb.QueryEventsRequest{},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "Invalid actor",
+			payload:  admin,
+			req:      &pb.QueryEventsRequest{ActorId: "nobody"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Invalid limit",
+			payload:  admin,
+			req:      &pb.QueryEventsRequest{Limit: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Invalid sequence",
+			payload:  admin,
+			req:      &pb.QueryEventsRequest{AfterSequence: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:       "By actor",
+			payload:    admin,
+			req:        &pb.QueryEventsRequest{ActorId: actorID.String()},
+			wantFilter: domainAudit.Filter{ActorID: option.Some(actorID), Limit: audit.DefaultQueryLimit},
+			wantEvents: 2,
+		},
+		{
+			name:    "By actor and time range",
+			payload: admin,
+			req: &pb.QueryEventsRequest{
+				Target: "audit_log",
+				From:   timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				To:     timestamppb.New(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Limit:  1,
+			},
+			wantFilter: domainAudit.Filter{
+				Target: option.Some("audit_log"),
+				From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Limit:  1,
+			},
+			wantEvents: 1,
+			wantCursor: 1,
+		},
+		{
+			name:    "Page of the newest entries",
+			payload: admin,
+			req: &pb.QueryEventsRequest{
+				BeforeSequence: 10,
+				NewestFirst:    true,
+			},
+			wantFilter: domainAudit.Filter{
+				BeforeSequence: 10,
+				NewestFirst:    true,
+				Limit:          audit.DefaultQueryLimit,
+			},
+			wantEvents: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+			if tt.wantCode == codes.OK {
+				auditLog.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+						assert.Equal(t, domainAudit.ActionAuditQueried, entry.Action)
+						assert.Equal(t, tt.payload.UserID, entry.ActorID)
+						return nil
+					})
+				// The service asks for one more entry than the limit.
+				query := tt.wantFilter
+				query.Limit++
+				auditLog.EXPECT().
+					Query(gomock.Any(), query).
+					Return(entries, nil)
+			}
+			auditService := audit.NewAuditService(auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+
+			ctx := context.Background()
+			if tt.payload != nil {
+				ctx = middleware.ContextWithPayload(ctx, tt.payload)
+				ctx = domainAudit.WithSource(ctx, domainAudit.Source{ActorID: tt.payload.UserID})
+			}
+			resp, err := NewServer(auditService).QueryEvents(ctx, tt.req)
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, resp.GetEvents(), tt.wantEvents)
+			first := resp.GetEvents()[0]
+			assert.Equal(t, string(domainAudit.ActionLoginSucceeded), first.GetAction())
+			assert.Equal(t, actorID.String(), first.GetActorId())
+			assert.Equal(t, int64(1), first.GetSequence())
+			assert.Len(t, first.GetHash(), 64)
+			assert.Equal(t, tt.wantCursor, resp.GetNextCursor())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/auth/v1"
+)
+
+// Routes describes the JSON surface of the auth service, as served over the
+// Connect protocol. Field names follow the protobuf JSON mapping.
+func Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Path:        pb.AuthService_RenewAccessToken_FullMethodName,
+			OperationID: "renewAccessToken",
+			Summary:     "Renew an access token",
+			Description: "Issues a new access token to the holder of a refresh token whose session is still valid.",
+			Tags:        []string{"Authentication"},
+			Request: &openapi.Schema{
+				Type:     "object",
+				Required: []string{"refreshToken"},
+				Properties: map[string]*openapi.Schema{
+					"refreshToken": {Type: "string", MinLength: openapi.Int(1), WriteOnly: true},
+				},
+			},
+			Response: &openapi.Schema{
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"accessToken":          {Type: "string"},
+					"accessTokenExpiresAt": {Type: "string", Format: "date-time"},
+				},
+			},
+		},
+		{
+			Path:        pb.AuthService_RevokeSession_FullMethodName,
+			OperationID: "revokeSession",
+			Summary:     "Revoke a session",
+			Description: "Users may revoke their own sessions, and admins any session.",
+			Tags:        []string{"Authentication"},
+			Request: &openapi.Schema{
+				Type:     "object",
+				Required: []string{"sessionId"},
+				Properties: map[string]*openapi.Schema{
+					"sessionId": {Type: "string", Format: "uuid"},
+				},
+			},
+			Response: &openapi.Schema{Type: "object"},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+// Package auth serves token renewal and session revocation over gRPC.
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/grpcstatus"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session"
+	domainAuth "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/auth/v1"
+)
+
+type Server struct {
+	pb.UnimplementedAuthServiceServer
+	authService    *auth.AuthService
+	sessionService *session.SessionService
+	config         config.Config
+}
+
+func NewServer(
+	authService *auth.AuthService,
+	sessionService *session.SessionService,
+	cfg config.Config,
+) *Server {
+	return &Server{
+		authService:    authService,
+		sessionService: sessionService,
+		config:         cfg,
+	}
+}
+
+func (s *Server) RegisterServer(server *grpc.Server) {
+	pb.RegisterAuthServiceServer(server, s)
+}
+
+// RenewAccessToken issues a new access token to the holder of a refresh token
+// whose session is still valid.
+func (s *Server) RenewAccessToken(
+	ctx context.Context,
+	req *pb.RenewAccessTokenRequest,
+) (*pb.RenewAccessTokenResponse, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid input: refreshToken is required")
+	}
+
+	accessToken, accessPayload, err := s.authService.RenewAccessToken(
+		ctx,
+		req.GetRefreshToken(),
+		s.config.AccessTokenDuration,
+	)
+	if err != nil {
+		if isRefreshRejected(err) {
+			return nil, status.Errorf(codes.Unauthenticated, "refresh token rejected: %v", err)
+		}
+		return nil, grpcstatus.Internal(err, "failed to renew access token")
+	}
+
+	return &pb.RenewAccessTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(accessPayload.ExpiredAt),
+	}, nil
+}
+
+// isRefreshRejected reports whether `err` means that the refresh token may not
+// be used, rather than that renewal failed unexpectedly.
+func isRefreshRejected(err error) bool {
+	if errors.Is(err, domainAuth.ErrSessionNotFound) {
+		return true
+	}
+
+	var notFoundErr *user.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return true
+	}
+
+	var validationErr *domainAuth.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	switch validationErr.Field {
+	case domainAuth.TokenFieldType, domainAuth.ExpiredTokenFieldtype, domainAuth.SessionFieldType:
+		return true
+	default:
+		return false
+	}
+}
+
+// RevokeSession deletes a session, so that its refresh token can no longer be
+// used. Users may revoke their own sessions, and admins any session.
+func (s *Server) RevokeSession(
+	ctx context.Context,
+	req *pb.RevokeSessionRequest,
+) (*pb.RevokeSessionResponse, error) {
+	payload, ok := middleware.PayloadFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "an access token is required")
+	}
+
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid input: sessionId: %v", err)
+	}
+
+	existing, err := s.sessionService.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domainAuth.ErrSessionNotFound) {
+			return nil, status.Errorf(codes.NotFound, "session %q not found", sessionID)
+		}
+		return nil, grpcstatus.Internal(err, "failed to get session")
+	}
+	if existing.UserID != payload.UserID && payload.Role != user.RoleAdmin.String() {
+		return nil, status.Error(codes.PermissionDenied, "only admins may revoke the sessions of other users")
+	}
+
+	if err := s.sessionService.DeleteSession(ctx, sessionID); err != nil {
+		return nil, grpcstatus.Internal(err, "failed to revoke session")
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	domainAuth "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	mock_inbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	pb "github.com/teamkweku/code-odessey-hex-arch/protogen/go/auth/v1"
+)
+
+var anyError = errors.New("any error")
+
+// mocks are the ports behind a [Server].
+type mocks struct {
+	tokens   *mock_inbound.MockTokenService
+	users    *mock_inbound.MockUserService
+	sessions *mock_outbound.MockSessionRepository
+	uow      *mock_outbound.MockUnitOfWork
+	auditLog *mock_outbound.MockAuditLogger
+}
+
+func newServer(ctrl *gomock.Controller) (*Server, mocks) {
+	m := mocks{
+		tokens:   mock_inbound.NewMockTokenService(ctrl),
+		users:    mock_inbound.NewMockUserService(ctrl),
+		sessions: mock_outbound.NewMockSessionRepository(ctrl),
+		uow:      mock_outbound.NewMockUnitOfWork(ctrl),
+		auditLog: mock_outbound.NewMockAuditLogger(ctrl),
+	}
+
+	auditService := audit.NewAuditService(m.auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+	sessionService := session.NewSessionService(m.sessions, m.uow, mock_outbound.NewMockMetrics(ctrl), auditService)
+	authService := auth.NewAuthService(m.tokens, sessionService, m.users, auditService)
+
+	return NewServer(authService, sessionService, config.Config{AccessTokenDuration: time.Minute}), m
+}
+
+func TestServer_RenewAccessToken(t *testing.T) {
+	t.Parallel()
+
+	const refreshToken = "refresh-token"
+	usr := domainUser.RandomUser(t)
+	refreshPayload := &domainAuth.Payload{ID: uuid.New(), UserID: usr.ID(), ExpiredAt: time.Now().Add(time.Hour)}
+	accessPayload := &domainAuth.Payload{ID: uuid.New(), UserID: usr.ID(), ExpiredAt: time.Now().Add(time.Minute)}
+	validSession := &domainAuth.Sessions{
+		ID:           refreshPayload.ID,
+		UserID:       usr.ID(),
+		RefreshToken: refreshToken,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+	}
+
+	tests := []struct {
+		name       string
+		req        *pb.RenewAccessTokenRequest
+		verifyErr  error
+		session    *domainAuth.Sessions
+		sessionErr error
+		wantCode   codes.Code
+	}{
+		{
+			name:     "Missing refresh token",
+			req:      &pb.RenewAccessTokenRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:      "Invalid refresh token",
+			req:       &pb.RenewAccessTokenRequest{RefreshToken: refreshToken},
+			verifyErr: fmt.Errorf("invalid token: %w", domainAuth.NewInvalidTokenError("token error")),
+			wantCode:  codes.Unauthenticated,
+		},
+		{
+			name:       "Session not found",
+			req:        &pb.RenewAccessTokenRequest{RefreshToken: refreshToken},
+			sessionErr: fmt.Errorf("failed to get session: %w", domainAuth.ErrSessionNotFound),
+			wantCode:   codes.Unauthenticated,
+		},
+		{
+			name: "Blocked session",
+			req:  &pb.RenewAccessTokenRequest{RefreshToken: refreshToken},
+			session: &domainAuth.Sessions{
+				ID:           validSession.ID,
+				UserID:       validSession.UserID,
+				RefreshToken: validSession.RefreshToken,
+				ExpiresAt:    validSession.ExpiresAt,
+				IsBlocked:    true,
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:       "Session lookup fails",
+			req:        &pb.RenewAccessTokenRequest{RefreshToken: refreshToken},
+			sessionErr: anyError,
+			wantCode:   codes.Internal,
+		},
+		{
+			name:    "Renewed",
+			req:     &pb.RenewAccessTokenRequest{RefreshToken: refreshToken},
+			session: validSession,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			server, m := newServer(ctrl)
+
+			if tt.req.GetRefreshToken() != "" {
+				if tt.verifyErr != nil {
+					m.tokens.EXPECT().VerifyToken(refreshToken).Return(nil, tt.verifyErr)
+				} else {
+					m.tokens.EXPECT().VerifyToken(refreshToken).Return(refreshPayload, nil)
+					m.sessions.EXPECT().GetSession(gomock.Any(), refreshPayload.ID).Return(tt.session, tt.sessionErr)
+				}
+			}
+			if tt.wantCode == codes.OK {
+				m.users.EXPECT().GetUser(gomock.Any(), usr.ID()).Return(usr, nil)
+				m.tokens.EXPECT().CreateToken(usr, time.Minute).Return("access-token", accessPayload, nil)
+				m.auditLog.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+						assert.Equal(t, domainAudit.ActionTokenRefreshed, entry.Action)
+						return nil
+					})
+			}
+
+			resp, err := server.RenewAccessToken(context.Background(), tt.req)
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "access-token", resp.GetAccessToken())
+			assert.True(t, accessPayload.ExpiredAt.Equal(resp.GetAccessTokenExpiresAt().AsTime()))
+		})
+	}
+}
+
+func TestServer_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	owner := &domainAuth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: domainUser.RoleReader.String()}
+	reader := &domainAuth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: domainUser.RoleReader.String()}
+	admin := &domainAuth.Payload{ID: uuid.New(), UserID: uuid.New(), Role: domainUser.RoleAdmin.String()}
+	stored := &domainAuth.Sessions{ID: uuid.New(), UserID: owner.UserID}
+
+	tests := []struct {
+		name       string
+		payload    *domainAuth.Payload
+		sessionID  string
+		sessionErr error
+		wantCode   codes.Code
+	}{
+		{
+			name:      "Unauthenticated",
+			sessionID: stored.ID.String(),
+			wantCode:  codes.Unauthenticated,
+		},
+		{
+			name:      "Invalid session ID",
+			payload:   owner,
+			sessionID: "nobody",
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:       "Session not found",
+			payload:    owner,
+			sessionID:  stored.ID.String(),
+			sessionErr: fmt.Errorf("failed to get session: %w", domainAuth.ErrSessionNotFound),
+			wantCode:   codes.NotFound,
+		},
+		{
+			name:      "Session of another user",
+			payload:   reader,
+			sessionID: stored.ID.String(),
+			wantCode:  codes.PermissionDenied,
+		},
+		{
+			name:      "Own session",
+			payload:   owner,
+			sessionID: stored.ID.String(),
+		},
+		{
+			name:      "Admin revokes the session of another user",
+			payload:   admin,
+			sessionID: stored.ID.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			server, m := newServer(ctrl)
+
+			if tt.payload != nil && tt.sessionID == stored.ID.String() {
+				if tt.sessionErr != nil {
+					m.sessions.EXPECT().GetSession(gomock.Any(), stored.ID).Return(nil, tt.sessionErr)
+				} else {
+					m.sessions.EXPECT().GetSession(gomock.Any(), stored.ID).Return(stored, nil)
+				}
+			}
+			if tt.wantCode == codes.OK {
+				sessions := mock_outbound.NewMockSessionRepository(ctrl)
+				sessions.EXPECT().DeleteSession(gomock.Any(), stored.ID).Return(nil)
+				auditLog := mock_outbound.NewMockAuditRecorder(ctrl)
+				auditLog.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+						assert.Equal(t, domainAudit.ActionSessionRevoked, entry.Action)
+						assert.Equal(t, domainAudit.SessionTarget(stored.ID), entry.Target)
+						assert.Equal(t, tt.payload.UserID, entry.ActorID)
+						return nil
+					})
+
+				repos := mock_outbound.NewMockRepositories(ctrl)
+				repos.EXPECT().Sessions().Return(sessions).AnyTimes()
+				repos.EXPECT().AuditLog().Return(auditLog).AnyTimes()
+				m.uow.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, fn func(outbound.Repositories) error) error {
+						return fn(repos)
+					})
+			}
+
+			ctx := context.Background()
+			if tt.payload != nil {
+				ctx = middleware.ContextWithPayload(ctx, tt.payload)
+				ctx = domainAudit.WithSource(ctx, domainAudit.Source{ActorID: tt.payload.UserID})
+			}
+			_, err := server.RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: tt.sessionID})
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package grpcstatus converts errors returned by the core into gRPC statuses.
+package grpcstatus
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Internal converts an unexpected error from a downstream call into a
+// status. Errors caused by the caller's context ending are reported as
+// DeadlineExceeded or Canceled rather than Internal.
+func Internal(err error, msg string) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
@@ -0,0 +1,37 @@
+package grpcstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInternal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"Unexpected error", errors.New("connection reset"), codes.Internal},
+		{"Deadline exceeded", fmt.Errorf("get user: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"Canceled", fmt.Errorf("get user: %w", context.Canceled), codes.Canceled},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Internal(tc.err, "failed to register user")
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), "failed to register user")
+		})
+	}
+}
@@ -2,6 +2,8 @@ package metadata
 
 import (
 	"context"
+	"net"
+	"net/netip"
 	"strings"
 
 	"google.golang.org/grpc/metadata"
@@ -10,33 +12,63 @@ import (
 
 type Metadata struct {
 	UserAgent string
-	ClientIP  string
+	// ClientIP is the address of the originating client, without a port. It
+	// is invalid if the client could not be identified.
+	ClientIP netip.Addr
 }
 
-type MetadataExtractor struct{}
+// MetadataExtractor reads client details from incoming gRPC metadata.
+//
+// The client IP is taken from the connection's peer address unless that peer
+// is a trusted proxy, in which case the forwarding headers are consulted. The
+// `Forwarded` (RFC 7239) header is preferred over `X-Forwarded-For`, and both
+// are walked from right to left, skipping trusted proxies, so that addresses
+// prepended by the client itself are never believed. With no trusted proxies
+// configured, forwarding headers are ignored.
+type MetadataExtractor struct {
+	trustedProxies []netip.Prefix
+}
 
-func NewMetadataExtractor() *MetadataExtractor {
-	return &MetadataExtractor{}
+func NewMetadataExtractor(trustedProxies ...netip.Prefix) *MetadataExtractor {
+	return &MetadataExtractor{trustedProxies: trustedProxies}
 }
 
 func (me *MetadataExtractor) Extract(ctx context.Context) Metadata {
 	md := Metadata{}
 
-	if grpcMd, ok := metadata.FromIncomingContext(ctx); ok {
-		md.UserAgent = me.extractUserAgent(grpcMd)
-		md.ClientIP = me.extractClientIP(grpcMd)
-	}
+	grpcMd, _ := metadata.FromIncomingContext(ctx)
+	md.UserAgent = me.extractUserAgent(grpcMd)
 
-	// If ClientIP is not found in metadata, try to get it from peer info
-	if md.ClientIP == "" {
-		if p, ok := peer.FromContext(ctx); ok {
-			md.ClientIP = p.Addr.String()
+	if p, ok := peer.FromContext(ctx); ok {
+		if addr, ok := peerAddr(p.Addr); ok {
+			md.ClientIP = me.extractClientIP(grpcMd, addr)
 		}
 	}
 
 	return md
 }
 
+func (me *MetadataExtractor) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range me.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func peerAddr(addr net.Addr) (netip.Addr, bool) {
+	if addr == nil {
+		return netip.Addr{}, false
+	}
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		parsed, ok := netip.AddrFromSlice(tcpAddr.IP)
+		return normalizeAddr(parsed), ok
+	}
+	parsed, ok := parseNodeAddr(addr.String())
+	return parsed, ok
+}
+
 func (me *MetadataExtractor) extractUserAgent(md metadata.MD) string {
 	if ua := md.Get("User-Agent"); len(ua) > 0 {
 		return ua[0]
@@ -47,16 +79,62 @@ func (me *MetadataExtractor) extractUserAgent(md metadata.MD) string {
 	return ""
 }
 
-func (me *MetadataExtractor) extractClientIP(md metadata.MD) string {
-	if xff := md.Get("X-Forwarded-For"); len(xff) > 0 {
-		ips := strings.Split(xff[0], ",")
-		return strings.TrimSpace(ips[0])
+// extractClientIP identifies the client of a connection from `peer`.
+func (me *MetadataExtractor) extractClientIP(md metadata.MD, peer netip.Addr) netip.Addr {
+	if !me.isTrusted(peer) {
+		return peer
 	}
-	if xrip := md.Get("X-Real-IP"); len(xrip) > 0 {
-		return xrip[0]
+
+	chain, ok := forwardedChain(md)
+	if !ok {
+		chain = xForwardedForChain(md)
 	}
-	if ra := md.Get("RemoteAddr"); len(ra) > 0 {
-		return strings.Split(ra[0], ":")[0]
+	if len(chain) == 0 {
+		if realIP, ok := xRealIP(md); ok {
+			return realIP
+		}
+		return peer
 	}
-	return ""
+
+	// Walk from the hop nearest to us towards the client. Each trusted proxy
+	// vouches for the hop to its left; the first untrusted hop is the client.
+	client := peer
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop, ok := parseNodeAddr(chain[i])
+		if !ok {
+			// The trusted proxy to the right forwarded an obfuscated or
+			// malformed address, so it is the furthest hop we can identify.
+			return client
+		}
+		client = hop
+		if !me.isTrusted(hop) {
+			return client
+		}
+	}
+	return client
+}
+
+// xForwardedForChain returns the hops listed across all X-Forwarded-For
+// values, from the original client to the most recent proxy.
+func xForwardedForChain(md metadata.MD) []string {
+	var chain []string
+	for _, value := range md.Get("X-Forwarded-For") {
+		for _, hop := range strings.Split(value, ",") {
+			chain = append(chain, strings.TrimSpace(hop))
+		}
+	}
+	return chain
+}
+
+func xRealIP(md metadata.MD) (netip.Addr, bool) {
+	values := md.Get("X-Real-IP")
+	if len(values) == 0 {
+		return netip.Addr{}, false
+	}
+	return parseNodeAddr(values[len(values)-1])
+}
+
+// normalizeAddr strips the IPv6 zone and unmaps IPv4-mapped IPv6 addresses.
+func normalizeAddr(addr netip.Addr) netip.Addr {
+	return addr.Unmap().WithZone("")
 }
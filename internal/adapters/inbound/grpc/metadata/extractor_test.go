@@ -3,11 +3,15 @@ package metadata
 import (
 	"context"
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/trustedproxy"
 )
 
 func TestMetadataExtractor(t *testing.T) {
@@ -17,92 +21,70 @@ func TestMetadataExtractor(t *testing.T) {
 	assert.NotNil(t, extractor, "NewMetadataExtractor should return a non-nil extractor")
 }
 
+func contextFrom(peerIP string, headers ...string) context.Context {
+	ctx := context.Background()
+	if len(headers) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(headers...))
+	}
+	if peerIP != "" {
+		ctx = peer.NewContext(ctx, &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 1234},
+		})
+	}
+	return ctx
+}
+
 func TestMetadataExtractor_Extract(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name           string
-		setupContext   func() context.Context
+		ctx            context.Context
 		expectedResult Metadata
 	}{
 		{
-			name: "Extract from gRPC metadata",
-			setupContext: func() context.Context {
-				ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
-					"User-Agent": "test-agent",
-					"X-Real-IP":  "192.168.1.1",
-				}))
-				return ctx
-			},
+			name: "Extract user agent",
+			ctx:  contextFrom("", "User-Agent", "test-agent"),
 			expectedResult: Metadata{
 				UserAgent: "test-agent",
-				ClientIP:  "192.168.1.1",
 			},
 		},
 		{
-			name: "Extract from peer info",
-			setupContext: func() context.Context {
-				ctx := peer.NewContext(context.Background(), &peer.Peer{
-					Addr: &net.TCPAddr{
-						IP:   net.ParseIP("10.0.0.1"),
-						Port: 1234,
-					},
-				})
-				return ctx
-			},
+			name: "Extract from peer info without port",
+			ctx:  contextFrom("10.0.0.1"),
 			expectedResult: Metadata{
-				UserAgent: "",
-				ClientIP:  "10.0.0.1:1234",
+				ClientIP: netip.MustParseAddr("10.0.0.1"),
 			},
 		},
 		{
-			name: "Extract with X-Forwarded-For",
-			setupContext: func() context.Context {
-				ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
-					"X-Forwarded-For": "203.0.113.195, 70.41.3.18, 150.172.238.178",
-				}))
-				return ctx
-			},
+			name: "Extract IPv6 peer",
+			ctx:  contextFrom("2001:db8::1"),
 			expectedResult: Metadata{
-				UserAgent: "",
-				ClientIP:  "203.0.113.195",
+				ClientIP: netip.MustParseAddr("2001:db8::1"),
 			},
 		},
 		{
-			name: "Extract with lowercase user-agent",
-			setupContext: func() context.Context {
-				ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
-					"user-agent": "lower-case-agent",
-				}))
-				return ctx
-			},
+			name: "Ignore forwarding headers without trusted proxies",
+			ctx: contextFrom("10.0.0.1",
+				"X-Forwarded-For", "203.0.113.195",
+				"X-Real-IP", "192.168.1.1",
+				"Forwarded", "for=198.51.100.17",
+			),
 			expectedResult: Metadata{
-				UserAgent: "lower-case-agent",
-				ClientIP:  "",
+				ClientIP: netip.MustParseAddr("10.0.0.1"),
 			},
 		},
 		{
-			name: "Extract with RemoteAddr",
-			setupContext: func() context.Context {
-				ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
-					"RemoteAddr": "192.168.0.1:5678",
-				}))
-				return ctx
-			},
+			name: "Extract with lowercase user-agent",
+			ctx:  contextFrom("", "user-agent", "lower-case-agent"),
 			expectedResult: Metadata{
-				UserAgent: "",
-				ClientIP:  "192.168.0.1",
+				UserAgent: "lower-case-agent",
 			},
 		},
 		{
-			name: "Extract with empty context",
-			setupContext: func() context.Context {
-				return context.Background()
-			},
-			expectedResult: Metadata{
-				UserAgent: "",
-				ClientIP:  "",
-			},
+			name:           "Extract with empty context",
+			ctx:            context.Background(),
+			expectedResult: Metadata{},
 		},
 	}
 
@@ -111,11 +93,106 @@ func TestMetadataExtractor_Extract(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			extractor := NewMetadataExtractor()
-			ctx := tt.setupContext()
-			result := extractor.Extract(ctx)
+			result := extractor.Extract(tt.ctx)
 
 			assert.Equal(t, tt.expectedResult.UserAgent, result.UserAgent, "UserAgent mismatch")
 			assert.Equal(t, tt.expectedResult.ClientIP, result.ClientIP, "ClientIP mismatch")
 		})
 	}
 }
+
+func TestMetadataExtractor_Extract_TrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	trustedProxies, err := trustedproxy.Parse("10.0.0.0/8, 192.168.1.10, 2001:db8:ffff::/48")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "Ignores headers from untrusted peer",
+			ctx:      contextFrom("198.51.100.7", "X-Forwarded-For", "203.0.113.195"),
+			expected: "198.51.100.7",
+		},
+		{
+			name:     "Uses X-Forwarded-For from trusted peer",
+			ctx:      contextFrom("10.1.2.3", "X-Forwarded-For", "203.0.113.195"),
+			expected: "203.0.113.195",
+		},
+		{
+			name:     "Skips trusted hops right to left",
+			ctx:      contextFrom("10.1.2.3", "X-Forwarded-For", "203.0.113.195, 192.168.1.10, 10.9.9.9"),
+			expected: "203.0.113.195",
+		},
+		{
+			name:     "Ignores spoofed entries left of the first untrusted hop",
+			ctx:      contextFrom("10.1.2.3", "X-Forwarded-For", "1.1.1.1, 203.0.113.195"),
+			expected: "203.0.113.195",
+		},
+		{
+			name: "Joins repeated X-Forwarded-For headers",
+			ctx: contextFrom("10.1.2.3",
+				"X-Forwarded-For", "203.0.113.195",
+				"X-Forwarded-For", "10.9.9.9",
+			),
+			expected: "203.0.113.195",
+		},
+		{
+			name:     "Returns leftmost hop when every hop is trusted",
+			ctx:      contextFrom("10.1.2.3", "X-Forwarded-For", "10.0.0.5, 10.0.0.6"),
+			expected: "10.0.0.5",
+		},
+		{
+			name:     "Stops at malformed hop",
+			ctx:      contextFrom("10.1.2.3", "X-Forwarded-For", "203.0.113.195, garbage, 10.0.0.6"),
+			expected: "10.0.0.6",
+		},
+		{
+			name:     "Strips port and unmaps IPv4-mapped IPv6",
+			ctx:      contextFrom("10.1.2.3", "X-Forwarded-For", "[::ffff:203.0.113.195]:4711"),
+			expected: "203.0.113.195",
+		},
+		{
+			name:     "Prefers Forwarded over X-Forwarded-For",
+			ctx:      contextFrom("10.1.2.3", "Forwarded", "for=198.51.100.17;proto=https", "X-Forwarded-For", "203.0.113.195"),
+			expected: "198.51.100.17",
+		},
+		{
+			name:     "Parses quoted IPv6 Forwarded node with port",
+			ctx:      contextFrom("10.1.2.3", "Forwarded", `For="[2001:db8:cafe::17]:4711"`),
+			expected: "2001:db8:cafe::17",
+		},
+		{
+			name:     "Walks Forwarded elements right to left",
+			ctx:      contextFrom("10.1.2.3", "Forwarded", `for=192.0.2.43, for="[2001:db8:ffff::1]";by=10.1.2.3`),
+			expected: "192.0.2.43",
+		},
+		{
+			name:     "Stops at obfuscated Forwarded node",
+			ctx:      contextFrom("10.1.2.3", "Forwarded", "for=_hidden, for=10.0.0.7"),
+			expected: "10.0.0.7",
+		},
+		{
+			name:     "Falls back to X-Real-IP",
+			ctx:      contextFrom("192.168.1.10", "X-Real-IP", "203.0.113.195"),
+			expected: "203.0.113.195",
+		},
+		{
+			name:     "Falls back to peer without headers",
+			ctx:      contextFrom("10.1.2.3"),
+			expected: "10.1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			extractor := NewMetadataExtractor(trustedProxies...)
+			assert.Equal(t, tt.expected, extractor.Extract(tt.ctx).ClientIP.String())
+		})
+	}
+}
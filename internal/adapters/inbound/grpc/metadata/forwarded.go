@@ -0,0 +1,107 @@
+package metadata
+
+import (
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// forwardedChain returns the `for` parameters of every element of the RFC 7239
+// Forwarded header, from the original client to the most recent proxy. It
+// reports false if the header is absent.
+//
+// Elements without a `for` parameter are kept as empty hops so that the chain
+// stays aligned with the proxies that produced it.
+func forwardedChain(md metadata.MD) ([]string, bool) {
+	values := md.Get("Forwarded")
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	var chain []string
+	for _, value := range values {
+		for _, element := range splitQuoted(value, ',') {
+			chain = append(chain, forwardedFor(element))
+		}
+	}
+	return chain, true
+}
+
+// forwardedFor returns the unquoted value of the `for` parameter of a single
+// Forwarded element such as `for="[2001:db8::1]:4711";proto=https`.
+func forwardedFor(element string) string {
+	for _, pair := range splitQuoted(element, ';') {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "for") {
+			continue
+		}
+		return unquote(strings.TrimSpace(value))
+	}
+	return ""
+}
+
+// splitQuoted splits `s` on `sep`, ignoring separators inside double-quoted
+// strings.
+func splitQuoted(s string, sep rune) []string {
+	var parts []string
+	inQuotes := false
+	escaped := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	var builder strings.Builder
+	escaped := false
+	for _, r := range s[1 : len(s)-1] {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// parseNodeAddr parses a forwarded node: an IPv4 address or bracketed IPv6
+// address, either optionally followed by a port, or a bare IPv6 address as
+// found in X-Forwarded-For. Obfuscated identifiers such as "unknown" or
+// "_hidden" are rejected.
+func parseNodeAddr(node string) (netip.Addr, bool) {
+	node = strings.TrimSpace(node)
+	if node == "" {
+		return netip.Addr{}, false
+	}
+
+	if addr, err := netip.ParseAddr(node); err == nil {
+		return normalizeAddr(addr), true
+	}
+	if addrPort, err := netip.ParseAddrPort(node); err == nil {
+		return normalizeAddr(addrPort.Addr()), true
+	}
+	if strings.HasPrefix(node, "[") && strings.HasSuffix(node, "]") {
+		if addr, err := netip.ParseAddr(node[1 : len(node)-1]); err == nil {
+			return normalizeAddr(addr), true
+		}
+	}
+	return netip.Addr{}, false
+}
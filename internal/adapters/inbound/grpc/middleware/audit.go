@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	grpcMetadata "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+)
+
+// requestIDHeader carries the ID that correlates a request across services and
+// the audit log.
+const requestIDHeader = "x-request-id"
+
+// maxRequestIDLen bounds the length of request IDs accepted from clients.
+const maxRequestIDLen = 128
+
+// GrpcAuditSource returns an interceptor that stores the [audit.Source] of
+// each request in its context: the user authenticated by [GrpcAuth], the
+// client details reported by `extractor` and the request ID. The request ID is
+// taken from the `x-request-id` metadata, or generated if the client sent none,
+// and is returned to the client in the response header of the same name.
+func GrpcAuditSource(extractor *grpcMetadata.MetadataExtractor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := requestIDFromMetadata(ctx)
+		// SetHeader only fails outside of a gRPC stream, where there is no
+		// client to send the header to.
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+		md := extractor.Extract(ctx)
+		source := audit.Source{
+			UserAgent: md.UserAgent,
+			RequestID: requestID,
+		}
+		if md.ClientIP.IsValid() {
+			source.ClientIP = md.ClientIP.String()
+		}
+		if payload, ok := PayloadFromContext(ctx); ok {
+			source.ActorID = payload.UserID
+		}
+
+		return handler(audit.WithSource(ctx, source), req)
+	}
+}
+
+// requestIDFromMetadata returns the client's request ID if it is usable, and a
+// new one otherwise.
+func requestIDFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(requestIDHeader); len(values) > 0 && isPrintableASCII(values[0], maxRequestIDLen) {
+		return values[0]
+	}
+	return uuid.NewString()
+}
+
+func isPrintableASCII(s string, maxLen int) bool {
+	if s == "" || len(s) > maxLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	grpcMetadata "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+)
+
+func TestGrpcAuditSource(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		payload       *auth.Payload
+		wantActorID   uuid.UUID
+		wantRequestID string
+	}{
+		{
+			name:          "Authenticated with request ID",
+			md:            metadata.Pairs(requestIDHeader, "req-123", "user-agent", "test-agent"),
+			payload:       &auth.Payload{ID: uuid.New(), UserID: userID},
+			wantActorID:   userID,
+			wantRequestID: "req-123",
+		},
+		{
+			name: "Anonymous without request ID",
+			md:   metadata.Pairs("user-agent", "test-agent"),
+		},
+		{
+			name: "Unusable request ID",
+			md:   metadata.Pairs(requestIDHeader, strings.Repeat("x", maxRequestIDLen+1), "user-agent", "test-agent"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 4000}})
+			if tt.payload != nil {
+				ctx = ContextWithPayload(ctx, tt.payload)
+			}
+
+			var got audit.Source
+			interceptor := GrpcAuditSource(grpcMetadata.NewMetadataExtractor())
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = audit.SourceFromContext(ctx)
+				return nil, nil
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantActorID, got.ActorID)
+			assert.Equal(t, "192.0.2.1", got.ClientIP)
+			assert.Equal(t, "test-agent", got.UserAgent)
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, got.RequestID)
+			} else {
+				assert.NoError(t, uuid.Validate(got.RequestID), "generated request ID")
+			}
+		})
+	}
+}
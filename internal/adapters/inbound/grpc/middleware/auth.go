@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound"
+)
+
+const (
+	authorizationHeader = "authorization"
+	authorizationBearer = "bearer"
+)
+
+type (
+	payloadContextKey   struct{}
+	principalContextKey struct{}
+)
+
+// Principal identifies a peer service authenticated by a client certificate
+// that was verified during the mutual TLS handshake.
+type Principal struct {
+	// Subject is the distinguished name of the certificate's subject, such as
+	// "CN=billing,O=Code Odessey".
+	Subject string
+	// CommonName is the subject's common name.
+	CommonName string
+}
+
+// ContextWithPrincipal returns a copy of `ctx` carrying the authenticated peer
+// service.
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the peer service identified by [GrpcAuth], if
+// the caller presented a verified client certificate.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
+
+// ContextWithPayload returns a copy of `ctx` carrying the authenticated token
+// payload.
+func ContextWithPayload(ctx context.Context, payload *auth.Payload) context.Context {
+	return context.WithValue(ctx, payloadContextKey{}, payload)
+}
+
+// PayloadFromContext returns the token payload stored by [GrpcAuth], if the
+// caller presented a valid access token.
+func PayloadFromContext(ctx context.Context) (*auth.Payload, bool) {
+	payload, ok := ctx.Value(payloadContextKey{}).(*auth.Payload)
+	return payload, ok && payload != nil
+}
+
+// GrpcAuth returns an interceptor that verifies the bearer token in the
+// `authorization` metadata and stores its payload in the request context.
+// Requests without a token are passed through unauthenticated, leaving each
+// handler to decide whether authentication is required. Requests with an
+// invalid token are rejected.
+//
+// When the connection was established with a verified client certificate, the
+// certificate's subject is also stored in the context as a [Principal].
+func GrpcAuth(tokens inbound.TokenService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if principal, ok := peerPrincipal(ctx); ok {
+			ctx = ContextWithPrincipal(ctx, principal)
+		}
+
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		payload, err := tokens.VerifyToken(token)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid access token: %v", err)
+		}
+
+		return handler(ContextWithPayload(ctx, payload), req)
+	}
+}
+
+// peerPrincipal returns the subject of the leaf certificate of the peer's
+// verified chain. Certificates that were presented but not verified against
+// the client CAs are ignored.
+func peerPrincipal(ctx context.Context) (Principal, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Principal{}, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return Principal{}, false
+	}
+
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+	return Principal{
+		Subject:    leaf.Subject.String(),
+		CommonName: leaf.Subject.CommonName,
+	}, true
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, authorizationBearer) || token == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
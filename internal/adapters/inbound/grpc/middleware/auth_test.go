@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	mock_repo "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+)
+
+func TestGrpcAuth(t *testing.T) {
+	t.Parallel()
+
+	payload := &auth.Payload{ID: uuid.New(), UserID: uuid.New()}
+
+	withAuthorization := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, value))
+	}
+
+	withClientCert := func(verified bool) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing", Organization: []string{"Code Odessey"}}}
+		state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		if verified {
+			state.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: state},
+		})
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		setupMock     func(tokens *mock_repo.MockTokenService)
+		wantCode      codes.Code
+		wantPayload   *auth.Payload
+		wantPrincipal *Principal
+	}{
+		{
+			name:      "No token",
+			ctx:       context.Background(),
+			setupMock: func(tokens *mock_repo.MockTokenService) {},
+			wantCode:  codes.OK,
+		},
+		{
+			name: "Valid token",
+			ctx:  withAuthorization("Bearer valid-token"),
+			setupMock: func(tokens *mock_repo.MockTokenService) {
+				tokens.EXPECT().VerifyToken("valid-token").Return(payload, nil)
+			},
+			wantCode:    codes.OK,
+			wantPayload: payload,
+		},
+		{
+			name: "Invalid token",
+			ctx:  withAuthorization("bearer expired-token"),
+			setupMock: func(tokens *mock_repo.MockTokenService) {
+				tokens.EXPECT().VerifyToken("expired-token").Return(nil, errors.New("token has expired"))
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:      "Verified client certificate",
+			ctx:       withClientCert(true),
+			setupMock: func(tokens *mock_repo.MockTokenService) {},
+			wantCode:  codes.OK,
+			wantPrincipal: &Principal{
+				Subject:    "CN=billing,O=Code Odessey",
+				CommonName: "billing",
+			},
+		},
+		{
+			name:      "Unverified client certificate",
+			ctx:       withClientCert(false),
+			setupMock: func(tokens *mock_repo.MockTokenService) {},
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "Unsupported scheme",
+			ctx:       withAuthorization("Basic dXNlcjpwYXNz"),
+			setupMock: func(tokens *mock_repo.MockTokenService) {},
+			wantCode:  codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tokens := mock_repo.NewMockTokenService(ctrl)
+			tt.setupMock(tokens)
+
+			var (
+				gotPayload   *auth.Payload
+				gotPrincipal *Principal
+			)
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotPayload, _ = PayloadFromContext(ctx)
+				if principal, ok := PrincipalFromContext(ctx); ok {
+					gotPrincipal = &principal
+				}
+				return "ok", nil
+			}
+
+			_, err := GrpcAuth(tokens)(tt.ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantPayload, gotPayload)
+			assert.Equal(t, tt.wantPrincipal, gotPrincipal)
+		})
+	}
+}
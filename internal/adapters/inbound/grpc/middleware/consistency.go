@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// defaultReadYourWritesWindow applies when [ReadYourWritesOptions] sets no
+// window.
+const defaultReadYourWritesWindow = 10 * time.Second
+
+// ReadYourWritesOptions configures [GrpcReadYourWrites].
+type ReadYourWritesOptions struct {
+	// Window is how long after a user's write their reads must observe every
+	// committed write. It should exceed the replication lag of the read
+	// replica. Zero means 10 seconds.
+	Window time.Duration
+	// ReadOnlyMethods lists the full gRPC method names that never write. Every
+	// other method is treated as a write.
+	ReadOnlyMethods []string
+}
+
+// GrpcReadYourWrites returns an interceptor that lets users see their own
+// changes, such as a profile update, straight away. Once a user calls a
+// method that may write, their requests within the window afterwards read
+// every committed write; other requests may be served by a read replica. It
+// must run after [GrpcAuth]. Only users' own writes are tracked, and only by
+// this server, so a request routed to another instance may still read from
+// the replica.
+func GrpcReadYourWrites(opts ReadYourWritesOptions) grpc.UnaryServerInterceptor {
+	window := opts.Window
+	if window <= 0 {
+		window = defaultReadYourWritesWindow
+	}
+
+	readOnly := make(map[string]bool, len(opts.ReadOnlyMethods))
+	for _, method := range opts.ReadOnlyMethods {
+		readOnly[method] = true
+	}
+
+	writes := newRecentWrites(window, time.Now)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		payload, ok := PayloadFromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if writes.recent(payload.UserID) {
+			ctx = outbound.WithReadYourWrites(ctx)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// Failed requests are recorded too, since their writes may have
+		// committed before the failure.
+		if !readOnly[info.FullMethod] {
+			writes.record(payload.UserID)
+		}
+
+		return resp, err
+	}
+}
+
+// recentWrites tracks when users last wrote, forgetting writes older than the
+// window.
+type recentWrites struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	writes    map[uuid.UUID]time.Time
+	lastSwept time.Time
+}
+
+func newRecentWrites(window time.Duration, now func() time.Time) *recentWrites {
+	return &recentWrites{
+		window:    window,
+		now:       now,
+		writes:    make(map[uuid.UUID]time.Time),
+		lastSwept: now(),
+	}
+}
+
+// recent reports whether `userID` wrote within the window.
+func (w *recentWrites) recent(userID uuid.UUID) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wroteAt, ok := w.writes[userID]
+	return ok && w.now().Sub(wroteAt) < w.window
+}
+
+// record notes that `userID` wrote just now. Writes older than the window are
+// swept at most once per window, keeping the map to the users active in the
+// last two windows.
+func (w *recentWrites) record(userID uuid.UUID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	w.writes[userID] = now
+
+	if now.Sub(w.lastSwept) < w.window {
+		return
+	}
+	for id, wroteAt := range w.writes {
+		if now.Sub(wroteAt) >= w.window {
+			delete(w.writes, id)
+		}
+	}
+	w.lastSwept = now
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+func TestGrpcReadYourWrites(t *testing.T) {
+	t.Parallel()
+
+	const (
+		readMethod  = "/user.UserService/GetUser"
+		writeMethod = "/user.UserService/UpdateUser"
+	)
+
+	alice := ContextWithPayload(context.Background(), &auth.Payload{UserID: uuid.New()})
+	bob := ContextWithPayload(context.Background(), &auth.Payload{UserID: uuid.New()})
+
+	type call struct {
+		ctx     context.Context
+		method  string
+		err     error
+		primary bool
+	}
+
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "Reads without a prior write may use the replica",
+			calls: []call{
+				{ctx: alice, method: readMethod},
+				{ctx: alice, method: readMethod},
+			},
+		},
+		{
+			name: "Requests after a user's write read their writes",
+			calls: []call{
+				{ctx: alice, method: writeMethod},
+				{ctx: alice, method: readMethod, primary: true},
+				{ctx: bob, method: readMethod},
+			},
+		},
+		{
+			name: "Failed writes are tracked",
+			calls: []call{
+				{ctx: alice, method: writeMethod, err: errors.New("commit unacknowledged")},
+				{ctx: alice, method: readMethod, primary: true},
+			},
+		},
+		{
+			name: "Peer services and anonymous callers are not tracked",
+			calls: []call{
+				{ctx: ContextWithPrincipal(context.Background(), Principal{CommonName: "billing"}), method: writeMethod},
+				{ctx: context.Background(), method: writeMethod},
+				{ctx: context.Background(), method: readMethod},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interceptor := GrpcReadYourWrites(ReadYourWritesOptions{ReadOnlyMethods: []string{readMethod}})
+
+			for i, c := range tt.calls {
+				var primary bool
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					primary = outbound.ReadYourWrites(ctx)
+					return req, c.err
+				}
+
+				resp, err := interceptor(c.ctx, "request", &grpc.UnaryServerInfo{FullMethod: c.method}, handler)
+				require.Equal(t, c.err, err)
+				assert.Equal(t, "request", resp)
+				assert.Equal(t, c.primary, primary, "call %d", i)
+			}
+		})
+	}
+}
+
+func TestRecentWrites(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	writes := newRecentWrites(time.Minute, func() time.Time { return now })
+	alice, bob := uuid.New(), uuid.New()
+
+	writes.record(alice)
+	assert.True(t, writes.recent(alice))
+	assert.False(t, writes.recent(bob))
+
+	now = now.Add(time.Minute)
+	assert.False(t, writes.recent(alice), "writes are forgotten after the window")
+
+	writes.record(bob)
+	assert.NotContains(t, writes.writes, alice, "old writes are swept")
+	assert.Contains(t, writes.writes, bob)
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/deadline"
+)
+
+// DeadlineOptions configures [GrpcDeadline].
+type DeadlineOptions struct {
+	// Methods maps full gRPC method names to their deadline.
+	Methods map[string]deadline.Deadline
+	// Default applies to methods without an entry in Methods.
+	Default deadline.Deadline
+}
+
+func (o DeadlineOptions) forMethod(method string) deadline.Deadline {
+	if methodDeadline, ok := o.Methods[method]; ok {
+		return methodDeadline
+	}
+	return o.Default
+}
+
+// GrpcDeadline returns unary and stream interceptors that apply a default
+// deadline to calls without one and cap the deadline of those that ask for too
+// long.
+//
+// Handlers commonly wrap unexpected errors as Internal. When the call's
+// context has expired or been cancelled, such errors are reported as
+// DeadlineExceeded or Canceled instead, so that clients can tell a timeout from
+// a server fault.
+func GrpcDeadline(opts DeadlineOptions) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := withDeadline(ctx, opts.forMethod(info.FullMethod))
+		defer cancel()
+
+		result, err := handler(ctx, req)
+		return result, contextStatus(ctx, err)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := withDeadline(ss.Context(), opts.forMethod(info.FullMethod))
+		defer cancel()
+
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		return contextStatus(ctx, err)
+	}
+
+	return unary, stream
+}
+
+func withDeadline(ctx context.Context, limit deadline.Deadline) (context.Context, context.CancelFunc) {
+	remaining, hasDeadline := timeRemaining(ctx)
+
+	switch {
+	case hasDeadline && limit.Max > 0 && remaining > limit.Max:
+		return context.WithTimeout(ctx, limit.Max)
+	case !hasDeadline && limit.Default > 0:
+		return context.WithTimeout(ctx, limit.Default)
+	default:
+		return ctx, func() {}
+	}
+}
+
+func timeRemaining(ctx context.Context) (time.Duration, bool) {
+	expiry, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(expiry), true
+}
+
+// contextStatus replaces an Internal or Unknown error with the status matching
+// the context's error, if the context is done.
+func contextStatus(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	switch status.Code(err) {
+	case codes.Internal, codes.Unknown:
+	default:
+		return err
+	}
+
+	code := codes.Canceled
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, status.Convert(err).Message())
+}
+
+// contextServerStream overrides the context of a [grpc.ServerStream].
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/deadline"
+)
+
+func TestGrpcDeadline(t *testing.T) {
+	t.Parallel()
+
+	const method = "/user.UserService/Register"
+	opts := DeadlineOptions{
+		Methods: map[string]deadline.Deadline{method: {Default: time.Second, Max: 2 * time.Second}},
+		Default: deadline.Deadline{Default: 10 * time.Second, Max: time.Minute},
+	}
+
+	withTimeout := func(d time.Duration) context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), d)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		method        string
+		wantRemaining time.Duration
+	}{
+		{name: "Applies method default", ctx: context.Background(), method: method, wantRemaining: time.Second},
+		{name: "Applies global default", ctx: context.Background(), method: "/user.UserService/GetUser", wantRemaining: 10 * time.Second},
+		{name: "Caps long deadline", ctx: withTimeout(time.Hour), method: method, wantRemaining: 2 * time.Second},
+		{name: "Keeps short deadline", ctx: withTimeout(500 * time.Millisecond), method: method, wantRemaining: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			unary, _ := GrpcDeadline(opts)
+
+			var remaining time.Duration
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				remaining = time.Until(deadline)
+				return nil, nil
+			}
+
+			_, err := unary(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.wantRemaining, remaining, float64(100*time.Millisecond))
+		})
+	}
+}
+
+func TestGrpcDeadline_NoLimits(t *testing.T) {
+	t.Parallel()
+
+	unary, _ := GrpcDeadline(DeadlineOptions{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil, nil
+	}
+
+	_, err := unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/A"}, handler)
+	require.NoError(t, err)
+}
+
+func TestGrpcDeadline_ContextErrors(t *testing.T) {
+	t.Parallel()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		opts     DeadlineOptions
+		err      error
+		wantCode codes.Code
+	}{
+		{
+			name:     "Internal after deadline",
+			ctx:      context.Background(),
+			opts:     DeadlineOptions{Default: deadline.Deadline{Default: time.Millisecond}},
+			err:      status.Error(codes.Internal, "failed to register user: timeout"),
+			wantCode: codes.DeadlineExceeded,
+		},
+		{
+			name:     "Plain error after cancellation",
+			ctx:      cancelled,
+			err:      errors.New("query failed"),
+			wantCode: codes.Canceled,
+		},
+		{
+			name:     "Client error after deadline is kept",
+			ctx:      context.Background(),
+			opts:     DeadlineOptions{Default: deadline.Deadline{Default: time.Millisecond}},
+			err:      status.Error(codes.InvalidArgument, "bad email"),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Internal with live context is kept",
+			ctx:      context.Background(),
+			err:      status.Error(codes.Internal, "bug"),
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			unary, stream := GrpcDeadline(tt.opts)
+
+			unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if _, ok := ctx.Deadline(); ok {
+					<-ctx.Done()
+				}
+				return nil, tt.err
+			}
+			_, err := unary(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/A"}, unaryHandler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			streamHandler := func(srv interface{}, ss grpc.ServerStream) error {
+				if _, ok := ss.Context().Deadline(); ok {
+					<-ss.Context().Done()
+				}
+				return tt.err
+			}
+			err = stream(nil, &fakeServerStream{ctx: tt.ctx}, &grpc.StreamServerInfo{FullMethod: "/svc/A"}, streamHandler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+const (
+	// idempotencyKeyHeader carries the client-chosen key, typically a UUID.
+	idempotencyKeyHeader = "idempotency-key"
+
+	// idempotentReplayedHeader is set on responses replayed from the store.
+	idempotentReplayedHeader = "idempotent-replayed"
+
+	maxIdempotencyKeyLen = 255
+
+	// defaultIdempotencyTTL applies when [IdempotencyOptions] sets no TTL.
+	defaultIdempotencyTTL = 24 * time.Hour
+
+	// defaultIdempotencyLease applies when [IdempotencyOptions] sets no lease.
+	defaultIdempotencyLease = time.Minute
+
+	// idempotencyStoreTimeout bounds the store calls made after the handler
+	// returns, which must succeed even if the request's context has ended.
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// IdempotencyOptions configures [GrpcIdempotency].
+type IdempotencyOptions struct {
+	// Methods lists the full gRPC method names that honour idempotency keys.
+	Methods []string
+	// TTL is how long a response is replayed for. Zero means 24 hours.
+	TTL time.Duration
+	// Lease is how long a request holds its key while in progress, or until
+	// its deadline if that is later. Zero means one minute.
+	Lease time.Duration
+}
+
+// GrpcIdempotency returns an interceptor that makes the configured methods
+// safe to retry. When a request carries an `idempotency-key` header, its
+// response, success or error, is stored keyed on the caller, method and key.
+// Retries with the same key receive the stored response without the handler
+// running again.
+//
+// A retry whose payload differs from the original fails with
+// FailedPrecondition, and one that arrives while the original is still in
+// progress fails with Aborted. An original that is still in progress once its
+// lease has run out is presumed to have crashed, and a retry takes over the
+// key and is processed. Responses that indicate the request may not
+// have been processed (Canceled, DeadlineExceeded, Unavailable and
+// ResourceExhausted) are not stored, so that the client can try again.
+//
+// If the store is unavailable the request is processed without idempotency.
+func GrpcIdempotency(
+	store outbound.IdempotencyStore,
+	opts IdempotencyOptions,
+	logger logger.Logger,
+) grpc.UnaryServerInterceptor {
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	lease := opts.Lease
+	if lease <= 0 {
+		lease = defaultIdempotencyLease
+	}
+
+	methods := make(map[string]bool, len(opts.Methods))
+	for _, method := range opts.Methods {
+		methods[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		keyValue, ok := idempotencyKey(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+		if len(keyValue) > maxIdempotencyKeyLen {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters long", idempotencyKeyHeader, maxIdempotencyKeyLen)
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		fingerprint, err := requestFingerprint(msg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fingerprint request: %v", err)
+		}
+
+		now := time.Now().UTC()
+		lockedUntil := now.Add(lease)
+		if deadline, ok := ctx.Deadline(); ok && deadline.After(lockedUntil) {
+			lockedUntil = deadline.UTC()
+		}
+		record := &idempotency.Record{
+			Key: idempotency.Key{
+				Principal: idempotencyPrincipal(ctx),
+				Method:    info.FullMethod,
+				Value:     keyValue,
+			},
+			Fingerprint: fingerprint,
+			LockedUntil: lockedUntil,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		fields := map[string]interface{}{"method": info.FullMethod, "idempotency_key": keyValue}
+
+		existing, err := store.Reserve(ctx, record)
+		if err != nil {
+			logger.Error(ctx, err, "idempotency store unavailable, processing request without it", fields)
+			return handler(ctx, req)
+		}
+		if existing != nil {
+			return replay(ctx, existing, fingerprint)
+		}
+
+		result, handlerErr := handler(ctx, req)
+
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyStoreTimeout)
+		defer cancel()
+
+		if !isStoredCode(status.Code(handlerErr)) {
+			if err := store.Release(storeCtx, record.Key); err != nil {
+				logger.Error(ctx, err, "failed to release idempotency key", fields)
+			}
+			return result, handlerErr
+		}
+
+		response, err := encodeResponse(result, handlerErr)
+		if err != nil {
+			logger.Error(ctx, err, "failed to encode idempotent response", fields)
+			if err := store.Release(storeCtx, record.Key); err != nil {
+				logger.Error(ctx, err, "failed to release idempotency key", fields)
+			}
+			return result, handlerErr
+		}
+
+		if err := store.Complete(storeCtx, record.Key, response); err != nil {
+			logger.Error(ctx, err, "failed to store idempotent response", fields)
+		}
+
+		return result, handlerErr
+	}
+}
+
+func idempotencyKey(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(idempotencyKeyHeader)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+// idempotencyPrincipal scopes keys to the caller, so that one caller can never
+// receive another's response. Unauthenticated callers share a scope, which is
+// why keys should be unguessable.
+func idempotencyPrincipal(ctx context.Context) string {
+	if payload, ok := PayloadFromContext(ctx); ok {
+		return "user:" + payload.UserID.String()
+	}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return "service:" + principal.Subject
+	}
+	return "anonymous"
+}
+
+func requestFingerprint(msg proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func isStoredCode(code codes.Code) bool {
+	switch code {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Unavailable, codes.ResourceExhausted:
+		return false
+	default:
+		return true
+	}
+}
+
+func replay(ctx context.Context, record *idempotency.Record, fingerprint string) (interface{}, error) {
+	if record.Fingerprint != fingerprint {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%s was already used with a different request",
+			idempotencyKeyHeader,
+		)
+	}
+	if !record.IsComplete() {
+		return nil, status.Errorf(
+			codes.Aborted,
+			"a request with this %s is still in progress",
+			idempotencyKeyHeader,
+		)
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedHeader, "true"))
+
+	result, st, err := decodeResponse(record.Response)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+	return result, st.Err()
+}
+
+// encodeResponse stores the outcome of a request as a google.rpc.Status. For a
+// successful request, the response message is its only detail.
+func encodeResponse(result interface{}, handlerErr error) ([]byte, error) {
+	if handlerErr != nil {
+		return proto.Marshal(status.Convert(handlerErr).Proto())
+	}
+
+	msg, ok := result.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("response of type %T is not a protobuf message", result)
+	}
+	detail, err := anypb.New(msg)
+	if err != nil {
+		return nil, fmt.Errorf("wrap response: %w", err)
+	}
+
+	return proto.Marshal(&spb.Status{Code: int32(codes.OK), Details: []*anypb.Any{detail}})
+}
+
+// decodeResponse returns the response message or status error encoded by
+// [encodeResponse]. The returned error reports a failure to decode, not the
+// stored status.
+func decodeResponse(data []byte) (interface{}, *status.Status, error) {
+	var st spb.Status
+	if err := proto.Unmarshal(data, &st); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal status: %w", err)
+	}
+
+	if codes.Code(st.GetCode()) != codes.OK {
+		return nil, status.FromProto(&st), nil
+	}
+
+	if len(st.GetDetails()) != 1 {
+		return nil, nil, fmt.Errorf("stored response has %d messages, want 1", len(st.GetDetails()))
+	}
+
+	msg, err := anypb.UnmarshalNew(st.GetDetails()[0], proto.UnmarshalOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+	return msg, nil, nil
+}
@@ -0,0 +1,332 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// memoryIdempotencyStore is an [outbound.IdempotencyStore] backed by a map.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[idempotency.Key]*idempotency.Record
+	err     error
+}
+
+var _ outbound.IdempotencyStore = (*memoryIdempotencyStore)(nil)
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[idempotency.Key]*idempotency.Record)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	now := time.Now()
+	if existing, ok := s.records[record.Key]; ok && !existing.IsExpired(now) && !existing.IsAbandoned(now) {
+		return existing, nil
+	}
+	s.records[record.Key] = record
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key idempotency.Key, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		record.Response = response
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key idempotency.Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+// countingHandler returns `responses` in turn and counts its calls.
+type countingHandler struct {
+	calls     int
+	responses []error
+}
+
+func (h *countingHandler) handle(_ context.Context, req interface{}) (interface{}, error) {
+	err := h.responses[h.calls]
+	h.calls++
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.String("created " + req.(*wrapperspb.StringValue).GetValue()), nil
+}
+
+func TestGrpcIdempotency(t *testing.T) {
+	t.Parallel()
+
+	const method = "/user.UserService/Register"
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	keyCtx := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, key))
+	}
+
+	type call struct {
+		ctx      context.Context
+		req      string
+		wantCode codes.Code
+		wantResp string
+	}
+
+	tests := []struct {
+		name      string
+		store     *memoryIdempotencyStore
+		responses []error
+		calls     []call
+		wantCalls int
+	}{
+		{
+			name:      "Replays successful response",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{nil},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:      "Replays error response",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{status.Error(codes.AlreadyExists, "user exists")},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.AlreadyExists},
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.AlreadyExists},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:      "Rejects key reused with different payload",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{nil},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+				{ctx: keyCtx("k1"), req: "bob", wantCode: codes.FailedPrecondition},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:      "Processes requests with different keys",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{nil, nil},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+				{ctx: keyCtx("k2"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "Scopes keys to the caller",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{nil, nil},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+				{
+					ctx:      ContextWithPayload(keyCtx("k1"), &auth.Payload{UserID: uuid.New()}),
+					req:      "alice",
+					wantCode: codes.OK,
+					wantResp: "created alice",
+				},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "Does not store retryable errors",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{status.Error(codes.Unavailable, "database down"), nil},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.Unavailable},
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "Ignores requests without a key",
+			store:     newMemoryIdempotencyStore(),
+			responses: []error{nil, nil},
+			calls: []call{
+				{ctx: context.Background(), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+				{ctx: context.Background(), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:  "Rejects overlong keys",
+			store: newMemoryIdempotencyStore(),
+			calls: []call{
+				{ctx: keyCtx(strings.Repeat("k", maxIdempotencyKeyLen+1)), req: "alice", wantCode: codes.InvalidArgument},
+			},
+		},
+		{
+			name:      "Fails open when store errors",
+			store:     &memoryIdempotencyStore{err: errors.New("connection refused")},
+			responses: []error{nil, nil},
+			calls: []call{
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+				{ctx: keyCtx("k1"), req: "alice", wantCode: codes.OK, wantResp: "created alice"},
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interceptor := GrpcIdempotency(
+				tt.store,
+				IdempotencyOptions{Methods: []string{method}, TTL: time.Hour},
+				&recordingLogger{},
+			)
+			handler := &countingHandler{responses: tt.responses}
+
+			for _, c := range tt.calls {
+				resp, err := interceptor(c.ctx, wrapperspb.String(c.req), info, handler.handle)
+
+				require.Equal(t, c.wantCode, status.Code(err), err)
+				if c.wantResp != "" {
+					assert.True(t, proto.Equal(wrapperspb.String(c.wantResp), resp.(proto.Message)))
+				}
+			}
+
+			assert.Equal(t, tt.wantCalls, handler.calls)
+		})
+	}
+}
+
+func TestGrpcIdempotency_InProgress(t *testing.T) {
+	t.Parallel()
+
+	const method = "/user.UserService/Register"
+	store := newMemoryIdempotencyStore()
+	interceptor := GrpcIdempotency(store, IdempotencyOptions{Methods: []string{method}}, &recordingLogger{})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, "k1"))
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	var retryErr error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		// A retry arriving before the original completes must not run again.
+		_, retryErr = interceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+			t.Error("handler ran for in-progress key")
+			return nil, nil
+		})
+		return wrapperspb.String("done"), nil
+	}
+
+	_, err := interceptor(ctx, wrapperspb.String("alice"), info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, codes.Aborted, status.Code(retryErr))
+}
+
+func TestGrpcIdempotency_Lease(t *testing.T) {
+	t.Parallel()
+
+	const method = "/user.UserService/Register"
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	req := wrapperspb.String("alice")
+	fingerprint, err := requestFingerprint(req)
+	require.NoError(t, err)
+
+	key := idempotency.Key{Principal: "anonymous", Method: method, Value: "k1"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, key.Value))
+
+	tests := []struct {
+		name        string
+		lockedUntil time.Time
+		wantCode    codes.Code
+		wantCalls   int
+	}{
+		{
+			name:        "Refuses a retry within the lease",
+			lockedUntil: time.Now().Add(time.Minute),
+			wantCode:    codes.Aborted,
+		},
+		{
+			name:        "Takes over the key once the lease has run out",
+			lockedUntil: time.Now().Add(-time.Second),
+			wantCode:    codes.OK,
+			wantCalls:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := newMemoryIdempotencyStore()
+			store.records[key] = &idempotency.Record{
+				Key:         key,
+				Fingerprint: fingerprint,
+				LockedUntil: tt.lockedUntil,
+				CreatedAt:   time.Now().Add(-time.Hour),
+				ExpiresAt:   time.Now().Add(time.Hour),
+			}
+			interceptor := GrpcIdempotency(store, IdempotencyOptions{Methods: []string{method}}, &recordingLogger{})
+
+			handler := &countingHandler{responses: []error{nil}}
+			_, err := interceptor(ctx, req, info, handler.handle)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCalls, handler.calls)
+		})
+	}
+
+	t.Run("Holds the key until the request's deadline", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMemoryIdempotencyStore()
+		interceptor := GrpcIdempotency(store, IdempotencyOptions{
+			Methods: []string{method},
+			Lease:   time.Second,
+		}, &recordingLogger{})
+
+		deadline := time.Now().Add(time.Hour)
+		ctx, cancel := context.WithDeadline(ctx, deadline)
+		defer cancel()
+
+		var lockedUntil time.Time
+		_, err := interceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+			lockedUntil = store.records[key].LockedUntil
+			return wrapperspb.String("done"), nil
+		})
+		require.NoError(t, err)
+		assert.True(t, lockedUntil.Equal(deadline))
+	})
+}
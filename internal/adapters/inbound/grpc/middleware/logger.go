@@ -2,44 +2,236 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
 )
 
-func GrpcLogger(logger logger.Logger) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// create a logger with request context
-		logger = logger.WithContext(ctx)
+// redactedValue replaces the value of every redacted payload field.
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedFields are always redacted from logged payloads, in addition
+// to any field whose name ends in "token".
+var defaultRedactedFields = []string{"password", "password_hash"}
+
+// LoggerOptions configures the access logging interceptors.
+type LoggerOptions struct {
+	// PayloadSampleRate is the fraction of unary requests, between 0 and 1,
+	// whose request and response bodies are logged.
+	PayloadSampleRate float64
+	// RedactFields lists payload fields, in addition to passwords and tokens,
+	// whose values are never logged. Names are matched case-insensitively and
+	// without regard to underscores, so "password_hash" also matches
+	// "PasswordHash".
+	RedactFields []string
+	// SlowThreshold is the duration above which an otherwise successful RPC
+	// is logged at warn. Zero disables it.
+	SlowThreshold time.Duration
+}
 
+// logLevel is the severity at which an RPC is logged.
+type logLevel int
+
+const (
+	levelInfo logLevel = iota
+	levelWarn
+	levelError
+)
+
+// codeLevel maps a status code to the level of its access log line. Codes
+// caused by the client log at warn, and those indicating a fault in the server
+// or its dependencies log at error.
+func codeLevel(code codes.Code) logLevel {
+	switch code {
+	case codes.OK:
+		return levelInfo
+	case codes.Canceled,
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.AlreadyExists,
+		codes.PermissionDenied,
+		codes.Unauthenticated,
+		codes.ResourceExhausted,
+		codes.FailedPrecondition,
+		codes.Aborted,
+		codes.OutOfRange:
+		return levelWarn
+	default:
+		return levelError
+	}
+}
+
+// accessLogger holds the configuration shared by the unary and stream
+// interceptors. It is never mutated after construction.
+type accessLogger struct {
+	logger        logger.Logger
+	opts          LoggerOptions
+	redactedNames map[string]bool
+	sample        func() float64
+}
+
+func newAccessLogger(logger logger.Logger, opts LoggerOptions) *accessLogger {
+	redactedNames := make(map[string]bool)
+	for _, field := range append(defaultRedactedFields, opts.RedactFields...) {
+		redactedNames[normalizeFieldName(field)] = true
+	}
+
+	return &accessLogger{
+		logger:        logger,
+		opts:          opts,
+		redactedNames: redactedNames,
+		sample:        rand.Float64,
+	}
+}
+
+// GrpcLogger returns an interceptor that logs the outcome and duration of
+// every unary RPC, at a level determined by its status code and latency.
+func GrpcLogger(logger logger.Logger, opts LoggerOptions) grpc.UnaryServerInterceptor {
+	return grpcLogger(newAccessLogger(logger, opts))
+}
+
+func grpcLogger(l *accessLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		startTime := time.Now()
 		// forwards request to handler to be processed
 		result, err := handler(ctx, req)
 		duration := time.Since(startTime)
 
-		statusCode := codes.Unknown
-		if st, ok := status.FromError(err); ok {
-			statusCode = st.Code()
+		fields := map[string]interface{}{
+			"method": info.FullMethod,
+		}
+
+		if l.opts.PayloadSampleRate > 0 && l.sample() < l.opts.PayloadSampleRate {
+			if payload, ok := l.payload(req); ok {
+				fields["request"] = payload
+			}
+			if payload, ok := l.payload(result); ok {
+				fields["response"] = payload
+			}
 		}
 
+		l.log(ctx, err, duration, "gRPC request", fields)
+
+		return result, err
+	}
+}
+
+// GrpcStreamLogger returns an interceptor that logs the outcome and duration of
+// every streaming RPC once the stream has finished. Stream messages are never
+// logged.
+func GrpcStreamLogger(logger logger.Logger, opts LoggerOptions) grpc.StreamServerInterceptor {
+	l := newAccessLogger(logger, opts)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startTime := time.Now()
+		err := handler(srv, ss)
+		duration := time.Since(startTime)
+
 		fields := map[string]interface{}{
-			"protocol":    "grpc",
-			"method":      info.FullMethod,
-			"status_code": int(statusCode),
-			"status_text": statusCode.String(),
-			"duration_ms": duration.Milliseconds(),
+			"method":           info.FullMethod,
+			"client_streaming": info.IsClientStream,
+			"server_streaming": info.IsServerStream,
 		}
 
+		l.log(ss.Context(), err, duration, "gRPC stream", fields)
+
+		return err
+	}
+}
+
+// log writes the access log line for a finished RPC using a logger scoped to
+// the request.
+func (l *accessLogger) log(
+	ctx context.Context,
+	err error,
+	duration time.Duration,
+	msg string,
+	fields map[string]interface{},
+) {
+	statusCode := status.Code(err)
+
+	fields["protocol"] = "grpc"
+	fields["status_code"] = int(statusCode)
+	fields["status_text"] = statusCode.String()
+	fields["duration_ms"] = duration.Milliseconds()
+
+	level := codeLevel(statusCode)
+	if l.opts.SlowThreshold > 0 && duration >= l.opts.SlowThreshold {
+		fields["slow"] = true
+		if level == levelInfo {
+			level = levelWarn
+		}
+	}
+
+	reqLogger := l.logger.WithContext(ctx)
+	switch level {
+	case levelError:
+		reqLogger.Error(ctx, err, msg+" failed", fields)
+	case levelWarn:
 		if err != nil {
-			logger.Error(ctx, err, "grpc request failed", fields)
+			fields["error"] = err.Error()
+			reqLogger.Warn(ctx, msg+" failed", fields)
 		} else {
-			logger.Info(ctx, "gRPC request proceed", fields)
+			reqLogger.Warn(ctx, "slow "+msg, fields)
 		}
+	default:
+		reqLogger.Info(ctx, msg+" processed", fields)
+	}
+}
 
-		return result, err
+// payload converts a protobuf message to a loggable map with sensitive fields
+// redacted. Values that are not messages are not logged.
+func (l *accessLogger) payload(v interface{}) (map[string]interface{}, bool) {
+	msg, ok := v.(proto.Message)
+	if !ok || msg == nil {
+		return nil, false
 	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, false
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, false
+	}
+
+	l.redact(payload)
+	return payload, true
+}
+
+func (l *accessLogger) redact(v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if l.isRedacted(key) {
+				value[key] = redactedValue
+				continue
+			}
+			l.redact(field)
+		}
+	case []interface{}:
+		for _, item := range value {
+			l.redact(item)
+		}
+	}
+}
+
+func (l *accessLogger) isRedacted(field string) bool {
+	name := normalizeFieldName(field)
+	return l.redactedNames[name] || strings.HasSuffix(name, "token")
+}
+
+func normalizeFieldName(field string) string {
+	return strings.ToLower(strings.ReplaceAll(field, "_", ""))
 }
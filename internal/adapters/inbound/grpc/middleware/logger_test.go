@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordingLogger is a [logger.Logger] that keeps every entry in memory.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+var _ logger.Logger = (*recordingLogger)(nil)
+
+func (l *recordingLogger) record(level, msg string, fields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: fields})
+}
+
+func (l *recordingLogger) Info(_ context.Context, msg string, fields map[string]interface{}) {
+	l.record("info", msg, fields)
+}
+
+func (l *recordingLogger) Error(_ context.Context, _ error, msg string, fields map[string]interface{}) {
+	l.record("error", msg, fields)
+}
+
+func (l *recordingLogger) Debug(_ context.Context, msg string, fields map[string]interface{}) {
+	l.record("debug", msg, fields)
+}
+
+func (l *recordingLogger) Warn(_ context.Context, msg string, fields map[string]interface{}) {
+	l.record("warn", msg, fields)
+}
+
+func (l *recordingLogger) Fatal(_ context.Context, msg string, fields map[string]interface{}) {
+	l.record("fatal", msg, fields)
+}
+
+func (l *recordingLogger) WithContext(_ context.Context) logger.Logger {
+	return l
+}
+
+func TestGrpcLogger_Levels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		delay     time.Duration
+		wantLevel string
+		wantSlow  bool
+	}{
+		{name: "OK", wantLevel: "info"},
+		{name: "Client error", err: status.Error(codes.InvalidArgument, "bad email"), wantLevel: "warn"},
+		{name: "Unauthenticated", err: status.Error(codes.Unauthenticated, "no token"), wantLevel: "warn"},
+		{name: "Server error", err: status.Error(codes.Internal, "db down"), wantLevel: "error"},
+		{name: "Non-status error", err: errors.New("boom"), wantLevel: "error"},
+		{name: "Slow OK", delay: 20 * time.Millisecond, wantLevel: "warn", wantSlow: true},
+		{
+			name:      "Slow server error",
+			err:       status.Error(codes.Unavailable, "try later"),
+			delay:     20 * time.Millisecond,
+			wantLevel: "error",
+			wantSlow:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := &recordingLogger{}
+			interceptor := GrpcLogger(log, LoggerOptions{SlowThreshold: 10 * time.Millisecond})
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				time.Sleep(tt.delay)
+				return nil, tt.err
+			}
+
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"}, handler)
+			assert.Equal(t, tt.err, err)
+
+			require.Len(t, log.entries, 1)
+			entry := log.entries[0]
+			assert.Equal(t, tt.wantLevel, entry.level)
+			assert.Equal(t, "grpc", entry.fields["protocol"])
+			assert.Equal(t, status.Code(tt.err).String(), entry.fields["status_text"])
+			if tt.wantSlow {
+				assert.Equal(t, true, entry.fields["slow"])
+			} else {
+				assert.NotContains(t, entry.fields, "slow")
+			}
+		})
+	}
+}
+
+func TestGrpcLogger_Payloads(t *testing.T) {
+	t.Parallel()
+
+	req, err := structpb.NewStruct(map[string]interface{}{
+		"email":         "jane@example.com",
+		"username":      "jane",
+		"password_hash": "hunter2",
+		"nested": map[string]interface{}{
+			"PasswordHash": "hunter2",
+			"items":        []interface{}{map[string]interface{}{"password": "hunter2"}},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := structpb.NewStruct(map[string]interface{}{
+		"access_token":  "v2.local.secret",
+		"refreshToken":  "v2.local.secret",
+		"session_id":    "42",
+		"access_expiry": "soon",
+	})
+	require.NoError(t, err)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return resp, nil
+	}
+
+	tests := []struct {
+		name       string
+		sampleRate float64
+		sample     float64
+		wantLogged bool
+	}{
+		{name: "Disabled", sampleRate: 0, sample: 0, wantLogged: false},
+		{name: "Sampled", sampleRate: 0.5, sample: 0.25, wantLogged: true},
+		{name: "Not sampled", sampleRate: 0.5, sample: 0.75, wantLogged: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := &recordingLogger{}
+			opts := LoggerOptions{PayloadSampleRate: tt.sampleRate, RedactFields: []string{"email"}}
+
+			l := newAccessLogger(log, opts)
+			l.sample = func() float64 { return tt.sample }
+
+			_, err := grpcLogger(l)(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}, handler)
+			require.NoError(t, err)
+			require.Len(t, log.entries, 1)
+			fields := log.entries[0].fields
+
+			if !tt.wantLogged {
+				assert.NotContains(t, fields, "request")
+				assert.NotContains(t, fields, "response")
+				return
+			}
+
+			assert.Equal(t, map[string]interface{}{
+				"email":         redactedValue,
+				"username":      "jane",
+				"password_hash": redactedValue,
+				"nested": map[string]interface{}{
+					"PasswordHash": redactedValue,
+					"items":        []interface{}{map[string]interface{}{"password": redactedValue}},
+				},
+			}, fields["request"])
+			assert.Equal(t, map[string]interface{}{
+				"access_token":  redactedValue,
+				"refreshToken":  redactedValue,
+				"session_id":    "42",
+				"access_expiry": "soon",
+			}, fields["response"])
+		})
+	}
+}
+
+func TestGrpcLogger_ConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	log := &recordingLogger{}
+	interceptor := GrpcLogger(log, LoggerOptions{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"}, handler)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, log.entries, 50)
+}
+
+func TestGrpcStreamLogger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		handler   grpc.StreamHandler
+		wantLevel string
+		wantCode  codes.Code
+	}{
+		{
+			name:      "Successful stream",
+			handler:   func(srv interface{}, stream grpc.ServerStream) error { return nil },
+			wantLevel: "info",
+			wantCode:  codes.OK,
+		},
+		{
+			name: "Failed stream",
+			handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return status.Error(codes.NotFound, "no such user")
+			},
+			wantLevel: "warn",
+			wantCode:  codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := &recordingLogger{}
+			info := &grpc.StreamServerInfo{FullMethod: "/user.UserService/Watch", IsServerStream: true}
+
+			err := GrpcStreamLogger(log, LoggerOptions{})(nil, &fakeServerStream{ctx: context.Background()}, info, tt.handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			require.Len(t, log.entries, 1)
+			entry := log.entries[0]
+			assert.Equal(t, tt.wantLevel, entry.level)
+			assert.Equal(t, "/user.UserService/Watch", entry.fields["method"])
+			assert.Equal(t, true, entry.fields["server_streaming"])
+			assert.Equal(t, false, entry.fields["client_streaming"])
+			assert.Equal(t, tt.wantCode.String(), entry.fields["status_text"])
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RPCRequestsTotal counts handled RPCs, labelled by `method` (the full
+	// gRPC method name) and `code` (the gRPC status code name).
+	RPCRequestsTotal = "codeodessey_grpc_server_requests_total"
+
+	// RPCRequestDurationSeconds is a histogram of RPC handling latency with
+	// the same labels as [RPCRequestsTotal].
+	RPCRequestDurationSeconds = "codeodessey_grpc_server_request_duration_seconds"
+)
+
+// GrpcMetrics returns unary and stream interceptors that record the request
+// count and latency of every RPC. Both share one set of collectors, registered
+// with `reg`, and it panics if they are already registered. A stream's latency
+// spans its whole lifetime.
+func GrpcMetrics(reg prometheus.Registerer) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: RPCRequestsTotal,
+		Help: "Total number of RPCs handled by the server.",
+	}, []string{"method", "code"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    RPCRequestDurationSeconds,
+		Help:    "Latency of RPCs handled by the server in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	reg.MustRegister(requests, duration)
+
+	observe := func(method string, err error, elapsed time.Duration) {
+		code := status.Code(err).String()
+		requests.WithLabelValues(method, code).Inc()
+		duration.WithLabelValues(method, code).Observe(elapsed.Seconds())
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		startTime := time.Now()
+		result, err := handler(ctx, req)
+		observe(info.FullMethod, err, time.Since(startTime))
+
+		return result, err
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startTime := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, err, time.Since(startTime))
+
+		return err
+	}
+
+	return unary, stream
+}
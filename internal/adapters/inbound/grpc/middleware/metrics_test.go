@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcMetrics(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	interceptor, streamInterceptor := GrpcMetrics(reg)
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Register"}
+
+	okHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	errHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad request")
+	}
+
+	_, err := interceptor(context.Background(), nil, info, okHandler)
+	require.NoError(t, err)
+	_, err = interceptor(context.Background(), nil, info, okHandler)
+	require.NoError(t, err)
+	_, err = interceptor(context.Background(), nil, info, errHandler)
+	require.Error(t, err)
+
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/user.UserService/Watch", IsServerStream: true}
+	streamHandler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+	require.NoError(t, streamInterceptor(nil, &fakeServerStream{ctx: context.Background()}, streamInfo, streamHandler))
+
+	want := `
+# HELP codeodessey_grpc_server_requests_total Total number of RPCs handled by the server.
+# TYPE codeodessey_grpc_server_requests_total counter
+codeodessey_grpc_server_requests_total{code="InvalidArgument",method="/user.UserService/Register"} 1
+codeodessey_grpc_server_requests_total{code="OK",method="/user.UserService/Register"} 2
+codeodessey_grpc_server_requests_total{code="OK",method="/user.UserService/Watch"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(want), RPCRequestsTotal))
+
+	histograms, err := testutil.GatherAndCount(reg, RPCRequestDurationSeconds)
+	require.NoError(t, err)
+	assert.Equal(t, 3, histograms)
+}
+
+// fakeServerStream is a [grpc.ServerStream] that only carries a context.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
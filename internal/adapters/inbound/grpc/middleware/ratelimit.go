@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	grpcMetadata "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+)
+
+// retryAfterHeader is the trailer carrying the number of seconds a rate-limited
+// client should wait, for clients that do not decode status details.
+const retryAfterHeader = "retry-after"
+
+// RateLimitOptions configures [GrpcRateLimit].
+type RateLimitOptions struct {
+	// Limits maps full gRPC method names to their limit.
+	Limits map[string]ratelimit.Limit
+	// Default applies to methods without an entry in Limits. When nil, such
+	// methods are not limited.
+	Default *ratelimit.Limit
+}
+
+// GrpcRateLimit returns an interceptor that enforces token-bucket limits per
+// method. Buckets are keyed on the authenticated user or peer service, as
+// stored by [GrpcAuth], or else on the client IP reported by `extractor`.
+//
+// Rejected requests fail with ResourceExhausted and carry a RetryInfo detail.
+// If the store is unavailable the request is allowed, so that an outage of the
+// store does not take the service down with it.
+func GrpcRateLimit(
+	store ratelimit.Store,
+	extractor *grpcMetadata.MetadataExtractor,
+	opts RateLimitOptions,
+	logger logger.Logger,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		limit, ok := opts.Limits[info.FullMethod]
+		if !ok {
+			if opts.Default == nil {
+				return handler(ctx, req)
+			}
+			limit = *opts.Default
+		}
+
+		key := info.FullMethod + "|" + rateLimitSubject(ctx, extractor)
+		if err := take(ctx, store, key, limit, info.FullMethod, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GrpcClientRateLimit returns an interceptor that enforces `limit` on every
+// method together, with buckets keyed on the client IP reported by
+// `extractor`. It belongs ahead of [GrpcAuth], so that requests are charged
+// before their credentials are checked and invalid tokens cannot be guessed
+// at an unlimited rate.
+//
+// Rejections and store outages are handled as by [GrpcRateLimit].
+func GrpcClientRateLimit(
+	store ratelimit.Store,
+	extractor *grpcMetadata.MetadataExtractor,
+	limit ratelimit.Limit,
+	logger logger.Logger,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := "client|" + clientIPSubject(ctx, extractor)
+		if err := take(ctx, store, key, limit, info.FullMethod, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// take consumes a token from the bucket `key`, returning a ResourceExhausted
+// error if none is left. Store errors are logged and the request is allowed.
+func take(
+	ctx context.Context,
+	store ratelimit.Store,
+	key string,
+	limit ratelimit.Limit,
+	method string,
+	logger logger.Logger,
+) error {
+	result, err := store.Take(ctx, key, limit)
+	if err != nil {
+		logger.Error(ctx, err, "rate limit store unavailable, allowing request", map[string]interface{}{
+			"method": method,
+		})
+		return nil
+	}
+
+	if !result.Allowed {
+		return rateLimitError(ctx, result)
+	}
+	return nil
+}
+
+// rateLimitSubject identifies who a request is charged to.
+func rateLimitSubject(ctx context.Context, extractor *grpcMetadata.MetadataExtractor) string {
+	if payload, ok := PayloadFromContext(ctx); ok {
+		return "user:" + payload.UserID.String()
+	}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return "service:" + principal.Subject
+	}
+	return clientIPSubject(ctx, extractor)
+}
+
+// clientIPSubject identifies the client of a request by its IP address.
+func clientIPSubject(ctx context.Context, extractor *grpcMetadata.MetadataExtractor) string {
+	clientIP := extractor.Extract(ctx).ClientIP
+	if !clientIP.IsValid() {
+		// Unidentifiable clients share a single bucket.
+		return "ip:unknown"
+	}
+	return "ip:" + clientIP.String()
+}
+
+func rateLimitError(ctx context.Context, result ratelimit.Result) error {
+	retrySeconds := int(math.Ceil(result.RetryAfter.Seconds()))
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(retryAfterHeader, strconv.Itoa(retrySeconds)))
+
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(result.RetryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
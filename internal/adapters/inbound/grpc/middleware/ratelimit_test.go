@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	grpcMetadata "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	mock_repo "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+)
+
+type recordingStore struct {
+	keys   []string
+	result ratelimit.Result
+	err    error
+}
+
+func (s *recordingStore) Take(_ context.Context, key string, _ ratelimit.Limit) (ratelimit.Result, error) {
+	s.keys = append(s.keys, key)
+	return s.result, s.err
+}
+
+func TestGrpcRateLimit(t *testing.T) {
+	t.Parallel()
+
+	const method = "/user.UserService/Register"
+	userID := uuid.New()
+
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.7"), Port: 1234},
+	})
+	userCtx := ContextWithPayload(peerCtx, &auth.Payload{UserID: userID})
+
+	defaultLimit := ratelimit.Limit{Rate: 1, Burst: 1}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		method      string
+		opts        RateLimitOptions
+		store       *recordingStore
+		wantKeys    []string
+		wantCode    codes.Code
+		wantHandled bool
+	}{
+		{
+			name:        "Keys anonymous requests on client IP",
+			ctx:         peerCtx,
+			method:      method,
+			opts:        RateLimitOptions{Limits: map[string]ratelimit.Limit{method: defaultLimit}},
+			store:       &recordingStore{result: ratelimit.Result{Allowed: true}},
+			wantKeys:    []string{method + "|ip:198.51.100.7"},
+			wantCode:    codes.OK,
+			wantHandled: true,
+		},
+		{
+			name:        "Keys authenticated requests on user",
+			ctx:         userCtx,
+			method:      method,
+			opts:        RateLimitOptions{Limits: map[string]ratelimit.Limit{method: defaultLimit}},
+			store:       &recordingStore{result: ratelimit.Result{Allowed: true}},
+			wantKeys:    []string{method + "|user:" + userID.String()},
+			wantCode:    codes.OK,
+			wantHandled: true,
+		},
+		{
+			name:        "Keys peer services on certificate subject",
+			ctx:         ContextWithPrincipal(peerCtx, Principal{Subject: "CN=billing"}),
+			method:      method,
+			opts:        RateLimitOptions{Limits: map[string]ratelimit.Limit{method: defaultLimit}},
+			store:       &recordingStore{result: ratelimit.Result{Allowed: true}},
+			wantKeys:    []string{method + "|service:CN=billing"},
+			wantCode:    codes.OK,
+			wantHandled: true,
+		},
+		{
+			name:        "Skips methods without a limit",
+			ctx:         peerCtx,
+			method:      "/user.UserService/GetUser",
+			opts:        RateLimitOptions{Limits: map[string]ratelimit.Limit{method: defaultLimit}},
+			store:       &recordingStore{},
+			wantCode:    codes.OK,
+			wantHandled: true,
+		},
+		{
+			name:        "Applies default limit",
+			ctx:         peerCtx,
+			method:      "/user.UserService/GetUser",
+			opts:        RateLimitOptions{Default: &defaultLimit},
+			store:       &recordingStore{result: ratelimit.Result{Allowed: true}},
+			wantKeys:    []string{"/user.UserService/GetUser|ip:198.51.100.7"},
+			wantCode:    codes.OK,
+			wantHandled: true,
+		},
+		{
+			name:     "Rejects when bucket is empty",
+			ctx:      peerCtx,
+			method:   method,
+			opts:     RateLimitOptions{Limits: map[string]ratelimit.Limit{method: defaultLimit}},
+			store:    &recordingStore{result: ratelimit.Result{RetryAfter: 1500 * time.Millisecond}},
+			wantKeys: []string{method + "|ip:198.51.100.7"},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:        "Fails open when store errors",
+			ctx:         peerCtx,
+			method:      method,
+			opts:        RateLimitOptions{Limits: map[string]ratelimit.Limit{method: defaultLimit}},
+			store:       &recordingStore{err: errors.New("connection refused")},
+			wantKeys:    []string{method + "|ip:198.51.100.7"},
+			wantCode:    codes.OK,
+			wantHandled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interceptor := GrpcRateLimit(
+				tt.store,
+				grpcMetadata.NewMetadataExtractor(),
+				tt.opts,
+				logger.NewZerologLogger(false),
+			)
+
+			handled := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = true
+				return "ok", nil
+			}
+
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantHandled, handled)
+			assert.Equal(t, tt.wantKeys, tt.store.keys)
+
+			if tt.wantCode == codes.ResourceExhausted {
+				st := status.Convert(err)
+				require.Len(t, st.Details(), 1)
+				retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+				require.True(t, ok)
+				assert.Equal(t, 1500*time.Millisecond, retryInfo.GetRetryDelay().AsDuration())
+			}
+		})
+	}
+}
+
+// TestGrpcClientRateLimit checks that requests with invalid tokens are limited
+// when the client limit runs ahead of authentication.
+func TestGrpcClientRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	tokens := mock_repo.NewMockTokenService(ctrl)
+	tokens.EXPECT().VerifyToken("guessed-token").Return(nil, errors.New("invalid token")).Times(2)
+
+	clientLimit := GrpcClientRateLimit(
+		ratelimit.NewMemoryStore(),
+		grpcMetadata.NewMetadataExtractor(),
+		ratelimit.Limit{Rate: 0.001, Burst: 2},
+		logger.NewZerologLogger(false),
+	)
+	authenticate := GrpcAuth(tokens)
+
+	ctx := metadata.NewIncomingContext(
+		peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.7"), Port: 1234},
+		}),
+		metadata.Pairs(authorizationHeader, "Bearer guessed-token"),
+	)
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return authenticate(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+	}
+
+	var codesSeen []codes.Code
+	for i := 0; i < 3; i++ {
+		_, err := clientLimit(ctx, nil, info, handler)
+		codesSeen = append(codesSeen, status.Code(err))
+	}
+
+	assert.Equal(t, []codes.Code{codes.Unauthenticated, codes.Unauthenticated, codes.ResourceExhausted}, codesSeen)
+}
@@ -2,38 +2,251 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/web"
 	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
 )
 
+// httpShutdownTimeout bounds how long Close waits for in-flight requests when
+// serving over net/http.
+const httpShutdownTimeout = 30 * time.Second
+
 type Server struct {
 	server     *grpc.Server
 	port       int
 	userServer *user.Server
+	services   []Service
 	listener   net.Listener
 	logger     logger.Logger
+	httpServer *http.Server
 }
 
-func NewServer(port int, userServer *user.Server, logger logger.Logger) *Server {
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(middleware.GrpcLogger(logger)),
-	)
+// serverOptions accumulates the configuration applied by each [Option].
+type serverOptions struct {
+	registerer           prometheus.Registerer
+	loggerOpts           middleware.LoggerOptions
+	tlsConfig            *tls.Config
+	unaryInterceptors    []grpc.UnaryServerInterceptor
+	streamInterceptors   []grpc.StreamServerInterceptor
+	keepaliveParams      *keepalive.ServerParameters
+	keepalivePolicy      *keepalive.EnforcementPolicy
+	maxRecvMsgSize       int
+	maxSendMsgSize       int
+	maxConcurrentStreams uint32
+	webOpts              *web.Options
+	services             []Service
+}
+
+// Service is a gRPC service that registers itself with a [grpc.Server].
+type Service interface {
+	RegisterServer(server *grpc.Server)
+}
+
+// Option configures a [Server].
+type Option func(*serverOptions)
+
+// WithMetrics records RPC metrics with `registerer`.
+func WithMetrics(registerer prometheus.Registerer) Option {
+	return func(o *serverOptions) {
+		o.registerer = registerer
+	}
+}
+
+// WithAccessLog configures payload sampling, redaction and slow-request
+// detection for the server's access logs.
+func WithAccessLog(opts middleware.LoggerOptions) Option {
+	return func(o *serverOptions) {
+		o.loggerOpts = opts
+	}
+}
+
+// WithTLS serves over TLS. Without it, the server listens in plaintext, which
+// is only suitable behind a TLS-terminating proxy or in development.
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(o *serverOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithUnaryInterceptors appends interceptors to the unary chain. They run in
+// the order given, after the server's logging and metrics interceptors.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *serverOptions) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors appends interceptors to the stream chain. They run in
+// the order given, after the server's logging and metrics interceptors.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *serverOptions) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithKeepalive sets the server's keepalive pings and connection lifetimes,
+// and the minimum ping interval permitted from clients.
+func WithKeepalive(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) Option {
+	return func(o *serverOptions) {
+		o.keepaliveParams = &params
+		o.keepalivePolicy = &policy
+	}
+}
+
+// WithMaxMessageSizes limits the size in bytes of messages received and sent.
+// Zero leaves the gRPC default in place.
+func WithMaxMessageSizes(recv, send int) Option {
+	return func(o *serverOptions) {
+		o.maxRecvMsgSize = recv
+		o.maxSendMsgSize = send
+	}
+}
+
+// WithMaxConcurrentStreams limits the number of concurrent streams per client
+// connection. Zero leaves the gRPC default in place.
+func WithMaxConcurrentStreams(n uint32) Option {
+	return func(o *serverOptions) {
+		o.maxConcurrentStreams = n
+	}
+}
+
+// WithWebProtocols also serves gRPC-Web and Connect requests on the server's
+// port, for browser clients. Requests of every protocol are served by net/http,
+// with plaintext HTTP/2 through h2c, and pass through the same interceptors.
+//
+// net/http has no equivalent of gRPC keepalive pings, so of the keepalive
+// options only the maximum idle time applies.
+func WithWebProtocols(opts web.Options) Option {
+	return func(o *serverOptions) {
+		o.webOpts = &opts
+	}
+}
+
+// WithServices also serves `services` on the server's port.
+func WithServices(services ...Service) Option {
+	return func(o *serverOptions) {
+		o.services = append(o.services, services...)
+	}
+}
+
+func NewServer(
+	port int,
+	userServer *user.Server,
+	logger logger.Logger,
+	opts ...Option,
+) *Server {
+	var options serverOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	grpcServer := grpc.NewServer(options.grpcServerOptions(logger)...)
 	reflection.Register(grpcServer)
 	return &Server{
 		server:     grpcServer,
 		port:       port,
 		userServer: userServer,
+		services:   options.services,
 		logger:     logger,
+		httpServer: options.httpServer(grpcServer),
 	}
 }
 
+// httpServer returns the server for web protocols, or nil if they are
+// disabled.
+func (o *serverOptions) httpServer(grpcServer *grpc.Server) *http.Server {
+	if o.webOpts == nil {
+		return nil
+	}
+
+	http2Server := &http2.Server{MaxConcurrentStreams: o.maxConcurrentStreams}
+	if o.keepaliveParams != nil {
+		http2Server.IdleTimeout = o.keepaliveParams.MaxConnectionIdle
+	}
+
+	webOpts := *o.webOpts
+	if webOpts.MaxRequestBytes == 0 && o.maxRecvMsgSize > 0 {
+		webOpts.MaxRequestBytes = int64(o.maxRecvMsgSize)
+	}
+
+	httpServer := &http.Server{
+		Handler:           h2c.NewHandler(web.NewHandler(grpcServer, webOpts), http2Server),
+		TLSConfig:         o.tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// Plaintext HTTP/2 is served by the h2c handler. ConfigureServer is only
+	// needed for TLS, and would otherwise set a TLS configuration that makes
+	// the server serve TLS. It only fails for TLS configurations that forbid
+	// HTTP/2, which the server's own TLS configuration never does.
+	if o.tlsConfig != nil {
+		_ = http2.ConfigureServer(httpServer, http2Server)
+	}
+
+	return httpServer
+}
+
+// grpcServerOptions translates the accumulated options. Logging and metrics
+// wrap every other interceptor, so that requests they reject, such as
+// rate-limited ones, are still observed.
+func (o *serverOptions) grpcServerOptions(logger logger.Logger) []grpc.ServerOption {
+	unaryInterceptors := []grpc.UnaryServerInterceptor{middleware.GrpcLogger(logger, o.loggerOpts)}
+	streamInterceptors := []grpc.StreamServerInterceptor{middleware.GrpcStreamLogger(logger, o.loggerOpts)}
+
+	if o.registerer != nil {
+		unaryMetrics, streamMetrics := middleware.GrpcMetrics(o.registerer)
+		unaryInterceptors = append(unaryInterceptors, unaryMetrics)
+		streamInterceptors = append(streamInterceptors, streamMetrics)
+	}
+
+	unaryInterceptors = append(unaryInterceptors, o.unaryInterceptors...)
+	streamInterceptors = append(streamInterceptors, o.streamInterceptors...)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	// TLS is terminated by net/http when serving web protocols.
+	if o.tlsConfig != nil && o.webOpts == nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(o.tlsConfig)))
+	}
+	if o.keepaliveParams != nil {
+		serverOpts = append(serverOpts, grpc.KeepaliveParams(*o.keepaliveParams))
+	}
+	if o.keepalivePolicy != nil {
+		serverOpts = append(serverOpts, grpc.KeepaliveEnforcementPolicy(*o.keepalivePolicy))
+	}
+	if o.maxRecvMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(o.maxRecvMsgSize))
+	}
+	if o.maxSendMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxSendMsgSize(o.maxSendMsgSize))
+	}
+	if o.maxConcurrentStreams > 0 {
+		serverOpts = append(serverOpts, grpc.MaxConcurrentStreams(o.maxConcurrentStreams))
+	}
+
+	return serverOpts
+}
+
 func (s *Server) Run() error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
 	if err != nil {
@@ -42,6 +255,9 @@ func (s *Server) Run() error {
 	s.listener = lis
 
 	s.userServer.RegisterServer(s.server)
+	for _, service := range s.services {
+		service.RegisterServer(s.server)
+	}
 
 	s.logger.Info(
 		context.Background(),
@@ -49,6 +265,10 @@ func (s *Server) Run() error {
 		map[string]interface{}{"port": s.port},
 	)
 
+	if s.httpServer != nil {
+		return s.serveHTTP(lis)
+	}
+
 	if err := s.server.Serve(lis); err != nil {
 		return fmt.Errorf("failed to serve %w", err)
 	}
@@ -56,7 +276,36 @@ func (s *Server) Run() error {
 	return nil
 }
 
+// serveHTTP serves native gRPC, gRPC-Web and Connect requests on `lis`.
+func (s *Server) serveHTTP(lis net.Listener) error {
+	var err error
+	if s.httpServer.TLSConfig != nil {
+		err = s.httpServer.ServeTLS(lis, "", "")
+	} else {
+		err = s.httpServer.Serve(lis)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve %w", err)
+	}
+
+	return nil
+}
+
 func (s *Server) Close() {
+	// Connections accepted by net/http are not tracked by the gRPC server,
+	// whose GracefulStop cannot drain them.
+	if s.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			s.logger.Error(context.Background(), err, "error shutting down HTTP server", nil)
+		}
+		s.server.Stop()
+		s.logger.Info(context.Background(), "gRPC server stopped gracefully", nil)
+		return
+	}
+
 	if s.listener != nil {
 		if err := s.listener.Close(); err != nil {
 			s.logger.Error(context.Background(), err, "error closing listener", nil)
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	pb "github.com/TeamKweku/code-odessey-hex-arch-proto/protogen/go/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/web"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/auth"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	mock_user "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+// freePort returns a port that was free when it was checked.
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+	return port
+}
+
+// startServer runs `server` until the test ends and waits for it to accept
+// connections.
+func startServer(t *testing.T, server *Server, port int) string {
+	t.Helper()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Run() }()
+	t.Cleanup(func() {
+		server.Close()
+		assert.NoError(t, <-errCh)
+	})
+
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return addr
+}
+
+func TestServer_WebProtocolsWithoutTLS(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	users := mock_user.NewMockUserService(ctrl)
+	registered := domainUser.RandomUser(t)
+	users.EXPECT().Register(gomock.Any(), gomock.Any()).Return(registered, nil).Times(3)
+
+	tokens, err := auth.NewPasetoToken()
+	require.NoError(t, err)
+	userServer := user.NewServer(users, config.Default(), tokens, nil, metadata.NewMetadataExtractor())
+
+	port := freePort(t)
+	server := NewServer(port, userServer, logger.NewZerologLogger(false), WithWebProtocols(web.Options{}))
+	addr := startServer(t, server, port)
+
+	req := &pb.RegisterUserRequest{
+		Username:     domainUser.RandomUsernameCandidate(),
+		Email:        domainUser.RandomEmailAddressCandidate(),
+		PasswordHash: domainUser.RandomPasswordCandidate(),
+	}
+	wantUsername := registered.Username().String()
+
+	// A server that fails to serve may leave its listener open, so calls are
+	// bounded rather than left waiting for a response.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("gRPC over h2c", func(t *testing.T) {
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		resp, err := pb.NewUserServiceClient(conn).Register(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, wantUsername, resp.GetUser().GetUsername())
+	})
+
+	t.Run("gRPC-Web", func(t *testing.T) {
+		data, err := proto.Marshal(req)
+		require.NoError(t, err)
+
+		body := make([]byte, 5, 5+len(data))
+		binary.BigEndian.PutUint32(body[1:], uint32(len(data)))
+		body = append(body, data...)
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+addr+pb.UserService_Register_FullMethodName, bytes.NewReader(body))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", "application/grpc-web+proto")
+
+		resp, err := http.DefaultClient.Do(httpReq)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		// The response is a message frame followed by a trailer frame.
+		require.GreaterOrEqual(t, len(respBody), 5)
+		length := binary.BigEndian.Uint32(respBody[1:5])
+		out := new(pb.RegisterUserResponse)
+		require.NoError(t, proto.Unmarshal(respBody[5:5+length], out))
+		assert.Equal(t, wantUsername, out.GetUser().GetUsername())
+		assert.Contains(t, string(respBody[5+length:]), "grpc-status: 0")
+	})
+
+	t.Run("Connect", func(t *testing.T) {
+		body, err := protojson.Marshal(req)
+		require.NoError(t, err)
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+addr+pb.UserService_Register_FullMethodName, bytes.NewReader(body))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Connect-Protocol-Version", "1")
+
+		resp, err := http.DefaultClient.Do(httpReq)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		out := new(pb.RegisterUserResponse)
+		require.NoError(t, protojson.Unmarshal(respBody, out))
+		assert.Equal(t, wantUsername, out.GetUser().GetUsername())
+	})
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	pb "github.com/TeamKweku/code-odessey-hex-arch-proto/protogen/go/user"
+
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+)
+
+// Routes describes the JSON surface of the user service, as served over the
+// Connect protocol. Field names follow the protobuf JSON mapping, and request
+// constraints mirror the domain's validation rules so that invalid requests
+// are rejected before reaching the service.
+func Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Path:        pb.UserService_Register_FullMethodName,
+			OperationID: "register",
+			Summary:     "Register a new user",
+			Tags:        []string{"Users"},
+			Request: &openapi.Schema{
+				Type:     "object",
+				Required: []string{"username", "email", "passwordHash"},
+				Properties: map[string]*openapi.Schema{
+					"username": usernameSchema(),
+					"email":    emailSchema(),
+					"passwordHash": {
+						Type:        "string",
+						Description: "The plaintext password, which is hashed before it is stored.",
+						MinLength:   openapi.Int(domainUser.PasswordMinLen),
+						MaxLength:   openapi.Int(domainUser.PasswordMaxLen),
+						WriteOnly:   true,
+					},
+				},
+			},
+			Response: &openapi.Schema{
+				Type:       "object",
+				Properties: map[string]*openapi.Schema{"user": openapi.Ref("User")},
+			},
+		},
+		{
+			Path:        pb.UserService_Authenticate_FullMethodName,
+			OperationID: "authenticate",
+			Summary:     "Log in and start a session",
+			Tags:        []string{"Authentication"},
+			Request: &openapi.Schema{
+				Type:     "object",
+				Required: []string{"email", "password"},
+				Properties: map[string]*openapi.Schema{
+					"email":    emailSchema(),
+					"password": {Type: "string", WriteOnly: true},
+				},
+			},
+			Response: &openapi.Schema{
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"user":                  openapi.Ref("User"),
+					"sessionId":             {Type: "string", Format: "uuid"},
+					"accessToken":           {Type: "string"},
+					"refreshToken":          {Type: "string"},
+					"accessTokenExpiresAt":  {Type: "string", Format: "date-time"},
+					"refreshTokenExpiresAt": {Type: "string", Format: "date-time"},
+				},
+			},
+		},
+	}
+}
+
+// Schemas returns the schemas shared by [Routes].
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"User": {
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"id":                {Type: "string", Format: "uuid", ReadOnly: true},
+				"username":          usernameSchema(),
+				"email":             emailSchema(),
+				"role":              {Type: "string", Enum: []string{domainUser.RoleReader.String(), domainUser.RoleAdmin.String()}},
+				"passwordChangedAt": {Type: "string", Format: "date-time", ReadOnly: true},
+				"createdAt":         {Type: "string", Format: "date-time", ReadOnly: true},
+				"updatedAt":         {Type: "string", Format: "date-time", ReadOnly: true},
+			},
+		},
+	}
+}
+
+func usernameSchema() *openapi.Schema {
+	return &openapi.Schema{
+		Type:      "string",
+		MinLength: openapi.Int(domainUser.UsernameMinLen),
+		MaxLength: openapi.Int(domainUser.UsernameMaxLen),
+		Pattern:   domainUser.UsernamePattern(),
+	}
+}
+
+func emailSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Format: "email"}
+}
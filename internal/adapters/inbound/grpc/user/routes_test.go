@@ -0,0 +1,61 @@
+package user
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	pb "github.com/TeamKweku/code-odessey-hex-arch-proto/protogen/go/user"
+	"github.com/stretchr/testify/assert"
+
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+)
+
+// TestRoutes_MatchDomainValidation checks that the request schemas accept
+// exactly the inputs that the domain accepts, so that schema validation never
+// rejects a request the service would have processed.
+func TestRoutes_MatchDomainValidation(t *testing.T) {
+	t.Parallel()
+
+	doc := openapi.NewDocument(openapi.Spec{Routes: Routes(), Schemas: Schemas()})
+
+	testCases := []struct {
+		name     string
+		username string
+		email    string
+		password string
+	}{
+		{"Valid", "alice_01", "alice@example.com", "correct horse"},
+		{"Username too short", "al", "alice@example.com", "correct horse"},
+		{"Username at min length", strings.Repeat("a", domainUser.UsernameMinLen), "alice@example.com", "correct horse"},
+		{"Username at max length", strings.Repeat("a", domainUser.UsernameMaxLen), "alice@example.com", "correct horse"},
+		{"Username too long", strings.Repeat("a", domainUser.UsernameMaxLen+1), "alice@example.com", "correct horse"},
+		{"Username with invalid characters", "alice!", "alice@example.com", "correct horse"},
+		{"Invalid email", "alice", "alice.example.com", "correct horse"},
+		{"Password too short", "alice", "alice@example.com", strings.Repeat("p", domainUser.PasswordMinLen-1)},
+		{"Password at max length", "alice", "alice@example.com", strings.Repeat("p", domainUser.PasswordMaxLen)},
+		{"Password too long", "alice", "alice@example.com", strings.Repeat("p", domainUser.PasswordMaxLen+1)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := map[string]interface{}{
+				"username":     tc.username,
+				"email":        tc.email,
+				"passwordHash": tc.password,
+			}
+			schemaErr := doc.ValidateRequest(http.MethodPost, pb.UserService_Register_FullMethodName, body)
+
+			_, usernameErr := domainUser.ParseUsername(tc.username)
+			_, emailErr := domainUser.ParseEmailAddress(tc.email)
+			passwordValid := len(tc.password) >= domainUser.PasswordMinLen && len(tc.password) <= domainUser.PasswordMaxLen
+			domainValid := usernameErr == nil && emailErr == nil && passwordValid
+
+			assert.Equal(t, domainValid, schemaErr == nil, "schema error: %v", schemaErr)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/grpcstatus"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
@@ -32,13 +33,14 @@ func NewServer(
 	cfg config.Config,
 	authService inbound.TokenService,
 	sessionService *session.SessionService,
+	metadataExtractor *metadata.MetadataExtractor,
 ) *Server {
 	return &Server{
 		userService:       userService,
 		authService:       authService,
 		sessionService:    sessionService,
 		config:            cfg,
-		metadataExtractor: &metadata.MetadataExtractor{},
+		metadataExtractor: metadataExtractor,
 	}
 }
 
@@ -63,14 +65,14 @@ func (s *Server) Register(
 		return nil, status.Errorf(codes.Internal, "failed to parse registration request: %v", err)
 	}
 
-	registeredUser, err := s.userService.Register(context.Background(), domainReq)
+	registeredUser, err := s.userService.Register(ctx, domainReq)
 	if err != nil {
 		// TODO
 		var validatorErr *domainUser.ValidationError
 		if errors.As(err, &validatorErr) {
 			return nil, status.Errorf(codes.InvalidArgument, "Validation error: %v", validatorErr)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to register user: %v", err)
+		return nil, grpcstatus.Internal(err, "failed to register user")
 	}
 
 	return &pb.RegisterUserResponse{
@@ -107,7 +109,7 @@ func (s *Server) Authenticate(
 		if errors.As(err, &authErr) {
 			return nil, status.Errorf(codes.Unauthenticated, "authentication failed: %v", err)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to authenticate user: %v", err)
+		return nil, grpcstatus.Internal(err, "failed to authenticate user")
 	}
 
 	// generate access token
@@ -126,14 +128,20 @@ func (s *Server) Authenticate(
 
 	// create session with refresh token and payload
 	// assign empty strings for test purposes for no
-	session, err := auth.NewSessions(authenticatedUser.ID(), refreshToken, refreshPayload, md.UserAgent, md.ClientIP)
+	session, err := auth.NewSessions(
+		authenticatedUser.ID(),
+		refreshToken,
+		refreshPayload,
+		md.UserAgent,
+		auth.NewClientIP(md.ClientIP),
+	)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create session: %v", err)
 	}
 
 	err = s.sessionService.CreateSession(ctx, session)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to save user session: %v", err)
+		return nil, grpcstatus.Internal(err, "failed to save user session")
 	}
 
 	return &pb.LoginUserResponse{
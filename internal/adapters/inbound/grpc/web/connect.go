@@ -0,0 +1,270 @@
+package web
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// connectTrailerPrefix prefixes the headers carrying the trailers of a unary
+// Connect response.
+const connectTrailerPrefix = "Trailer-"
+
+// connectCodes maps status codes to their Connect names and HTTP statuses.
+var connectCodes = map[codes.Code]struct {
+	name       string
+	httpStatus int
+}{
+	codes.Canceled:           {"canceled", 499},
+	codes.Unknown:            {"unknown", http.StatusInternalServerError},
+	codes.InvalidArgument:    {"invalid_argument", http.StatusBadRequest},
+	codes.DeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout},
+	codes.NotFound:           {"not_found", http.StatusNotFound},
+	codes.AlreadyExists:      {"already_exists", http.StatusConflict},
+	codes.PermissionDenied:   {"permission_denied", http.StatusForbidden},
+	codes.ResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests},
+	codes.FailedPrecondition: {"failed_precondition", http.StatusBadRequest},
+	codes.Aborted:            {"aborted", http.StatusConflict},
+	codes.OutOfRange:         {"out_of_range", http.StatusBadRequest},
+	codes.Unimplemented:      {"unimplemented", http.StatusNotImplemented},
+	codes.Internal:           {"internal", http.StatusInternalServerError},
+	codes.Unavailable:        {"unavailable", http.StatusServiceUnavailable},
+	codes.DataLoss:           {"data_loss", http.StatusInternalServerError},
+	codes.Unauthenticated:    {"unauthenticated", http.StatusUnauthorized},
+}
+
+// connectError is the JSON body of a failed Connect request.
+type connectError struct {
+	Code    string                `json:"code"`
+	Message string                `json:"message,omitempty"`
+	Details []connectErrorDetails `json:"details,omitempty"`
+}
+
+type connectErrorDetails struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func isConnectUnary(contentType string) bool {
+	return hasContentType(contentType, connectProtoContentType) ||
+		hasContentType(contentType, connectJSONContentType)
+}
+
+// serveConnect serves a unary Connect request. The request body is the bare
+// message, which is framed for the gRPC server, and the response is unframed
+// with its status mapped to an HTTP status and, on failure, a JSON error body.
+// JSON requests are validated against the OpenAPI document, if there is one.
+// Compressed messages and GET requests are not supported.
+func (h *Handler) serveConnect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeConnectError(w, status.New(codes.Unimplemented, "only POST requests are supported"), nil)
+		return
+	}
+	if encoding := r.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		writeConnectError(w, status.Newf(codes.Unimplemented, "content encoding %q is not supported", encoding), nil)
+		return
+	}
+
+	contentType := connectProtoContentType
+	subtype := "proto"
+	if hasContentType(r.Header.Get("Content-Type"), connectJSONContentType) {
+		contentType = connectJSONContentType
+		subtype = "json"
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxRequestBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeConnectError(w, status.Newf(codes.ResourceExhausted, "request exceeds %d bytes", h.maxRequestBytes), nil)
+			return
+		}
+		writeConnectError(w, status.Newf(codes.InvalidArgument, "failed to read request: %v", err), nil)
+		return
+	}
+
+	if subtype == "json" {
+		if st := h.validateJSON(r, body); st != nil {
+			writeConnectError(w, st, nil)
+			return
+		}
+	}
+
+	req := grpcRequest(r, subtype)
+	req.Header.Del("Content-Encoding")
+	req.Header.Del("Connect-Protocol-Version")
+	req.Header.Del("Connect-Timeout-Ms")
+	if timeout := r.Header.Get("Connect-Timeout-Ms"); timeout != "" {
+		ms, err := strconv.ParseInt(timeout, 10, 64)
+		if err != nil || ms <= 0 {
+			writeConnectError(w, status.Newf(codes.InvalidArgument, "invalid Connect-Timeout-Ms %q", timeout), nil)
+			return
+		}
+		req.Header.Set("Grpc-Timeout", strconv.FormatInt(ms, 10)+"m")
+	}
+
+	frame := make([]byte, 5, 5+len(body))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(body)))
+	frame = append(frame, body...)
+	req.Body = io.NopCloser(bytes.NewReader(frame))
+	req.ContentLength = int64(len(frame))
+
+	rw := &connectResponseWriter{responseCapture: newResponseCapture()}
+	h.grpc.ServeHTTP(rw, req)
+
+	st, err := rw.grpcStatus()
+	if err != nil {
+		writeConnectError(w, status.New(codes.Internal, err.Error()), nil)
+		return
+	}
+
+	header := w.Header()
+	for name, values := range rw.responseHeaders() {
+		if isGRPCHeader(name) {
+			continue
+		}
+		header[name] = values
+	}
+	for name, values := range rw.trailers() {
+		if isGRPCHeader(name) {
+			continue
+		}
+		header[connectTrailerPrefix+name] = values
+	}
+
+	if st.Code() != codes.OK {
+		writeConnectError(w, st, header)
+		return
+	}
+
+	msg, err := rw.message()
+	if err != nil {
+		writeConnectError(w, status.New(codes.Internal, err.Error()), nil)
+		return
+	}
+
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Length", strconv.Itoa(len(msg)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(msg)
+}
+
+func isGRPCHeader(name string) bool {
+	return strings.HasPrefix(name, "Grpc-") || name == "Content-Type" || name == "Content-Length"
+}
+
+// writeConnectError writes `st` as a Connect error. `header`, if not nil, is
+// the response's header map, already populated with metadata.
+func writeConnectError(w http.ResponseWriter, st *status.Status, header http.Header) {
+	if header == nil {
+		header = w.Header()
+	}
+
+	mapping, ok := connectCodes[st.Code()]
+	if !ok {
+		mapping = connectCodes[codes.Unknown]
+	}
+
+	body := connectError{Code: mapping.name, Message: st.Message()}
+	for _, detail := range st.Proto().GetDetails() {
+		body.Details = append(body.Details, connectErrorDetails{
+			Type:  strings.TrimPrefix(detail.GetTypeUrl(), "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(detail.GetValue()),
+		})
+	}
+
+	header.Set("Content-Type", connectJSONContentType)
+	w.WriteHeader(mapping.httpStatus)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// connectResponseWriter buffers the gRPC server's response to a unary request.
+type connectResponseWriter struct {
+	responseCapture
+	body bytes.Buffer
+}
+
+func (rw *connectResponseWriter) WriteHeader(status int) {
+	rw.commit(status)
+}
+
+func (rw *connectResponseWriter) Write(p []byte) (int, error) {
+	rw.commit(http.StatusOK)
+	return rw.body.Write(p)
+}
+
+func (rw *connectResponseWriter) Flush() {
+	rw.commit(http.StatusOK)
+}
+
+// grpcStatus returns the status sent by the gRPC server, or an error if the
+// server rejected the request without one.
+func (rw *connectResponseWriter) grpcStatus() (*status.Status, error) {
+	trailers := rw.trailers()
+
+	rawCode := trailers.Get("Grpc-Status")
+	if rawCode == "" {
+		return nil, fmt.Errorf("gRPC server responded with HTTP %d: %s", rw.status, strings.TrimSpace(rw.body.String()))
+	}
+
+	if details := trailers.Get("Grpc-Status-Details-Bin"); details != "" {
+		data, err := decodeBinHeader(details)
+		if err != nil {
+			return nil, fmt.Errorf("malformed grpc-status-details-bin: %w", err)
+		}
+		var st spb.Status
+		if err := proto.Unmarshal(data, &st); err != nil {
+			return nil, fmt.Errorf("malformed grpc-status-details-bin: %w", err)
+		}
+		return status.FromProto(&st), nil
+	}
+
+	code, err := strconv.ParseUint(rawCode, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("malformed grpc-status %q", rawCode)
+	}
+	msg, err := url.PathUnescape(trailers.Get("Grpc-Message"))
+	if err != nil {
+		msg = trailers.Get("Grpc-Message")
+	}
+	return status.New(codes.Code(code), msg), nil
+}
+
+// message returns the single message of a successful unary response.
+func (rw *connectResponseWriter) message() ([]byte, error) {
+	data := rw.body.Bytes()
+	if len(data) < 5 {
+		return nil, errors.New("gRPC server sent no response message")
+	}
+	if data[0] != 0 {
+		return nil, errors.New("gRPC server sent a compressed response message")
+	}
+
+	length := binary.BigEndian.Uint32(data[1:5])
+	if uint64(len(data)-5) != uint64(length) {
+		return nil, errors.New("gRPC server sent a malformed response message")
+	}
+	return data[5:], nil
+}
+
+// decodeBinHeader decodes a binary gRPC header, which may or may not be
+// padded.
+func decodeBinHeader(v string) ([]byte, error) {
+	if len(v)%4 == 0 {
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return base64.RawStdEncoding.DecodeString(v)
+}
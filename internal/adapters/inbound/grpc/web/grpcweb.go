@@ -0,0 +1,101 @@
+package web
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// grpcWebTrailerFlag marks a gRPC-Web message frame that carries trailers.
+const grpcWebTrailerFlag = 0x80
+
+// serveGRPCWeb serves a binary gRPC-Web request. gRPC-Web frames messages as
+// gRPC does, but sends trailers in a final frame of the body rather than as
+// HTTP trailers, which browsers cannot read. The base64 "grpc-web-text"
+// variant is not supported.
+func (h *Handler) serveGRPCWeb(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, grpcWebContentType+"-text") {
+		http.Error(w, "grpc-web-text is not supported", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	subtype := "proto"
+	if _, s, ok := strings.Cut(contentType, "+"); ok && s != "" {
+		subtype = s
+	}
+
+	rw := &grpcWebResponseWriter{
+		responseCapture: newResponseCapture(),
+		w:               w,
+		contentType:     grpcWebContentType + "+" + subtype,
+	}
+	h.grpc.ServeHTTP(rw, grpcRequest(r, subtype))
+	rw.finish()
+}
+
+// grpcWebResponseWriter streams the gRPC server's response to the client,
+// appending its trailers as a gRPC-Web trailer frame.
+type grpcWebResponseWriter struct {
+	responseCapture
+	w           http.ResponseWriter
+	contentType string
+}
+
+func (rw *grpcWebResponseWriter) WriteHeader(status int) {
+	if !rw.commit(status) {
+		return
+	}
+
+	header := rw.w.Header()
+	for name, values := range rw.responseHeaders() {
+		header[name] = values
+	}
+	if status == http.StatusOK {
+		header.Set("Content-Type", rw.contentType)
+	}
+	rw.w.WriteHeader(status)
+}
+
+func (rw *grpcWebResponseWriter) Write(p []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	return rw.w.Write(p)
+}
+
+func (rw *grpcWebResponseWriter) Flush() {
+	rw.WriteHeader(http.StatusOK)
+	if flusher, ok := rw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish writes the trailer frame once the gRPC server has returned.
+func (rw *grpcWebResponseWriter) finish() {
+	trailers := rw.trailers()
+	if len(trailers) == 0 {
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+
+	names := make([]string, 0, len(trailers))
+	for name := range trailers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var block bytes.Buffer
+	for _, name := range names {
+		for _, value := range trailers[name] {
+			block.WriteString(strings.ToLower(name) + ": " + value + "\r\n")
+		}
+	}
+
+	frame := make([]byte, 5, 5+block.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(block.Len()))
+	frame = append(frame, block.Bytes()...)
+
+	_, _ = rw.w.Write(frame)
+}
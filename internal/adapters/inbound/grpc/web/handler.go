@@ -0,0 +1,217 @@
+// Package web serves a gRPC server to browsers. It translates gRPC-Web and
+// Connect requests into native gRPC requests for the server's ServeHTTP
+// handler, so that every protocol shares the same service implementation and
+// interceptor chain.
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+)
+
+const (
+	grpcContentType    = "application/grpc"
+	grpcWebContentType = "application/grpc-web"
+
+	connectProtoContentType  = "application/proto"
+	connectJSONContentType   = "application/json"
+	connectStreamContentType = "application/connect"
+)
+
+// exposedHeaders are the response headers browsers may read from cross-origin
+// responses. gRPC-Web clients read the status from them when a response has no
+// body.
+var exposedHeaders = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+
+// Options configures a [Handler].
+type Options struct {
+	// AllowedOrigins lists the origins permitted to make cross-origin
+	// requests, or "*" for any origin. Empty disables CORS.
+	AllowedOrigins []string
+	// MaxRequestBytes limits the size of Connect request bodies, which are
+	// read into memory before being passed to the gRPC server. Zero means 4
+	// MiB, the gRPC default.
+	MaxRequestBytes int64
+	// OpenAPI, if set, is served at /openapi.json with a documentation page at
+	// /docs, and Connect JSON requests are validated against it.
+	OpenAPI *openapi.Document
+}
+
+// Handler routes native gRPC, gRPC-Web and Connect requests to a gRPC server.
+type Handler struct {
+	grpc            http.Handler
+	allowedOrigins  map[string]bool
+	allowAnyOrigin  bool
+	maxRequestBytes int64
+	document        *openapi.Document
+	openAPI         []byte
+}
+
+// NewHandler returns a handler serving `grpcServer`, typically a
+// [google.golang.org/grpc.Server]. Native gRPC requests require HTTP/2, which
+// for plaintext connections means wrapping the handler with h2c.
+func NewHandler(grpcServer http.Handler, opts Options) *Handler {
+	h := &Handler{
+		grpc:            grpcServer,
+		allowedOrigins:  make(map[string]bool, len(opts.AllowedOrigins)),
+		maxRequestBytes: opts.MaxRequestBytes,
+		document:        opts.OpenAPI,
+	}
+
+	if opts.OpenAPI != nil {
+		// Documents consist only of strings, maps and slices, which always
+		// marshal.
+		h.openAPI, _ = json.Marshal(opts.OpenAPI)
+	}
+
+	for _, origin := range opts.AllowedOrigins {
+		if origin == "*" {
+			h.allowAnyOrigin = true
+		}
+		h.allowedOrigins[origin] = true
+	}
+
+	if h.maxRequestBytes <= 0 {
+		h.maxRequestBytes = 4 << 20
+	}
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.handleCORS(w, r) || h.serveDocs(w, r) {
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case hasContentType(contentType, grpcWebContentType):
+		h.serveGRPCWeb(w, r)
+	case hasContentType(contentType, grpcContentType):
+		h.grpc.ServeHTTP(w, r)
+	case hasContentType(contentType, connectStreamContentType):
+		http.Error(w, "streaming Connect requests are not supported", http.StatusUnsupportedMediaType)
+	case isConnectUnary(contentType):
+		h.serveConnect(w, r)
+	default:
+		http.Error(w, "unsupported content type "+contentType, http.StatusUnsupportedMediaType)
+	}
+}
+
+// hasContentType reports whether `contentType` is `base` or one of its
+// subtypes, such as "application/grpc+proto" for "application/grpc".
+func hasContentType(contentType, base string) bool {
+	if !strings.HasPrefix(contentType, base) {
+		return false
+	}
+	rest := contentType[len(base):]
+	return rest == "" || rest[0] == '+' || rest[0] == ';'
+}
+
+// handleCORS sets the CORS headers of requests from allowed origins, and
+// reports whether the request was a preflight request that has been answered.
+func (h *Handler) handleCORS(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !(h.allowAnyOrigin || h.allowedOrigins[origin]) {
+		return false
+	}
+
+	header := w.Header()
+	header.Set("Access-Control-Allow-Origin", origin)
+	header.Add("Vary", "Origin")
+
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		header.Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
+		return false
+	}
+
+	header.Set("Access-Control-Allow-Methods", http.MethodPost)
+	if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+		header.Set("Access-Control-Allow-Headers", requested)
+	}
+	header.Set("Access-Control-Max-Age", "7200")
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// grpcRequest returns a copy of `r` that the gRPC server accepts as a native
+// gRPC request with the given content subtype.
+func grpcRequest(r *http.Request, subtype string) *http.Request {
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2.0"
+
+	req.Header.Set("Content-Type", grpcContentType+"+"+subtype)
+	req.Header.Set("Te", "trailers")
+	req.Header.Del("Content-Length")
+
+	return req
+}
+
+// responseCapture collects the headers written by the gRPC server. The server
+// sends its status as HTTP trailers, which it sets on the header map once the
+// response has been committed. Headers present at commit are the response
+// headers, and those declared as trailers or set with [http.TrailerPrefix] are
+// the trailers.
+type responseCapture struct {
+	header    http.Header
+	status    int
+	committed bool
+	declared  map[string]bool
+}
+
+func newResponseCapture() responseCapture {
+	return responseCapture{header: make(http.Header)}
+}
+
+func (c *responseCapture) Header() http.Header {
+	return c.header
+}
+
+// commit records the response status and the trailers declared so far. It
+// reports whether this was the first call.
+func (c *responseCapture) commit(status int) bool {
+	if c.committed {
+		return false
+	}
+	c.committed = true
+	c.status = status
+
+	c.declared = make(map[string]bool)
+	for _, value := range c.header.Values("Trailer") {
+		for _, name := range strings.Split(value, ",") {
+			c.declared[http.CanonicalHeaderKey(strings.TrimSpace(name))] = true
+		}
+	}
+
+	return true
+}
+
+// responseHeaders returns the headers that are not trailers, excluding those
+// that only concern the gRPC transport.
+func (c *responseCapture) responseHeaders() http.Header {
+	headers := make(http.Header)
+	for name, values := range c.header {
+		if name == "Trailer" || c.declared[name] || strings.HasPrefix(name, http.TrailerPrefix) {
+			continue
+		}
+		headers[name] = values
+	}
+	return headers
+}
+
+func (c *responseCapture) trailers() http.Header {
+	trailers := make(http.Header)
+	for name, values := range c.header {
+		switch {
+		case strings.HasPrefix(name, http.TrailerPrefix):
+			name = http.CanonicalHeaderKey(strings.TrimPrefix(name, http.TrailerPrefix))
+		case !c.declared[name]:
+			continue
+		}
+		trailers[name] = append(trailers[name], values...)
+	}
+	return trailers
+}
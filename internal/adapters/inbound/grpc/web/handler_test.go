@@ -0,0 +1,474 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	pb "github.com/TeamKweku/code-odessey-hex-arch-proto/protogen/go/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	grpcMetadata "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/metadata"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/middleware"
+	userGRPC "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/inbound/grpc/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/memory"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	mock_user "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+)
+
+const registerMethod = pb.UserService_Register_FullMethodName
+
+// testBurst is the number of Register calls a client may make before the
+// rate limiter rejects it. The rate is low enough that no tokens are refilled
+// while a test runs.
+const testBurst = 2
+
+// newTestServer serves the user service behind the server's own interceptor
+// chain, with the user service itself mocked.
+func newTestServer(t *testing.T, opts Options) (*httptest.Server, *mock_user.MockUserService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	users := mock_user.NewMockUserService(ctrl)
+
+	tokens, err := auth.NewPasetoToken()
+	require.NoError(t, err)
+
+	log := logger.NewZerologLogger(false)
+	extractor := grpcMetadata.NewMetadataExtractor()
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		middleware.GrpcAuth(tokens),
+		middleware.GrpcRateLimit(ratelimit.NewMemoryStore(), extractor, middleware.RateLimitOptions{
+			Limits: map[string]ratelimit.Limit{registerMethod: {Rate: 0.001, Burst: testBurst}},
+		}, log),
+		middleware.GrpcIdempotency(memory.New(), middleware.IdempotencyOptions{
+			Methods: []string{registerMethod},
+		}, log),
+	))
+	userGRPC.NewServer(users, config.Default(), tokens, nil, extractor).RegisterServer(grpcServer)
+
+	handler := NewHandler(grpcServer, opts)
+	server := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	t.Cleanup(func() {
+		server.Close()
+		grpcServer.Stop()
+	})
+	return server, users
+}
+
+func randomRegisterRequest() *pb.RegisterUserRequest {
+	return &pb.RegisterUserRequest{
+		Username:     domainUser.RandomUsernameCandidate(),
+		Email:        domainUser.RandomEmailAddressCandidate(),
+		PasswordHash: domainUser.RandomPasswordCandidate(),
+	}
+}
+
+// registerResult is the outcome of a Register call, whatever the protocol.
+type registerResult struct {
+	code     codes.Code
+	username string
+	replayed bool
+}
+
+type registerCaller func(t *testing.T, server *httptest.Server, md metadata.MD, req *pb.RegisterUserRequest) registerResult
+
+func callGRPC(t *testing.T, server *httptest.Server, md metadata.MD, req *pb.RegisterUserRequest) registerResult {
+	conn, err := grpc.NewClient(server.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var header metadata.MD
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	resp, err := pb.NewUserServiceClient(conn).Register(ctx, req, grpc.Header(&header))
+
+	return registerResult{
+		code:     status.Code(err),
+		username: resp.GetUser().GetUsername(),
+		replayed: len(header.Get("idempotent-replayed")) > 0,
+	}
+}
+
+func callGRPCWeb(t *testing.T, server *httptest.Server, md metadata.MD, req *pb.RegisterUserRequest) registerResult {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	body := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(data)))
+	body = append(body, data...)
+
+	httpReq, err := http.NewRequest(http.MethodPost, server.URL+registerMethod, bytes.NewReader(body))
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/grpc-web+proto")
+	setHeaders(httpReq, md)
+
+	resp, err := server.Client().Do(httpReq)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/grpc-web+proto", resp.Header.Get("Content-Type"))
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	result := registerResult{replayed: resp.Header.Get("Idempotent-Replayed") != ""}
+	for len(respBody) > 0 {
+		require.GreaterOrEqual(t, len(respBody), 5)
+		flag, length := respBody[0], binary.BigEndian.Uint32(respBody[1:5])
+		frame := respBody[5 : 5+length]
+		respBody = respBody[5+length:]
+
+		if flag&grpcWebTrailerFlag == 0 {
+			out := new(pb.RegisterUserResponse)
+			require.NoError(t, proto.Unmarshal(frame, out))
+			result.username = out.GetUser().GetUsername()
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(string(frame)), "\r\n") {
+			name, value, _ := strings.Cut(line, ": ")
+			if name == "grpc-status" {
+				code, err := strconv.Atoi(value)
+				require.NoError(t, err)
+				result.code = codes.Code(code)
+			}
+		}
+	}
+	return result
+}
+
+func callConnect(contentType string) registerCaller {
+	return func(t *testing.T, server *httptest.Server, md metadata.MD, req *pb.RegisterUserRequest) registerResult {
+		var body []byte
+		var err error
+		if contentType == connectJSONContentType {
+			body, err = protojson.Marshal(req)
+		} else {
+			body, err = proto.Marshal(req)
+		}
+		require.NoError(t, err)
+
+		httpReq, err := http.NewRequest(http.MethodPost, server.URL+registerMethod, bytes.NewReader(body))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", contentType)
+		httpReq.Header.Set("Connect-Protocol-Version", "1")
+		setHeaders(httpReq, md)
+
+		resp, err := server.Client().Do(httpReq)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		result := registerResult{replayed: resp.Header.Get("Idempotent-Replayed") != ""}
+
+		if resp.StatusCode != http.StatusOK {
+			var connectErr connectError
+			require.NoError(t, json.Unmarshal(respBody, &connectErr))
+			result.code = connectCode(t, connectErr.Code, resp.StatusCode)
+			return result
+		}
+
+		assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+		out := new(pb.RegisterUserResponse)
+		if contentType == connectJSONContentType {
+			require.NoError(t, protojson.Unmarshal(respBody, out))
+		} else {
+			require.NoError(t, proto.Unmarshal(respBody, out))
+		}
+		result.username = out.GetUser().GetUsername()
+		return result
+	}
+}
+
+// connectCode returns the gRPC code of a Connect error, checking that it was
+// sent with the HTTP status the Connect protocol assigns to it.
+func connectCode(t *testing.T, name string, httpStatus int) codes.Code {
+	t.Helper()
+
+	for code, mapping := range connectCodes {
+		if mapping.name == name {
+			assert.Equal(t, mapping.httpStatus, httpStatus, "HTTP status of %s", name)
+			return code
+		}
+	}
+	t.Fatalf("unknown Connect error code %q", name)
+	return codes.Unknown
+}
+
+func setHeaders(req *http.Request, md metadata.MD) {
+	for name, values := range md {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+}
+
+func TestHandler_Protocols(t *testing.T) {
+	t.Parallel()
+
+	protocols := []struct {
+		name string
+		call registerCaller
+	}{
+		{name: "gRPC", call: callGRPC},
+		{name: "gRPC-Web", call: callGRPCWeb},
+		{name: "Connect proto", call: callConnect(connectProtoContentType)},
+		{name: "Connect JSON", call: callConnect(connectJSONContentType)},
+	}
+
+	// call is one request of a test case and the result it should get.
+	type call struct {
+		md   metadata.MD
+		req  *pb.RegisterUserRequest
+		want registerResult
+	}
+
+	registered := domainUser.RandomUser(t)
+	username := registered.Username().String()
+	req := randomRegisterRequest()
+	otherReq := randomRegisterRequest()
+	invalidReq := randomRegisterRequest()
+	invalidReq.Email = "not-an-email"
+	idempotencyKey := metadata.Pairs("idempotency-key", "8a2c7f0e-5a43-4c39-9a49-1f4b8de5a6f2")
+
+	tests := []struct {
+		name      string
+		calls     []call
+		registers int
+	}{
+		{
+			name:      "Success",
+			calls:     []call{{req: req, want: registerResult{code: codes.OK, username: username}}},
+			registers: 1,
+		},
+		{
+			name: "Invalid access token rejected by auth interceptor",
+			calls: []call{{
+				md:   metadata.Pairs("authorization", "Bearer not-a-token"),
+				req:  req,
+				want: registerResult{code: codes.Unauthenticated},
+			}},
+		},
+		{
+			name: "Rate limit exceeded",
+			calls: []call{
+				{req: req, want: registerResult{code: codes.OK, username: username}},
+				{req: req, want: registerResult{code: codes.OK, username: username}},
+				{req: req, want: registerResult{code: codes.ResourceExhausted}},
+			},
+			registers: testBurst,
+		},
+		{
+			name: "Idempotent retry replayed",
+			calls: []call{
+				{md: idempotencyKey, req: req, want: registerResult{code: codes.OK, username: username}},
+				{md: idempotencyKey, req: req, want: registerResult{code: codes.OK, username: username, replayed: true}},
+			},
+			registers: 1,
+		},
+		{
+			name: "Idempotency key reused with a different request",
+			calls: []call{
+				{md: idempotencyKey, req: req, want: registerResult{code: codes.OK, username: username}},
+				{md: idempotencyKey, req: otherReq, want: registerResult{code: codes.FailedPrecondition}},
+			},
+			registers: 1,
+		},
+		{
+			name:  "Invalid request rejected by server",
+			calls: []call{{req: invalidReq, want: registerResult{code: codes.InvalidArgument}}},
+		},
+	}
+
+	for _, protocol := range protocols {
+		protocol := protocol
+		for _, tt := range tests {
+			tt := tt
+			t.Run(protocol.name+"/"+tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				server, users := newTestServer(t, Options{})
+				users.EXPECT().Register(gomock.Any(), gomock.Any()).Return(registered, nil).Times(tt.registers)
+
+				for i, c := range tt.calls {
+					got := protocol.call(t, server, c.md, c.req)
+					assert.Equal(t, c.want, got, "call %d", i)
+				}
+			})
+		}
+	}
+}
+
+func TestHandler_CORS(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t, Options{AllowedOrigins: []string{"https://app.example.com"}})
+
+	tests := []struct {
+		name       string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{
+			name:       "Allowed origin",
+			origin:     "https://app.example.com",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "https://app.example.com",
+		},
+		{
+			name:       "Disallowed origin",
+			origin:     "https://evil.example.com",
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodOptions, server.URL+registerMethod, nil)
+			require.NoError(t, err)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestHandler_OpenAPI(t *testing.T) {
+	t.Parallel()
+
+	doc := openapi.NewDocument(openapi.Spec{
+		Info:    openapi.Info{Title: "Users", Version: "v1"},
+		Routes:  userGRPC.Routes(),
+		Schemas: userGRPC.Schemas(),
+		Error:   ErrorSchema(),
+	})
+	server, users := newTestServer(t, Options{OpenAPI: doc})
+
+	registered := domainUser.RandomUser(t)
+	users.EXPECT().Register(gomock.Any(), gomock.Any()).Return(registered, nil).Times(1)
+
+	t.Run("Serves document", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := server.Client().Get(server.URL + openAPIPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		assert.Contains(t, decoded["paths"], registerMethod)
+	})
+
+	t.Run("Serves docs page", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := server.Client().Get(server.URL + docsPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), openAPIPath)
+	})
+
+	t.Run("Rejects invalid JSON request before the interceptors", func(t *testing.T) {
+		t.Parallel()
+
+		req := randomRegisterRequest()
+		req.Username = "ab"
+		body, err := protojson.Marshal(req)
+		require.NoError(t, err)
+
+		// The auth interceptor would reject the token as Unauthenticated, so
+		// InvalidArgument shows validation ran first.
+		httpReq, err := http.NewRequest(http.MethodPost, server.URL+registerMethod, bytes.NewReader(body))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", connectJSONContentType)
+		httpReq.Header.Set("Authorization", "Bearer not-a-token")
+
+		resp, err := server.Client().Do(httpReq)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		var connectErr connectError
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&connectErr))
+		assert.Equal(t, "invalid_argument", connectErr.Code)
+		require.Len(t, connectErr.Details, 1)
+		assert.Equal(t, "google.rpc.BadRequest", connectErr.Details[0].Type)
+
+		data, err := decodeBinHeader(connectErr.Details[0].Value)
+		require.NoError(t, err)
+		var badRequest errdetails.BadRequest
+		require.NoError(t, proto.Unmarshal(data, &badRequest))
+		require.Len(t, badRequest.GetFieldViolations(), 1)
+		assert.Equal(t, "username", badRequest.GetFieldViolations()[0].GetField())
+	})
+
+	t.Run("Passes valid JSON request", func(t *testing.T) {
+		t.Parallel()
+
+		got := callConnect(connectJSONContentType)(t, server, nil, randomRegisterRequest())
+		assert.Equal(t, registerResult{code: codes.OK, username: registered.Username().String()}, got)
+	})
+}
+
+func TestJSONNames(t *testing.T) {
+	t.Parallel()
+
+	got := jsonNames(map[string]interface{}{
+		"password_hash": "secret",
+		"user":          map[string]interface{}{"created_at": "now", "email": "a@b.com"},
+		"tags":          []interface{}{map[string]interface{}{"tag_name": "x"}},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"passwordHash": "secret",
+		"user":         map[string]interface{}{"createdAt": "now", "email": "a@b.com"},
+		"tags":         []interface{}{map[string]interface{}{"tagName": "x"}},
+	}, got)
+}
@@ -0,0 +1,151 @@
+package web
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/openapi"
+)
+
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// ErrorSchema describes the JSON body of a failed Connect request, for use as
+// the error schema of an OpenAPI document.
+func ErrorSchema() *openapi.Schema {
+	codeNames := make([]string, 0, len(connectCodes))
+	for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+		codeNames = append(codeNames, connectCodes[code].name)
+	}
+
+	return &openapi.Schema{
+		Type:     "object",
+		Required: []string{"code"},
+		Properties: map[string]*openapi.Schema{
+			"code":    {Type: "string", Enum: codeNames},
+			"message": {Type: "string"},
+			"details": {
+				Type: "array",
+				Items: &openapi.Schema{
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"type":  {Type: "string", Description: "The fully qualified name of the detail's protobuf message."},
+						"value": {Type: "string", Format: "byte", Description: "The detail as unpadded base64-encoded protobuf."},
+					},
+				},
+			},
+		},
+	}
+}
+
+// serveDocs serves the OpenAPI document and the documentation page, and
+// reports whether the request was for either.
+func (h *Handler) serveDocs(w http.ResponseWriter, r *http.Request) bool {
+	if h.openAPI == nil || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return false
+	}
+
+	switch r.URL.Path {
+	case openAPIPath:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(h.openAPI)
+	case docsPath:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(docsPage)
+	default:
+		return false
+	}
+	return true
+}
+
+// validateJSON validates a Connect JSON request body against the OpenAPI
+// document, returning an InvalidArgument status listing the violations.
+func (h *Handler) validateJSON(r *http.Request, body []byte) *status.Status {
+	if h.document == nil {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return status.Newf(codes.InvalidArgument, "request body is not valid JSON: %v", err)
+	}
+
+	err := h.document.ValidateRequest(r.Method, r.URL.Path, jsonNames(decoded))
+	if err == nil {
+		return nil
+	}
+
+	validationErr, ok := err.(*openapi.ValidationError)
+	if !ok {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, validationErr.Error())
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// jsonNames renames the fields of a decoded JSON value to the lowerCamelCase
+// names used by the OpenAPI document. The protobuf JSON mapping also accepts
+// the original snake_case field names, which would otherwise bypass
+// validation.
+func jsonNames(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(v))
+		for name, field := range v {
+			renamed[lowerCamelCase(name)] = jsonNames(field)
+		}
+		return renamed
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNames(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func lowerCamelCase(name string) string {
+	if !strings.Contains(name, "_") {
+		return name
+	}
+
+	var builder strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
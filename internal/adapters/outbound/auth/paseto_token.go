@@ -38,9 +38,9 @@ func NewPasetoToken() (inbound.TokenService, error) {
 // CreateToken creates a new paseto token
 func (pt *PasetoToken) CreateToken(
 	user *user.User,
-	durationStr string,
+	duration time.Duration,
 ) (string, *auth.Payload, error) {
-	payload, err := auth.NewPayload(user, durationStr)
+	payload, err := auth.NewPayload(user, duration)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to set token payload: %w", err)
 	}
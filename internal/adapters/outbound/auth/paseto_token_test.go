@@ -20,7 +20,7 @@ func TestPasetoToken_CreateToken(t *testing.T) {
 
 	usr := user.RandomUser(t)
 	cfg := config.Config{
-		AccessTokenDuration: "30m",
+		AccessTokenDuration: 30 * time.Minute,
 	}
 
 	token, payload, err := pt.CreateToken(usr, cfg.AccessTokenDuration)
@@ -37,7 +37,7 @@ func TestPasetoToken_VerifyToken(t *testing.T) {
 
 	usr := user.RandomUser(t)
 	cfg := config.Config{
-		AccessTokenDuration: "30m",
+		AccessTokenDuration: 30 * time.Minute,
 	}
 
 	token, payload, err := pt.CreateToken(usr, cfg.AccessTokenDuration)
@@ -58,7 +58,7 @@ func TestPasetoToken_VerifyToken_Expired(t *testing.T) {
 
 	usr := user.RandomUser(t)
 	cfg := config.Config{
-		AccessTokenDuration: "10ms", // Set a short duration for testing expiration
+		AccessTokenDuration: 10 * time.Millisecond, // Set a short duration for testing expiration
 	}
 
 	token, payload, err := pt.CreateToken(usr, cfg.AccessTokenDuration)
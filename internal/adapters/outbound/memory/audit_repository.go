@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.AuditLogger = (*Client)(nil)
+
+func (c *Client) Record(ctx context.Context, entry *audit.Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records.Record(ctx, entry)
+}
+
+func (r *records) Record(_ context.Context, entry *audit.Entry) error {
+	var (
+		prevSequence int64
+		prevHash     []byte
+	)
+	if n := len(r.auditLog); n > 0 {
+		prevSequence, prevHash = r.auditLog[n-1].Sequence, r.auditLog[n-1].Hash
+	}
+
+	entry.Seal(prevSequence, prevHash)
+	r.auditLog = append(r.auditLog, cloneEntry(entry))
+	return nil
+}
+
+func (c *Client) Query(_ context.Context, filter audit.Filter) ([]*audit.Entry, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	log := c.records.auditLog
+	if filter.NewestFirst {
+		log = slices.Clone(log)
+		slices.Reverse(log)
+	}
+
+	var entries []*audit.Entry
+	for _, entry := range log {
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+		if matchesFilter(entry, filter) {
+			entries = append(entries, cloneEntry(entry))
+		}
+	}
+	return entries, nil
+}
+
+func matchesFilter(entry *audit.Entry, filter audit.Filter) bool {
+	if filter.ActorID.IsSome() && entry.ActorID != filter.ActorID.UnwrapOrZero() {
+		return false
+	}
+	if filter.Target.IsSome() && entry.Target != filter.Target.UnwrapOrZero() {
+		return false
+	}
+	if !filter.From.IsZero() && entry.OccurredAt.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !entry.OccurredAt.Before(filter.To) {
+		return false
+	}
+	if filter.AfterSequence != 0 && entry.Sequence <= filter.AfterSequence {
+		return false
+	}
+	if filter.BeforeSequence != 0 && entry.Sequence >= filter.BeforeSequence {
+		return false
+	}
+	return true
+}
+
+func cloneEntry(entry *audit.Entry) *audit.Entry {
+	clone := *entry
+	clone.Details = maps.Clone(entry.Details)
+	clone.PrevHash = append([]byte{}, entry.PrevHash...)
+	clone.Hash = append([]byte{}, entry.Hash...)
+	return &clone
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+)
+
+// Client is an in-memory store safe for concurrent use. Stored values are
+// copied on the way in and out, so callers never share state with the store.
+type Client struct {
+	mu sync.RWMutex
+
+	records         *records
+	idempotencyKeys map[idempotency.Key]*idempotency.Record
+	// claimedMessages are the outbox messages being processed, which are not
+	// claimed again until processing ends.
+	claimedMessages map[uuid.UUID]struct{}
+	// jobLocks are the names of the jobs being run.
+	jobLocks map[string]struct{}
+
+	now func() time.Time
+}
+
+// New returns an empty Client.
+func New() *Client {
+	return &Client{
+		records:         newRecords(time.Now),
+		idempotencyKeys: make(map[idempotency.Key]*idempotency.Record),
+		claimedMessages: make(map[uuid.UUID]struct{}),
+		jobLocks:        make(map[string]struct{}),
+		now:             time.Now,
+	}
+}
+
+// Close discards all stored data.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.records = newRecords(c.now)
+	clear(c.idempotencyKeys)
+	clear(c.claimedMessages)
+	return nil
+}
+
+// records holds the users, sessions, outbox messages and audit log of a
+// [Client]. It implements the repository ports without synchronization, which
+// is left to the Client.
+type records struct {
+	users          map[uuid.UUID]*user.User
+	userIDsByEmail map[string]uuid.UUID
+	userIDsByName  map[string]uuid.UUID
+	sessions       map[uuid.UUID]*auth.Sessions
+	// outbox holds messages in the order they were appended.
+	outbox []outbox.Message
+	// auditLog holds the audit log in sequence order.
+	auditLog []*audit.Entry
+
+	now func() time.Time
+}
+
+func newRecords(now func() time.Time) *records {
+	return &records{
+		users:          make(map[uuid.UUID]*user.User),
+		userIDsByEmail: make(map[string]uuid.UUID),
+		userIDsByName:  make(map[string]uuid.UUID),
+		sessions:       make(map[uuid.UUID]*auth.Sessions),
+		now:            now,
+	}
+}
+
+// clone returns a copy of `r` that can be modified without affecting `r`.
+// Stored users, sessions, event payloads and audit entries are replaced
+// rather than modified, so they are shared.
+func (r *records) clone() *records {
+	return &records{
+		users:          maps.Clone(r.users),
+		userIDsByEmail: maps.Clone(r.userIDsByEmail),
+		userIDsByName:  maps.Clone(r.userIDsByName),
+		sessions:       maps.Clone(r.sessions),
+		outbox:         slices.Clone(r.outbox),
+		auditLog:       slices.Clone(r.auditLog),
+		now:            r.now,
+	}
+}
+
+// timestamp returns the current time at the microsecond resolution of the
+// Postgres adapter, so that ETags behave the same with either store.
+func (r *records) timestamp() time.Time {
+	return r.now().UTC().Truncate(time.Microsecond)
+}
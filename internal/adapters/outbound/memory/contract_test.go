@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/outboundtest"
+)
+
+func TestClient_Contract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.Run(t, func(*testing.T) outboundtest.Repositories {
+		return New()
+	})
+}
+
+func TestClient_UnitOfWorkContract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.RunUnitOfWork(t, func(*testing.T) outboundtest.TransactionalRepositories {
+		return New()
+	})
+}
+
+func TestClient_OutboxContract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.RunOutbox(t, func(*testing.T) outboundtest.OutboxStore {
+		return New()
+	})
+}
+
+func TestClient_OutboxCleanerContract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.RunOutboxCleaner(t, func(*testing.T) outboundtest.OutboxCleanupStore {
+		return New()
+	})
+}
+
+func TestClient_AuditLoggerContract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.RunAuditLogger(t, func(*testing.T) outboundtest.AuditStore {
+		return New()
+	})
+}
+
+func TestClient_SessionCleanerContract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.RunSessionCleaner(t, func(*testing.T) outboundtest.SessionStore {
+		return New()
+	})
+}
+
+func TestClient_JobLockerContract(t *testing.T) {
+	t.Parallel()
+
+	outboundtest.RunJobLocker(t, func(*testing.T) outbound.JobLocker {
+		return New()
+	})
+}
@@ -0,0 +1,4 @@
+// Package memory provides in-process implementations of the domain outbound
+// interfaces. Data lives only as long as the process, which makes the package
+// suited to local development and tests that should not depend on a database.
+package memory
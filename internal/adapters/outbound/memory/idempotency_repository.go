@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.IdempotencyStore = (*Client)(nil)
+
+func (c *Client) Reserve(
+	_ context.Context,
+	record *idempotency.Record,
+) (*idempotency.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if existing, ok := c.idempotencyKeys[record.Key]; ok && !existing.IsExpired(now) && !existing.IsAbandoned(now) {
+		return cloneRecord(existing), nil
+	}
+
+	c.idempotencyKeys[record.Key] = cloneRecord(record)
+	return nil, nil
+}
+
+func (c *Client) Complete(_ context.Context, key idempotency.Key, response []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if record, ok := c.idempotencyKeys[key]; ok {
+		record.Response = append([]byte{}, response...)
+	}
+	return nil
+}
+
+func (c *Client) Release(_ context.Context, key idempotency.Key) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.idempotencyKeys, key)
+	return nil
+}
+
+func (c *Client) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var deleted int64
+	for key, record := range c.idempotencyKeys {
+		if record.IsExpired(now) {
+			delete(c.idempotencyKeys, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func cloneRecord(record *idempotency.Record) *idempotency.Record {
+	clone := *record
+	if record.Response != nil {
+		clone.Response = append([]byte{}, record.Response...)
+	}
+	return &clone
+}
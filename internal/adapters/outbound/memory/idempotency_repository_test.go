@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+)
+
+func TestClient_Reserve(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	client := New()
+	client.now = func() time.Time { return now }
+
+	record := &idempotency.Record{
+		Key:         idempotency.Key{Principal: "user:1", Method: "/user.UserService/Register", Value: "k1"},
+		Fingerprint: "first",
+		LockedUntil: now.Add(time.Minute),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	existing, err := client.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	retry := *record
+	retry.Fingerprint = "second"
+	existing, err = client.Reserve(ctx, &retry)
+	require.NoError(t, err)
+	require.NotNil(t, existing, "an in-progress record within its lease is kept")
+	assert.Equal(t, "first", existing.Fingerprint)
+
+	now = now.Add(time.Minute)
+	existing, err = client.Reserve(ctx, &retry)
+	require.NoError(t, err)
+	assert.Nil(t, existing, "an abandoned record is replaced")
+
+	require.NoError(t, client.Complete(ctx, record.Key, []byte("response")))
+	now = now.Add(10 * time.Minute)
+	existing, err = client.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing, "a completed record is kept until it expires")
+	assert.Equal(t, "second", existing.Fingerprint)
+	assert.Equal(t, []byte("response"), existing.Response)
+}
@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.JobLocker = (*Client)(nil)
+
+// RunExclusive excludes other callers of the same Client, which suffices for
+// a single replica.
+func (c *Client) RunExclusive(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	c.mu.Lock()
+	if _, held := c.jobLocks[name]; held {
+		c.mu.Unlock()
+		return false, nil
+	}
+	c.jobLocks[name] = struct{}{}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.jobLocks, name)
+		c.mu.Unlock()
+	}()
+
+	return true, fn(ctx)
+}
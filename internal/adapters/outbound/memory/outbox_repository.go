@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.OutboxRelayStore = (*Client)(nil)
+
+// Append stages `messages` in the records of a unit of work.
+func (r *records) Append(_ context.Context, messages ...*outbox.Message) error {
+	for _, msg := range messages {
+		r.outbox = append(r.outbox, *msg)
+	}
+	return nil
+}
+
+// ProcessOutbox claims due messages under the client's lock, but calls
+// `process` without it, so that events published in-process may be handled
+// by calls to the client.
+func (c *Client) ProcessOutbox(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	process func(ctx context.Context, messages []*outbox.Message) error,
+) (int, error) {
+	claimed := c.claimOutbox(now, limit)
+	if len(claimed) == 0 {
+		return 0, nil
+	}
+
+	err := process(ctx, claimed)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, msg := range claimed {
+		delete(c.claimedMessages, msg.Event.ID)
+		if err != nil {
+			continue
+		}
+		for i := range c.records.outbox {
+			if c.records.outbox[i].Event.ID == msg.Event.ID {
+				c.records.outbox[i] = *msg
+				break
+			}
+		}
+	}
+	return len(claimed), err
+}
+
+func (c *Client) claimOutbox(now time.Time, limit int) []*outbox.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var claimed []*outbox.Message
+	for i := range c.records.outbox {
+		if len(claimed) == limit {
+			break
+		}
+
+		msg := c.records.outbox[i]
+		if _, ok := c.claimedMessages[msg.Event.ID]; ok || !msg.IsDue(now) {
+			continue
+		}
+		c.claimedMessages[msg.Event.ID] = struct{}{}
+		claimed = append(claimed, &msg)
+	}
+	return claimed
+}
+
+var _ outbound.OutboxCleaner = (*Client)(nil)
+
+func (c *Client) DeleteFinishedOutboxMessages(
+	_ context.Context,
+	sentBefore, deadBefore time.Time,
+	limit int,
+) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var deleted int64
+	kept := c.records.outbox[:0]
+	for _, msg := range c.records.outbox {
+		finished := (msg.Status == outbox.StatusSent && msg.SentAt.Before(sentBefore)) ||
+			(msg.Status == outbox.StatusDead && msg.Event.OccurredAt.Before(deadBefore))
+		if finished && deleted < int64(limit) {
+			deleted++
+			continue
+		}
+		kept = append(kept, msg)
+	}
+	c.records.outbox = kept
+	return deleted, nil
+}
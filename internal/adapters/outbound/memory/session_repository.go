@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var (
+	_ outbound.SessionRepository = (*Client)(nil)
+	_ outbound.SessionRepository = (*records)(nil)
+)
+
+func (c *Client) CreateSession(ctx context.Context, session *auth.Sessions) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records.CreateSession(ctx, session)
+}
+
+func (c *Client) GetSession(ctx context.Context, id uuid.UUID) (*auth.Sessions, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.records.GetSession(ctx, id)
+}
+
+func (c *Client) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*auth.Sessions, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.records.GetSessionByUserID(ctx, userID)
+}
+
+func (c *Client) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records.DeleteSession(ctx, id)
+}
+
+// CreateSession stores `session` under its ID. The session's user must
+// exist.
+func (r *records) CreateSession(_ context.Context, session *auth.Sessions) error {
+	if _, ok := r.users[session.UserID]; !ok {
+		return fmt.Errorf("failed to create session: user %q does not exist", session.UserID)
+	}
+
+	stored := *session
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = r.timestamp()
+	}
+	r.sessions[stored.ID] = &stored
+
+	return nil
+}
+
+func (r *records) GetSession(_ context.Context, id uuid.UUID) (*auth.Sessions, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get session: %w", auth.ErrSessionNotFound)
+	}
+
+	clone := *session
+	return &clone, nil
+}
+
+// GetSessionByUserID returns the most recently created session of the user.
+func (r *records) GetSessionByUserID(_ context.Context, userID uuid.UUID) (*auth.Sessions, error) {
+	var latest *auth.Sessions
+	for _, session := range r.sessions {
+		if session.UserID != userID {
+			continue
+		}
+		if latest == nil || session.CreatedAt.After(latest.CreatedAt) {
+			latest = session
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("failed to get session by user ID: %w", auth.ErrSessionNotFound)
+	}
+
+	clone := *latest
+	return &clone, nil
+}
+
+// DeleteSession removes the session with `id`. Deleting a session that does
+// not exist is not an error.
+func (r *records) DeleteSession(_ context.Context, id uuid.UUID) error {
+	delete(r.sessions, id)
+	return nil
+}
+
+var _ outbound.SessionCleaner = (*Client)(nil)
+
+func (c *Client) DeleteStaleSessions(
+	_ context.Context,
+	expiredBefore, blockedBefore time.Time,
+	limit int,
+) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var deleted int64
+	for id, session := range c.records.sessions {
+		if deleted == int64(limit) {
+			break
+		}
+		if session.ExpiresAt.Before(expiredBefore) || (session.IsBlocked && session.CreatedAt.Before(blockedBefore)) {
+			delete(c.records.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
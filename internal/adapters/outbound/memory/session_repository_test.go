@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+)
+
+func TestClient_Sessions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := New()
+
+	usr, err := client.CreateUser(ctx, user.RandomRegistrationRequest(t))
+	require.NoError(t, err)
+
+	session := &auth.Sessions{
+		ID:           uuid.New(),
+		UserID:       usr.ID(),
+		RefreshToken: "refresh-token",
+		UserAgent:    "test-agent",
+		ClientIP:     auth.NewClientIP(netip.MustParseAddr("203.0.113.7")),
+		ExpiresAt:    time.Now().Add(time.Hour).UTC(),
+	}
+	require.NoError(t, client.CreateSession(ctx, session))
+
+	stored, err := client.GetSessionByUserID(ctx, usr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, stored.ID)
+	assert.Equal(t, session.RefreshToken, stored.RefreshToken)
+	assert.Equal(t, session.UserAgent, stored.UserAgent)
+	assert.Equal(t, session.ClientIP, stored.ClientIP)
+	assert.Equal(t, session.ExpiresAt, stored.ExpiresAt)
+	assert.False(t, stored.CreatedAt.IsZero())
+
+	byID, err := client.GetSession(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, stored, byID)
+
+	require.NoError(t, client.DeleteSession(ctx, stored.ID))
+	_, err = client.GetSession(ctx, stored.ID)
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+	_, err = client.GetSessionByUserID(ctx, usr.ID())
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+
+	assert.NoError(t, client.DeleteSession(ctx, stored.ID), "deleting a missing session is not an error")
+}
+
+func TestClient_CreateSession_UnknownUser(t *testing.T) {
+	t.Parallel()
+
+	err := New().CreateSession(context.Background(), &auth.Sessions{UserID: uuid.New()})
+	assert.Error(t, err)
+}
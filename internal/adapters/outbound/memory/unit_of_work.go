@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.UnitOfWork = (*Client)(nil)
+
+// Do calls `fn` with repositories over a copy of the stored users, sessions,
+// outbox messages and audit log, which replaces them if `fn` succeeds. Units
+// of work hold the client's write lock throughout, so they are serialized with each other and
+// with every other write, and `fn` must not call the client itself.
+func (c *Client) Do(_ context.Context, fn func(repos outbound.Repositories) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	staged := c.records.clone()
+	if err := fn(txRepositories{records: staged}); err != nil {
+		return err
+	}
+
+	c.records = staged
+	return nil
+}
+
+// txRepositories serves the repository ports from the records staged by a
+// unit of work.
+type txRepositories struct {
+	records *records
+}
+
+func (r txRepositories) Users() outbound.UserRepository {
+	return r.records
+}
+
+func (r txRepositories) Sessions() outbound.SessionRepository {
+	return r.records
+}
+
+func (r txRepositories) Outbox() outbound.OutboxRepository {
+	return r.records
+}
+
+func (r txRepositories) AuditLog() outbound.AuditRecorder {
+	return r.records
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+var (
+	_ outbound.UserRepository = (*Client)(nil)
+	_ outbound.UserRepository = (*records)(nil)
+)
+
+func (c *Client) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.records.GetUserByID(ctx, id)
+}
+
+func (c *Client) GetUserByEmail(ctx context.Context, email user.EmailAddress) (*user.User, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.records.GetUserByEmail(ctx, email)
+}
+
+func (c *Client) CreateUser(ctx context.Context, req *user.RegistrationRequest) (*user.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records.CreateUser(ctx, req)
+}
+
+func (c *Client) UpdateUser(ctx context.Context, req *user.UpdateRequest) (*user.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records.UpdateUser(ctx, req)
+}
+
+// GetUserByID returns the [user.User] with the given ID or
+// [user.NotFoundError] if no such user is found
+func (r *records) GetUserByID(
+	_ context.Context,
+	id uuid.UUID,
+) (*user.User, error) {
+	usr, ok := r.users[id]
+	if !ok {
+		return nil, user.NewNotFoundByIDError(id)
+	}
+	return cloneUser(usr), nil
+}
+
+// GetUserByEmail returns the [user.User] with the given email, or
+// [user.NotFoundError] if no user exists with email
+func (r *records) GetUserByEmail(
+	_ context.Context,
+	email user.EmailAddress,
+) (*user.User, error) {
+	id, ok := r.userIDsByEmail[email.String()]
+	if !ok {
+		return nil, user.NewNotFoundByEmailError(email)
+	}
+	return cloneUser(r.users[id]), nil
+}
+
+// CreateUser creates a new user record from the given
+// [user.RegistrationRequest] and returns the created [user.User].
+//
+// Returns [user.ValidationError] if the username or email is taken.
+func (r *records) CreateUser(
+	_ context.Context,
+	req *user.RegistrationRequest,
+) (*user.User, error) {
+	if _, ok := r.userIDsByName[req.Username().String()]; ok {
+		return nil, user.NewDuplicateUsernameError(req.Username())
+	}
+	if _, ok := r.userIDsByEmail[req.Email().String()]; ok {
+		return nil, user.NewDuplicateEmailError(req.Email())
+	}
+
+	id := uuid.New()
+	// As in Postgres, password_changed_at and updated_at start at the zero
+	// time and are only set by updates.
+	var updatedAt time.Time
+	usr := user.NewUser(
+		id,
+		etag.New(id, updatedAt),
+		req.Username(),
+		req.Email(),
+		req.PasswordHash(),
+		user.RoleReader,
+		r.timestamp(),
+		time.Time{},
+		updatedAt,
+	)
+
+	r.users[id] = usr
+	r.userIDsByName[usr.Username().String()] = id
+	r.userIDsByEmail[usr.Email().String()] = id
+
+	return cloneUser(usr), nil
+}
+
+// UpdateUser updates the user record and returns the updated [user.User].
+//
+// Returns [user.NotFoundError] if the user does not exist,
+// [user.ValidationError] if the new username or email is taken, and
+// [user.ConcurrentModificationError] if the request's ETag is stale.
+func (r *records) UpdateUser(
+	_ context.Context,
+	req *user.UpdateRequest,
+) (*user.User, error) {
+	current, ok := r.users[req.UserID()]
+	if !ok {
+		return nil, user.NewNotFoundByIDError(req.UserID())
+	}
+
+	if !current.UpdatedAt().Equal(req.ETag().UpdatedAt()) {
+		return nil, &user.ConcurrentModificationError{
+			ID:   req.UserID(),
+			ETag: req.ETag(),
+		}
+	}
+
+	username := valueOr(req.Username(), current.Username())
+	if id, ok := r.userIDsByName[username.String()]; ok && id != current.ID() {
+		return nil, user.NewDuplicateUsernameError(username)
+	}
+
+	email := valueOr(req.Email(), current.Email())
+	if id, ok := r.userIDsByEmail[email.String()]; ok && id != current.ID() {
+		return nil, user.NewDuplicateEmailError(email)
+	}
+
+	// Updates within the resolution of the clock must still change the ETag.
+	updatedAt := r.timestamp()
+	if !updatedAt.After(current.UpdatedAt()) {
+		updatedAt = current.UpdatedAt().Add(time.Microsecond)
+	}
+
+	passwordChangedAt := current.PasswordChangedAt()
+	if req.PasswordHash().IsSome() {
+		passwordChangedAt = updatedAt
+	}
+
+	updated := user.NewUser(
+		current.ID(),
+		etag.New(current.ID(), updatedAt),
+		username,
+		email,
+		valueOr(req.PasswordHash(), current.PasswordHash()),
+		valueOr(req.Role(), current.Role()),
+		current.CreatedAt(),
+		passwordChangedAt,
+		updatedAt,
+	)
+
+	delete(r.userIDsByName, current.Username().String())
+	delete(r.userIDsByEmail, current.Email().String())
+	r.users[updated.ID()] = updated
+	r.userIDsByName[updated.Username().String()] = updated.ID()
+	r.userIDsByEmail[updated.Email().String()] = updated.ID()
+
+	return cloneUser(updated), nil
+}
+
+// valueOr returns the value of `opt`, or `fallback` if it is empty.
+func valueOr[T any](opt option.Option[T], fallback T) T {
+	if opt.IsSome() {
+		return opt.UnwrapOrZero()
+	}
+	return fallback
+}
+
+// cloneUser returns a copy of `usr`. Users are immutable once built, but
+// copying keeps callers from observing the store's pointers.
+func cloneUser(usr *user.User) *user.User {
+	clone := *usr
+	return &clone
+}
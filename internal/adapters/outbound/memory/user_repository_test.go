@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+func TestClient_CreateUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := New()
+
+	req := user.RandomRegistrationRequest(t)
+	created, err := client.CreateUser(ctx, req)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, uuid.Nil, created.ID())
+	assert.Equal(t, req.Username(), created.Username())
+	assert.Equal(t, req.Email(), created.Email())
+	assert.Equal(t, req.PasswordHash(), created.PasswordHash())
+	assert.Equal(t, user.RoleReader, created.Role())
+	assert.Equal(t, etag.New(created.ID(), created.UpdatedAt()), created.ETag())
+
+	byID, err := client.GetUserByID(ctx, created.ID())
+	require.NoError(t, err)
+	assert.Equal(t, created, byID)
+
+	byEmail, err := client.GetUserByEmail(ctx, req.Email())
+	require.NoError(t, err)
+	assert.Equal(t, created, byEmail)
+
+	testCases := []struct {
+		name    string
+		req     *user.RegistrationRequest
+		wantErr error
+	}{
+		{
+			name:    "Duplicate username",
+			req:     user.NewRegistrationRequest(req.Username(), user.RandomEmailAddress(t), user.RandomPasswordHash(t)),
+			wantErr: user.NewDuplicateUsernameError(req.Username()),
+		},
+		{
+			name:    "Duplicate email",
+			req:     user.NewRegistrationRequest(user.RandomUsername(t), req.Email(), user.RandomPasswordHash(t)),
+			wantErr: user.NewDuplicateEmailError(req.Email()),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.CreateUser(ctx, tc.req)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestClient_GetUser_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := New()
+
+	id := uuid.New()
+	_, err := client.GetUserByID(ctx, id)
+	assert.ErrorIs(t, err, user.NewNotFoundByIDError(id))
+
+	email := user.RandomEmailAddress(t)
+	_, err = client.GetUserByEmail(ctx, email)
+	assert.ErrorIs(t, err, user.NewNotFoundByEmailError(email))
+}
+
+func TestClient_UpdateUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newUsername := user.RandomUsername(t)
+	newEmail := user.RandomEmailAddress(t)
+	newPassword := user.RandomPasswordHash(t)
+
+	testCases := []struct {
+		name    string
+		request func(usr, other *user.User) *user.UpdateRequest
+		wantErr func(usr, other *user.User, req *user.UpdateRequest) error
+		check   func(t *testing.T, usr, updated *user.User)
+	}{
+		{
+			name: "Updates set fields",
+			request: func(usr, _ *user.User) *user.UpdateRequest {
+				return user.NewUpdateRequest(
+					usr.ID(),
+					usr.ETag(),
+					option.Some(newEmail),
+					option.Some(newPassword),
+					option.Some(newUsername),
+					option.Some(user.RoleAdmin),
+				)
+			},
+			check: func(t *testing.T, usr, updated *user.User) {
+				assert.Equal(t, newUsername, updated.Username())
+				assert.Equal(t, newEmail, updated.Email())
+				assert.Equal(t, newPassword, updated.PasswordHash())
+				assert.Equal(t, user.RoleAdmin, updated.Role())
+				assert.Equal(t, updated.UpdatedAt(), updated.PasswordChangedAt())
+				assert.True(t, updated.UpdatedAt().After(usr.UpdatedAt()))
+				assert.NotEqual(t, usr.ETag(), updated.ETag())
+			},
+		},
+		{
+			name: "Keeps unset fields",
+			request: func(usr, _ *user.User) *user.UpdateRequest {
+				return user.NewUpdateRequest(
+					usr.ID(),
+					usr.ETag(),
+					option.None[user.EmailAddress](),
+					option.None[user.PasswordHash](),
+					option.None[user.Username](),
+					option.None[user.Role](),
+				)
+			},
+			check: func(t *testing.T, usr, updated *user.User) {
+				assert.Equal(t, usr.Username(), updated.Username())
+				assert.Equal(t, usr.Email(), updated.Email())
+				assert.Equal(t, usr.PasswordHash(), updated.PasswordHash())
+				assert.Equal(t, usr.PasswordChangedAt(), updated.PasswordChangedAt())
+				assert.NotEqual(t, usr.ETag(), updated.ETag())
+			},
+		},
+		{
+			name: "User not found",
+			request: func(usr, _ *user.User) *user.UpdateRequest {
+				return user.NewUpdateRequest(
+					uuid.New(),
+					usr.ETag(),
+					option.None[user.EmailAddress](),
+					option.None[user.PasswordHash](),
+					option.None[user.Username](),
+					option.None[user.Role](),
+				)
+			},
+			wantErr: func(_, _ *user.User, req *user.UpdateRequest) error {
+				return user.NewNotFoundByIDError(req.UserID())
+			},
+		},
+		{
+			name: "Stale ETag",
+			request: func(usr, _ *user.User) *user.UpdateRequest {
+				return user.NewUpdateRequest(
+					usr.ID(),
+					etag.Random(),
+					option.None[user.EmailAddress](),
+					option.None[user.PasswordHash](),
+					option.Some(newUsername),
+					option.None[user.Role](),
+				)
+			},
+			wantErr: func(_, _ *user.User, req *user.UpdateRequest) error {
+				return &user.ConcurrentModificationError{ID: req.UserID(), ETag: req.ETag()}
+			},
+		},
+		{
+			name: "Duplicate username",
+			request: func(usr, other *user.User) *user.UpdateRequest {
+				return user.NewUpdateRequest(
+					usr.ID(),
+					usr.ETag(),
+					option.None[user.EmailAddress](),
+					option.None[user.PasswordHash](),
+					option.Some(other.Username()),
+					option.None[user.Role](),
+				)
+			},
+			wantErr: func(_, other *user.User, _ *user.UpdateRequest) error {
+				return user.NewDuplicateUsernameError(other.Username())
+			},
+		},
+		{
+			name: "Duplicate email",
+			request: func(usr, other *user.User) *user.UpdateRequest {
+				return user.NewUpdateRequest(
+					usr.ID(),
+					usr.ETag(),
+					option.Some(other.Email()),
+					option.None[user.PasswordHash](),
+					option.None[user.Username](),
+					option.None[user.Role](),
+				)
+			},
+			wantErr: func(_, other *user.User, _ *user.UpdateRequest) error {
+				return user.NewDuplicateEmailError(other.Email())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := New()
+			usr, err := client.CreateUser(ctx, user.RandomRegistrationRequest(t))
+			require.NoError(t, err)
+			other, err := client.CreateUser(ctx, user.RandomRegistrationRequest(t))
+			require.NoError(t, err)
+
+			req := tc.request(usr, other)
+			updated, err := client.UpdateUser(ctx, req)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr(usr, other, req), err)
+
+				unchanged, getErr := client.GetUserByID(ctx, usr.ID())
+				require.NoError(t, getErr)
+				assert.Equal(t, usr, unchanged)
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, usr, updated)
+
+			stored, err := client.GetUserByID(ctx, usr.ID())
+			require.NoError(t, err)
+			assert.Equal(t, updated, stored)
+
+			_, err = client.GetUserByEmail(ctx, updated.Email())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_UpdateUser_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := New()
+
+	usr, err := client.CreateUser(ctx, user.RandomRegistrationRequest(t))
+	require.NoError(t, err)
+
+	const writers = 10
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := user.NewUpdateRequest(
+				usr.ID(),
+				usr.ETag(),
+				option.None[user.EmailAddress](),
+				option.None[user.PasswordHash](),
+				option.None[user.Username](),
+				option.Some(user.RoleAdmin),
+			)
+			if _, err := client.UpdateUser(ctx, req); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, succeeded, "only one update with the same ETag may succeed")
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// Fully qualified names of the background job series.
+const (
+	// JobRunsTotal counts background job runs, labelled by `job` and `result`
+	// ("success", "failure" or "skipped").
+	JobRunsTotal = Namespace + "_job_runs_total"
+
+	// JobDurationSeconds observes the duration of background job runs,
+	// labelled by `job`.
+	JobDurationSeconds = Namespace + "_job_duration_seconds"
+
+	// JobItemsProcessedTotal counts the items processed by background jobs,
+	// labelled by `job`.
+	JobItemsProcessedTotal = Namespace + "_job_items_processed_total"
+)
+
+// JobMetrics is a Prometheus-backed [outbound.JobMetrics].
+type JobMetrics struct {
+	runs      *prometheus.CounterVec
+	durations *prometheus.HistogramVec
+	processed *prometheus.CounterVec
+}
+
+var _ outbound.JobMetrics = (*JobMetrics)(nil)
+
+// NewJobMetrics creates the background job collectors and registers them
+// with `reg`. It panics if any of them is already registered.
+func NewJobMetrics(reg prometheus.Registerer) *JobMetrics {
+	m := &JobMetrics{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: JobRunsTotal,
+			Help: "Total number of background job runs by job and result.",
+		}, []string{"job", "result"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    JobDurationSeconds,
+			Help:    "Duration of background job runs in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"job"}),
+		processed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: JobItemsProcessedTotal,
+			Help: "Total number of items processed by background jobs.",
+		}, []string{"job"}),
+	}
+
+	reg.MustRegister(m.runs, m.durations, m.processed)
+
+	return m
+}
+
+func (m *JobMetrics) JobRun(job string, result outbound.JobResult, duration time.Duration, processed int64) {
+	m.runs.WithLabelValues(job, string(result)).Inc()
+	m.durations.WithLabelValues(job).Observe(duration.Seconds())
+	if processed > 0 {
+		m.processed.WithLabelValues(job).Add(float64(processed))
+	}
+}
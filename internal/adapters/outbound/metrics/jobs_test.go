@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+func TestJobMetrics(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	m := NewJobMetrics(reg)
+
+	m.JobRun("cleanup", outbound.JobSucceeded, time.Second, 3)
+	m.JobRun("cleanup", outbound.JobSucceeded, time.Second, 2)
+	m.JobRun("cleanup", outbound.JobSkipped, time.Millisecond, 0)
+	m.JobRun("cleanup", outbound.JobFailed, time.Second, 0)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.runs.WithLabelValues("cleanup", "success")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.runs.WithLabelValues("cleanup", "skipped")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.runs.WithLabelValues("cleanup", "failure")), 0)
+	assert.InDelta(t, 5, testutil.ToFloat64(m.processed.WithLabelValues("cleanup")), 0)
+
+	count, err := testutil.GatherAndCount(reg, JobRunsTotal, JobDurationSeconds, JobItemsProcessedTotal)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	lint, err := testutil.GatherAndLint(reg)
+	require.NoError(t, err)
+	assert.Empty(t, lint)
+}
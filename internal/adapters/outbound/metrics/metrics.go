@@ -0,0 +1,120 @@
+// Package metrics provides Prometheus implementations of the domain metrics
+// port and collectors for infrastructure owned by other adapters.
+//
+// All series are prefixed with [Namespace]. Names and labels form part of the
+// service's operational contract and must not be changed without updating the
+// dashboards and alerts that depend on them.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// Namespace prefixes every metric exported by the service.
+const Namespace = "codeodessey"
+
+// Label values for the `result` label of [LoginsTotal].
+const (
+	LoginResultSuccess = "success"
+	LoginResultFailure = "failure"
+)
+
+// Fully qualified names of the domain series.
+const (
+	// UserRegistrationsTotal counts successful user registrations.
+	UserRegistrationsTotal = Namespace + "_user_registrations_total"
+
+	// LoginsTotal counts authentication attempts, labelled by `result`
+	// ("success" or "failure").
+	LoginsTotal = Namespace + "_user_logins_total"
+
+	// SessionsCreatedTotal counts sessions persisted after a successful login.
+	SessionsCreatedTotal = Namespace + "_sessions_created_total"
+
+	// ConcurrentModificationsTotal counts updates rejected because the
+	// resource's ETag was stale.
+	ConcurrentModificationsTotal = Namespace + "_concurrent_modifications_total"
+
+	// AuditRecordFailuresTotal counts actions that happened but could not be
+	// recorded in the audit log.
+	AuditRecordFailuresTotal = Namespace + "_audit_record_failures_total"
+)
+
+// DomainMetrics is a Prometheus-backed [outbound.Metrics].
+type DomainMetrics struct {
+	registrations           prometheus.Counter
+	logins                  *prometheus.CounterVec
+	sessionsCreated         prometheus.Counter
+	concurrentModifications prometheus.Counter
+	auditRecordFailures     prometheus.Counter
+}
+
+var _ outbound.Metrics = (*DomainMetrics)(nil)
+
+// NewDomainMetrics creates the domain counters and registers them with `reg`.
+// It panics if any of the counters is already registered.
+func NewDomainMetrics(reg prometheus.Registerer) *DomainMetrics {
+	m := &DomainMetrics{
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: UserRegistrationsTotal,
+			Help: "Total number of successful user registrations.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: LoginsTotal,
+			Help: "Total number of authentication attempts by result.",
+		}, []string{"result"}),
+		sessionsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: SessionsCreatedTotal,
+			Help: "Total number of sessions created.",
+		}),
+		concurrentModifications: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: ConcurrentModificationsTotal,
+			Help: "Total number of updates rejected due to a stale ETag.",
+		}),
+		auditRecordFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: AuditRecordFailuresTotal,
+			Help: "Total number of actions that could not be recorded in the audit log.",
+		}),
+	}
+
+	// Initialise both label values so that the series are exported before the
+	// first login attempt.
+	m.logins.WithLabelValues(LoginResultSuccess)
+	m.logins.WithLabelValues(LoginResultFailure)
+
+	reg.MustRegister(
+		m.registrations,
+		m.logins,
+		m.sessionsCreated,
+		m.concurrentModifications,
+		m.auditRecordFailures,
+	)
+
+	return m
+}
+
+func (m *DomainMetrics) UserRegistered() {
+	m.registrations.Inc()
+}
+
+func (m *DomainMetrics) LoginSucceeded() {
+	m.logins.WithLabelValues(LoginResultSuccess).Inc()
+}
+
+func (m *DomainMetrics) LoginFailed() {
+	m.logins.WithLabelValues(LoginResultFailure).Inc()
+}
+
+func (m *DomainMetrics) SessionCreated() {
+	m.sessionsCreated.Inc()
+}
+
+func (m *DomainMetrics) ConcurrentModification() {
+	m.concurrentModifications.Inc()
+}
+
+func (m *DomainMetrics) AuditRecordFailed() {
+	m.auditRecordFailures.Inc()
+}
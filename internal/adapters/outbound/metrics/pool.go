@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Fully qualified names of the connection pool series. All of them are read
+// from [pgxpool.Stat] at scrape time.
+const (
+	// DBPoolAcquiredConnections is the number of connections currently in use.
+	DBPoolAcquiredConnections = Namespace + "_db_pool_acquired_connections"
+
+	// DBPoolIdleConnections is the number of idle connections in the pool.
+	DBPoolIdleConnections = Namespace + "_db_pool_idle_connections"
+
+	// DBPoolConstructingConnections is the number of connections being
+	// established.
+	DBPoolConstructingConnections = Namespace + "_db_pool_constructing_connections"
+
+	// DBPoolTotalConnections is the total number of connections in the pool.
+	DBPoolTotalConnections = Namespace + "_db_pool_total_connections"
+
+	// DBPoolMaxConnections is the configured maximum pool size.
+	DBPoolMaxConnections = Namespace + "_db_pool_max_connections"
+
+	// DBPoolAcquiresTotal counts successful connection acquisitions.
+	DBPoolAcquiresTotal = Namespace + "_db_pool_acquires_total"
+
+	// DBPoolAcquireDurationSecondsTotal is the cumulative time spent acquiring
+	// connections.
+	DBPoolAcquireDurationSecondsTotal = Namespace + "_db_pool_acquire_duration_seconds_total"
+
+	// DBPoolEmptyAcquiresTotal counts acquisitions that had to wait for a
+	// connection because the pool was empty.
+	DBPoolEmptyAcquiresTotal = Namespace + "_db_pool_empty_acquires_total"
+
+	// DBPoolCanceledAcquiresTotal counts acquisitions cancelled by their
+	// context.
+	DBPoolCanceledAcquiresTotal = Namespace + "_db_pool_canceled_acquires_total"
+
+	// DBPoolNewConnectionsTotal counts connections opened by the pool.
+	DBPoolNewConnectionsTotal = Namespace + "_db_pool_new_connections_total"
+
+	// DBPoolMaxLifetimeDestroysTotal counts connections closed for exceeding
+	// their maximum lifetime.
+	DBPoolMaxLifetimeDestroysTotal = Namespace + "_db_pool_max_lifetime_destroys_total"
+
+	// DBPoolMaxIdleDestroysTotal counts connections closed for exceeding their
+	// maximum idle time.
+	DBPoolMaxIdleDestroysTotal = Namespace + "_db_pool_max_idle_destroys_total"
+)
+
+// PoolStatter is implemented by adapters that own a pgx connection pool.
+type PoolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolCollector is a [prometheus.Collector] exporting the statistics of a
+// pgx connection pool.
+type PoolCollector struct {
+	statter PoolStatter
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+	lifetimeDestroyCount *prometheus.Desc
+	idleDestroyCount     *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*PoolCollector)(nil)
+
+// NewPoolCollector returns a collector that reads pool statistics from
+// `statter` on every scrape.
+func NewPoolCollector(statter PoolStatter) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, nil, nil)
+	}
+
+	return &PoolCollector{
+		statter: statter,
+		acquiredConns: desc(
+			DBPoolAcquiredConnections,
+			"Number of connections currently acquired from the pool.",
+		),
+		idleConns: desc(
+			DBPoolIdleConnections,
+			"Number of idle connections in the pool.",
+		),
+		constructingConns: desc(
+			DBPoolConstructingConnections,
+			"Number of connections currently being established.",
+		),
+		totalConns: desc(
+			DBPoolTotalConnections,
+			"Total number of connections in the pool.",
+		),
+		maxConns: desc(
+			DBPoolMaxConnections,
+			"Maximum size of the pool.",
+		),
+		acquireCount: desc(
+			DBPoolAcquiresTotal,
+			"Total number of successful connection acquisitions.",
+		),
+		acquireDuration: desc(
+			DBPoolAcquireDurationSecondsTotal,
+			"Cumulative time spent acquiring connections in seconds.",
+		),
+		emptyAcquireCount: desc(
+			DBPoolEmptyAcquiresTotal,
+			"Total number of acquisitions that waited on an empty pool.",
+		),
+		canceledAcquireCount: desc(
+			DBPoolCanceledAcquiresTotal,
+			"Total number of acquisitions cancelled by their context.",
+		),
+		newConnsCount: desc(
+			DBPoolNewConnectionsTotal,
+			"Total number of connections opened by the pool.",
+		),
+		lifetimeDestroyCount: desc(
+			DBPoolMaxLifetimeDestroysTotal,
+			"Total number of connections closed for exceeding their max lifetime.",
+		),
+		idleDestroyCount: desc(
+			DBPoolMaxIdleDestroysTotal,
+			"Total number of connections closed for exceeding their max idle time.",
+		),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.statter.Stat()
+
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(c.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(c.idleConns, float64(stat.IdleConns()))
+	gauge(c.constructingConns, float64(stat.ConstructingConns()))
+	gauge(c.totalConns, float64(stat.TotalConns()))
+	gauge(c.maxConns, float64(stat.MaxConns()))
+	counter(c.acquireCount, float64(stat.AcquireCount()))
+	counter(c.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(c.emptyAcquireCount, float64(stat.EmptyAcquireCount()))
+	counter(c.canceledAcquireCount, float64(stat.CanceledAcquireCount()))
+	counter(c.newConnsCount, float64(stat.NewConnsCount()))
+	counter(c.lifetimeDestroyCount, float64(stat.MaxLifetimeDestroyCount()))
+	counter(c.idleDestroyCount, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// auditLockKey is the advisory lock that serializes appends to the audit log
+// across replicas. Its value spells "audit" in ASCII.
+const auditLockKey int64 = 0x6175646974
+
+var _ outbound.AuditLogger = (*Client)(nil)
+
+func (c *Client) Record(ctx context.Context, entry *audit.Entry) error {
+	return c.InTx(ctx, pgx.ReadCommitted, func(q sqlc.Querier) error {
+		return recordAuditEvent(ctx, q, entry)
+	})
+}
+
+// recordAuditEvent appends `entry` while holding [auditLockKey] until the end
+// of the transaction, so that the last entry cannot change between reading
+// its hash and inserting the new entry.
+func recordAuditEvent(ctx context.Context, q queries, entry *audit.Entry) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event details: %w", err)
+	}
+
+	if err := q.LockAuditLog(ctx, auditLockKey); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+
+	last, err := q.GetLastAuditEvent(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get last audit event: %w", err)
+	}
+
+	entry.Seal(last.Sequence, last.Hash)
+	err = q.AppendAuditEvent(ctx, sqlc.AppendAuditEventParams{
+		Sequence:   entry.Sequence,
+		ID:         entry.ID,
+		Action:     string(entry.Action),
+		ActorID:    entry.ActorID,
+		Target:     entry.Target,
+		ClientIp:   entry.ClientIP,
+		UserAgent:  entry.UserAgent,
+		RequestID:  entry.RequestID,
+		Details:    details,
+		OccurredAt: entry.OccurredAt,
+		PrevHash:   entry.PrevHash,
+		Hash:       entry.Hash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append audit event %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+func (c *Client) Query(ctx context.Context, filter audit.Filter) ([]*audit.Entry, error) {
+	params := sqlc.QueryAuditEventsParams{
+		ActorID: pgtype.UUID{
+			Bytes: filter.ActorID.UnwrapOrZero(),
+			Valid: filter.ActorID.IsSome(),
+		},
+		Target: pgtype.Text{
+			String: filter.Target.UnwrapOrZero(),
+			Valid:  filter.Target.IsSome(),
+		},
+		FromTime: pgtype.Timestamptz{
+			Time:  filter.From,
+			Valid: !filter.From.IsZero(),
+		},
+		ToTime: pgtype.Timestamptz{
+			Time:  filter.To,
+			Valid: !filter.To.IsZero(),
+		},
+		AfterSequence: pgtype.Int8{
+			Int64: filter.AfterSequence,
+			Valid: filter.AfterSequence != 0,
+		},
+		BeforeSequence: pgtype.Int8{
+			Int64: filter.BeforeSequence,
+			Valid: filter.BeforeSequence != 0,
+		},
+		MaxEvents: int32(filter.Limit),
+	}
+
+	var rows []sqlc.AuditEvent
+	var err error
+	if filter.NewestFirst {
+		rows, err = c.reader(ctx).QueryAuditEventsNewestFirst(ctx, sqlc.QueryAuditEventsNewestFirstParams(params))
+	} else {
+		rows, err = c.reader(ctx).QueryAuditEvents(ctx, params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+
+	entries := make([]*audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := parseAuditEvent(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseAuditEvent(row sqlc.AuditEvent) (*audit.Entry, error) {
+	var details map[string]string
+	if err := json.Unmarshal(row.Details, &details); err != nil {
+		return nil, fmt.Errorf("failed to decode details of audit event %s: %w", row.ID, err)
+	}
+
+	return &audit.Entry{
+		Sequence:   row.Sequence,
+		ID:         row.ID,
+		Action:     audit.Action(row.Action),
+		ActorID:    row.ActorID,
+		Target:     row.Target,
+		ClientIP:   row.ClientIp,
+		UserAgent:  row.UserAgent,
+		RequestID:  row.RequestID,
+		Details:    details,
+		OccurredAt: row.OccurredAt.UTC(),
+		PrevHash:   row.PrevHash,
+		Hash:       row.Hash,
+	}, nil
+}
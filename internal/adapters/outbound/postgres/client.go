@@ -2,18 +2,19 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"embed"
 	"fmt"
+	"strconv"
+	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/teamkweku/code-odessey-hex-arch/config"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
 )
 
 const migrationsPath = "migrations"
@@ -25,103 +26,164 @@ type queries interface {
 	sqlc.Querier
 }
 
-// client is a Posgres client
+// client is a Posgres client. Writes and transactions go to the primary
+// database, and reads outside transactions go to the replica if there is one.
 type Client struct {
-	db      *pgxpool.Pool
-	queries queries
+	db          *pgxpool.Pool
+	queries     queries
+	retryPolicy retryPolicy
+
+	// replica and replicaQueries are nil if reads are served by the primary.
+	replica        *pgxpool.Pool
+	replicaQueries queries
+}
+
+// Options configures a [Client]. Zero values select the pgx defaults.
+type Options struct {
+	// SkipMigrations disables applying pending migrations on startup. [New]
+	// instead fails if the database schema is behind the migrations embedded
+	// in the binary, which must then be applied with cmd/migrate.
+	SkipMigrations bool
+
+	// MaxConns and MinConns bound the size of the connection pool.
+	MaxConns int32
+	MinConns int32
+	// MaxConnLifetime is the age after which a connection is closed, and
+	// MaxConnIdleTime the idle time after which it is closed.
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+	// HealthCheckPeriod is the interval between checks of idle connections.
+	HealthCheckPeriod time.Duration
+
+	// ApplicationName identifies the client's sessions in pg_stat_activity.
+	ApplicationName string
+	// StatementTimeout aborts statements that run longer. Zero leaves the
+	// server's setting in place.
+	StatementTimeout time.Duration
+
+	// MaxTxAttempts is the number of times a transaction aborted by a
+	// serialization failure or deadlock is attempted, including the first.
+	// Zero means 5.
+	MaxTxAttempts int
+
+	// QueryLogger, if set, logs every query at debug level.
+	QueryLogger logger.Logger
+
+	// ReplicaURL, if set, is a read replica of the primary database. Reads
+	// outside transactions are sent to it unless the context requires
+	// [outbound.ReadYourWrites]. It shares the primary's pool options.
+	ReplicaURL option.Option[URL]
 }
 
-// create a new PostgresClient
-func New(ctx context.Context, url URL) (*Client, error) {
+// create a new PostgresClient. The first connection is retried with backoff
+// until it succeeds or `ctx` is done, since the database may start after the
+// application.
+func New(ctx context.Context, url URL, opts Options) (*Client, error) {
+	db, err := openPool(ctx, url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		db:          db,
+		queries:     sqlc.New(db),
+		retryPolicy: txRetryPolicy(opts),
+	}
+
+	if opts.ReplicaURL.IsSome() {
+		replica, err := openPool(ctx, opts.ReplicaURL.UnwrapOrZero(), opts)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("open replica: %w", err)
+		}
+		client.replica = replica
+		client.replicaQueries = sqlc.New(replica)
+	}
+
+	if err := prepareSchema(url, opts.SkipMigrations); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// openPool connects to the database at `url`.
+func openPool(ctx context.Context, url URL, opts Options) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(url.Expose())
 	if err != nil {
 		return nil, fmt.Errorf("parse postgres config: %w", err)
 	}
+	configurePool(config, opts)
 
-	db, err := pgxpool.NewWithConfig(ctx, config)
+	// The pool keeps using its context to open MinConns connections in the
+	// background, so it must outlive any deadline meant for startup.
+	db, err := pgxpool.NewWithConfig(context.WithoutCancel(ctx), config)
 	if err != nil {
 		return nil, fmt.Errorf("connect to postgres at %q: %w", url, err)
 	}
 
-	if err := db.Ping(ctx); err != nil {
+	if err := pingWithRetry(ctx, db, defaultConnectPolicy); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("ping db at %q: %w", url, err)
 	}
 
-	client := &Client{
-		db:      db,
-		queries: sqlc.New(db),
-	}
+	return db, nil
+}
 
-	if err := client.migrate(); err != nil {
-		db.Close()
-		return nil, err
+// reader returns the queries for reads outside transactions: the replica's,
+// unless there is no replica or `ctx` requires read-your-writes consistency.
+func (c *Client) reader(ctx context.Context) queries {
+	if c.replicaQueries == nil || outbound.ReadYourWrites(ctx) {
+		return c.queries
 	}
-
-	return client, nil
+	return c.replicaQueries
 }
 
-// close the database connection pool
+// close the database connection pools
 func (c *Client) Close() error {
 	c.db.Close()
+	if c.replica != nil {
+		c.replica.Close()
+	}
 	return nil
 }
 
-// migrate run all migrations
-func (c *Client) migrate() error {
-	migrator, err := newMigrator(c.db)
+// Stat returns a snapshot of the primary connection pool statistics.
+func (c *Client) Stat() *pgxpool.Stat {
+	return c.db.Stat()
+}
+
+// prepareSchema applies all pending migrations, or only checks that none are
+// pending if `checkOnly` is set.
+func prepareSchema(url URL, checkOnly bool) error {
+	migrator, err := NewMigrator(url)
 	if err != nil {
 		return fmt.Errorf("create migrator: %w", err)
 	}
 
 	defer func() {
-		if _, closeErr := migrator.Close(); err != nil {
+		if closeErr := migrator.Close(); closeErr != nil {
 			fmt.Printf("error closing migrator: %v\n", closeErr)
 		}
 	}()
 
-	if err := migrator.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("migrate db: %w", err)
-	}
-
-	return nil
-}
-
-func newMigrator(
-	pool *pgxpool.Pool,
-) (*migrate.Migrate, error) {
-	src, err := iofs.New(migrations, migrationsPath)
-	if err != nil {
-		return nil, fmt.Errorf("create io.FS migration source: %w", err)
-	}
-
-	// Create a *sql.DB instance from the connection string
-	connConfig, err := pgxpool.ParseConfig(pool.Config().ConnString())
-	if err != nil {
-		return nil, fmt.Errorf("parse connection string: %w", err)
-	}
-
-	db, err := sql.Open("pgx", connConfig.ConnString())
-	if err != nil {
-		return nil, fmt.Errorf("open sql.DB connection: %w", err)
+	if !checkOnly {
+		if err := migrator.Up(); err != nil {
+			return fmt.Errorf("migrate db: %w", err)
+		}
+		return nil
 	}
 
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	status, err := migrator.Status()
 	if err != nil {
-		return nil, fmt.Errorf(
-			"create postgres migration driver from db: %w",
-			err,
-		)
+		return fmt.Errorf("check schema version: %w", err)
 	}
-	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"create migrator from io.FS source and postgres driver: %w",
-			err,
-		)
+	if err := status.check(); err != nil {
+		return fmt.Errorf("automatic migrations are disabled: %w", err)
 	}
 
-	return m, nil
+	return nil
 }
 
 // URL is a Postgres connection URL.
@@ -137,7 +199,7 @@ type URL struct {
 func NewURL(cfg config.Config) URL {
 	return URL{
 		host:     cfg.DBHost,
-		port:     cfg.DBPort,
+		port:     strconv.Itoa(cfg.DBPort),
 		dbName:   cfg.DBName,
 		user:     cfg.DBUser,
 		password: cfg.DBPassword,
@@ -145,6 +207,22 @@ func NewURL(cfg config.Config) URL {
 	}
 }
 
+// NewReplicaURL returns the URL of the read replica configured in `cfg`, or
+// None if there is none. The replica shares the primary's database name,
+// credentials and SSL mode, and its port unless one is set.
+func NewReplicaURL(cfg config.Config) option.Option[URL] {
+	if cfg.DBReplicaHost == "" {
+		return option.None[URL]()
+	}
+
+	url := NewURL(cfg)
+	url.host = cfg.DBReplicaHost
+	if cfg.DBReplicaPort != 0 {
+		url.port = strconv.Itoa(cfg.DBReplicaPort)
+	}
+	return option.Some(url)
+}
+
 // GoString returns a Go-syntax representation of the URL, with the password
 // redacted.
 func (u URL) GoString() string {
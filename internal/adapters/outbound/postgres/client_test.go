@@ -6,11 +6,24 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"github.com/teamkweku/code-odessey-hex-arch/config"
+	mock_sqlc "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	userService "github.com/teamkweku/code-odessey-hex-arch/internal/core/application/user"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
 )
 
 func TestNew(t *testing.T) {
@@ -22,7 +35,7 @@ func TestNew(t *testing.T) {
 		cfg, err := config.LoadConfig("../../../..")
 		require.NoError(t, err)
 
-		client, err := New(context.Background(), NewURL(cfg))
+		client, err := New(context.Background(), NewURL(cfg), Options{})
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
 
@@ -33,13 +46,20 @@ func TestNew(t *testing.T) {
 		assert.NoError(t, err)
 
 		expectedVersion := latestMigrationVersion(t)
-		migrator, err := newMigrator(client.db)
+		migrator, err := NewMigrator(NewURL(cfg))
 		require.NoError(t, err)
 
-		gotVersion, dirty, err := migrator.Version()
+		status, err := migrator.Status()
 		require.NoError(t, err)
-		assert.Equal(t, expectedVersion, gotVersion)
-		assert.False(t, dirty, "Latest migration is dirty")
+		assert.Equal(t, expectedVersion, status.Version)
+		assert.False(t, status.Dirty, "Latest migration is dirty")
+		assert.NoError(t, migrator.Close())
+
+		checked, err := New(context.Background(), NewURL(cfg), Options{SkipMigrations: true})
+		assert.NoError(t, err, "schema is up to date")
+		if checked != nil {
+			_ = checked.Close()
+		}
 
 		_ = client.Close()
 	})
@@ -49,14 +69,17 @@ func TestNew(t *testing.T) {
 
 		invalidCfg := config.Config{
 			DBHost:     "invalid-host",
-			DBPort:     "5432",
+			DBPort:     5432,
 			DBName:     "invalid-db",
 			DBUser:     "invliad-user",
 			DBPassword: "invalid-password",
 			DBSslMode:  "disable",
 		}
 
-		client, err := New(context.Background(), NewURL(invalidCfg))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		client, err := New(ctx, NewURL(invalidCfg), Options{})
 		assert.Error(t, err)
 		assert.Nil(t, client)
 	})
@@ -67,7 +90,7 @@ func Test_URL_New(t *testing.T) {
 
 	cfg := config.Config{
 		DBHost:     "localhost",
-		DBPort:     "5432",
+		DBPort:     5432,
 		DBName:     "codeodessey",
 		DBUser:     "user",
 		DBPassword: "password",
@@ -76,7 +99,7 @@ func Test_URL_New(t *testing.T) {
 
 	wantURL := URL{
 		host:     cfg.DBHost,
-		port:     cfg.DBPort,
+		port:     "5432",
 		dbName:   cfg.DBName,
 		user:     cfg.DBUser,
 		password: cfg.DBPassword,
@@ -86,6 +109,108 @@ func Test_URL_New(t *testing.T) {
 	assert.Equal(t, wantURL, NewURL(cfg))
 }
 
+func Test_NewReplicaURL(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Config{
+		DBHost:     "primary",
+		DBPort:     5432,
+		DBName:     "codeodessey",
+		DBUser:     "user",
+		DBPassword: "password",
+		DBSslMode:  "disable",
+	}
+
+	assert.False(t, NewReplicaURL(cfg).IsSome(), "no replica host")
+
+	cfg.DBReplicaHost = "replica"
+	want := NewURL(cfg)
+	want.host = "replica"
+	assert.Equal(t, option.Some(want), NewReplicaURL(cfg), "primary port")
+
+	cfg.DBReplicaPort = 5433
+	want.port = "5433"
+	assert.Equal(t, option.Some(want), NewReplicaURL(cfg), "replica port")
+}
+
+func TestClient_reader(t *testing.T) {
+	t.Parallel()
+
+	primary, replica := sqlc.New(nil), sqlc.New(nil)
+	ctx := context.Background()
+	ryw := outbound.WithReadYourWrites(ctx)
+
+	withoutReplica := &Client{queries: primary}
+	assert.Same(t, primary, withoutReplica.reader(ctx))
+	assert.Same(t, primary, withoutReplica.reader(ryw))
+
+	withReplica := &Client{queries: primary, replicaQueries: replica}
+	assert.Same(t, replica, withReplica.reader(ctx))
+	assert.Same(t, primary, withReplica.reader(ryw), "read-your-writes reads from the primary")
+}
+
+func TestClient_ReadYourWritesWithReplica(t *testing.T) {
+	t.Parallel()
+
+	// newClient returns a client whose primary and replica expect no reads
+	// unless the test sets them up, so that reads from the wrong one fail.
+	newClient := func(t *testing.T) (*Client, *mock_sqlc.Mockqueries, *mock_sqlc.Mockqueries) {
+		ctrl := gomock.NewController(t)
+		primary, replica := mock_sqlc.NewMockqueries(ctrl), mock_sqlc.NewMockqueries(ctrl)
+		return &Client{queries: primary, replicaQueries: replica}, primary, replica
+	}
+
+	t.Run("Authenticate reads from the primary", func(t *testing.T) {
+		t.Parallel()
+
+		client, primary, _ := newClient(t)
+		ctrl := gomock.NewController(t)
+		metrics := mock_outbound.NewMockMetrics(ctrl)
+		metrics.EXPECT().LoginFailed()
+		auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+		auditLog.EXPECT().Record(gomock.Any(), gomock.Any())
+		users := userService.NewUserService(
+			client,
+			client,
+			metrics,
+			audit.NewAuditService(auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false)),
+		)
+
+		req := domainUser.RandomLoginRequest(t)
+		primary.EXPECT().
+			GetUserByEmail(gomock.Any(), req.Email().String()).
+			Return(sqlc.GetUserByEmailRow{}, pgx.ErrNoRows)
+
+		_, err := users.Authenticate(context.Background(), req)
+		var authErr *domainUser.AuthError
+		assert.ErrorAs(t, err, &authErr)
+	})
+
+	t.Run("Reads requiring read-your-writes go to the primary", func(t *testing.T) {
+		t.Parallel()
+
+		client, primary, _ := newClient(t)
+		id := uuid.New()
+		primary.EXPECT().GetUserById(gomock.Any(), id).Return(sqlc.GetUserByIdRow{}, pgx.ErrNoRows)
+
+		_, err := client.GetUserByID(outbound.WithReadYourWrites(context.Background()), id)
+		var notFoundErr *domainUser.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+
+	t.Run("Other reads go to the replica", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, replica := newClient(t)
+		id := uuid.New()
+		replica.EXPECT().GetUserById(gomock.Any(), id).Return(sqlc.GetUserByIdRow{}, pgx.ErrNoRows)
+
+		_, err := client.GetUserByID(context.Background(), id)
+		var notFoundErr *domainUser.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+}
+
 func Test_URL_GoString(t *testing.T) {
 	t.Parallel()
 
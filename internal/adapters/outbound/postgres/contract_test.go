@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/outboundtest"
+)
+
+func TestClient_Contract(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadConfig("../../../..")
+	require.NoError(t, err)
+
+	client, err := New(context.Background(), NewURL(cfg), Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	outboundtest.Run(t, func(*testing.T) outboundtest.Repositories {
+		return client
+	})
+	outboundtest.RunUnitOfWork(t, func(*testing.T) outboundtest.TransactionalRepositories {
+		return client
+	})
+	outboundtest.RunOutbox(t, func(*testing.T) outboundtest.OutboxStore {
+		return client
+	})
+	outboundtest.RunOutboxCleaner(t, func(*testing.T) outboundtest.OutboxCleanupStore {
+		return client
+	})
+	outboundtest.RunAuditLogger(t, func(*testing.T) outboundtest.AuditStore {
+		return client
+	})
+	outboundtest.RunSessionCleaner(t, func(*testing.T) outboundtest.SessionStore {
+		return client
+	})
+	outboundtest.RunJobLocker(t, func(*testing.T) outbound.JobLocker {
+		return client
+	})
+}
+
+// TestClient_Contract_Replica runs the contract with reads routed to a replica
+// pool connected to the same server as the primary.
+func TestClient_Contract_Replica(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadConfig("../../../..")
+	require.NoError(t, err)
+	cfg.DBReplicaHost = cfg.DBHost
+
+	client, err := New(context.Background(), NewURL(cfg), Options{ReplicaURL: NewReplicaURL(cfg)})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	require.NotNil(t, client.replica)
+
+	outboundtest.Run(t, func(*testing.T) outboundtest.Repositories {
+		return client
+	})
+}
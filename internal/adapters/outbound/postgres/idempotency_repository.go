@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// reserveAttempts bounds the retries of Reserve when the conflicting record
+// is released between the insert and the read.
+const reserveAttempts = 3
+
+var _ outbound.IdempotencyStore = (*Client)(nil)
+
+func (c *Client) Reserve(
+	ctx context.Context,
+	record *idempotency.Record,
+) (*idempotency.Record, error) {
+	for attempt := 0; attempt < reserveAttempts; attempt++ {
+		_, err := c.queries.ReserveIdempotencyKey(ctx, sqlc.ReserveIdempotencyKeyParams{
+			Principal:   record.Key.Principal,
+			Method:      record.Key.Method,
+			Key:         record.Key.Value,
+			Fingerprint: record.Fingerprint,
+			LockedUntil: record.LockedUntil,
+			CreatedAt:   record.CreatedAt,
+			ExpiresAt:   record.ExpiresAt,
+		})
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		existing, err := c.queries.GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{
+			Principal: record.Key.Principal,
+			Method:    record.Key.Method,
+			Key:       record.Key.Value,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+
+		return &idempotency.Record{
+			Key:         record.Key,
+			Fingerprint: existing.Fingerprint,
+			Response:    existing.Response,
+			LockedUntil: existing.LockedUntil.UTC(),
+			CreatedAt:   existing.CreatedAt.UTC(),
+			ExpiresAt:   existing.ExpiresAt.UTC(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("failed to reserve idempotency key after %d attempts", reserveAttempts)
+}
+
+func (c *Client) Complete(ctx context.Context, key idempotency.Key, response []byte) error {
+	if response == nil {
+		// NULL marks a reservation whose request is still in progress.
+		response = []byte{}
+	}
+
+	err := c.queries.CompleteIdempotencyKey(ctx, sqlc.CompleteIdempotencyKeyParams{
+		Principal: key.Principal,
+		Method:    key.Method,
+		Key:       key.Value,
+		Response:  response,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) Release(ctx context.Context, key idempotency.Key) error {
+	err := c.queries.DeleteIdempotencyKey(ctx, sqlc.DeleteIdempotencyKeyParams{
+		Principal: key.Principal,
+		Method:    key.Method,
+		Key:       key.Value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := c.queries.DeleteExpiredIdempotencyKeys(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.JobLocker = (*Client)(nil)
+
+// RunExclusive holds a session-level advisory lock on a dedicated connection
+// while `fn` runs, so that it excludes every replica connected to the same
+// database. The lock is released if the connection is lost, in which case
+// another replica may start the job before `fn` returns.
+func (c *Client) RunExclusive(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := c.db.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquire connection for lock %q: %w", name, err)
+	}
+	defer conn.Release()
+
+	q := sqlc.New(conn)
+	locked, err := q.TryAdvisoryLock(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("take lock %q: %w", name, err)
+	}
+	if !locked {
+		return false, nil
+	}
+
+	defer func() {
+		// The lock must be released even if `ctx` has ended, or the pooled
+		// connection would keep holding it.
+		if _, err := q.AdvisoryUnlock(context.WithoutCancel(ctx), name); err != nil {
+			// Closing the connection releases its locks.
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,12 @@
+CREATE TABLE "idempotency_keys" (
+  "principal" varchar NOT NULL,
+  "method" varchar NOT NULL,
+  "key" varchar NOT NULL,
+  "fingerprint" varchar NOT NULL,
+  "response" bytea,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "expires_at" timestamp NOT NULL,
+  PRIMARY KEY ("principal", "method", "key")
+);
+
+CREATE INDEX ON "idempotency_keys" ("expires_at");
@@ -0,0 +1,23 @@
+-- Restore case-sensitive emails
+ALTER TABLE "users" ALTER COLUMN "email" TYPE varchar;
+
+DROP EXTENSION IF EXISTS citext;
+
+-- Restore timestamps without time zone, in UTC
+ALTER TABLE "idempotency_keys"
+  ALTER COLUMN "created_at" TYPE timestamp USING "created_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "expires_at" TYPE timestamp USING "expires_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE "rate_limit_buckets"
+  ALTER COLUMN "updated_at" TYPE timestamp USING "updated_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE "sessions"
+  ALTER COLUMN "expires_at" TYPE timestamp USING "expires_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "created_at" TYPE timestamp USING "created_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE "users"
+  ALTER COLUMN "created_at" TYPE timestamp USING "created_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "password_changed_at" TYPE timestamp USING "password_changed_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "password_changed_at" SET DEFAULT '0001-01-01 00:00:00',
+  ALTER COLUMN "updated_at" TYPE timestamp USING "updated_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "updated_at" SET DEFAULT '0001-01-01 00:00:00';
@@ -0,0 +1,32 @@
+-- Existing timestamps were written in UTC, so they are interpreted as UTC.
+ALTER TABLE "users"
+  ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "password_changed_at" TYPE timestamptz USING "password_changed_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "password_changed_at" SET DEFAULT '0001-01-01 00:00:00Z',
+  ALTER COLUMN "updated_at" TYPE timestamptz USING "updated_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "updated_at" SET DEFAULT '0001-01-01 00:00:00Z';
+
+ALTER TABLE "sessions"
+  ALTER COLUMN "expires_at" TYPE timestamptz USING "expires_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE "rate_limit_buckets"
+  ALTER COLUMN "updated_at" TYPE timestamptz USING "updated_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE "idempotency_keys"
+  ALTER COLUMN "created_at" TYPE timestamptz USING "created_at" AT TIME ZONE 'UTC',
+  ALTER COLUMN "expires_at" TYPE timestamptz USING "expires_at" AT TIME ZONE 'UTC';
+
+-- Emails compare case-insensitively. This fails if two users registered
+-- addresses that differ only in case, which must be merged by hand first.
+CREATE EXTENSION IF NOT EXISTS citext;
+
+ALTER TABLE "users" ALTER COLUMN "email" TYPE citext;
+
+-- Normalizing the stored case is not a change to the user, so the trigger that
+-- bumps updated_at, and with it the user's ETag, is disabled meanwhile.
+ALTER TABLE "users" DISABLE TRIGGER update_users_updated_at;
+
+UPDATE "users" SET "email" = lower("email") WHERE "email"::text <> lower("email");
+
+ALTER TABLE "users" ENABLE TRIGGER update_users_updated_at;
@@ -0,0 +1,17 @@
+CREATE TABLE "outbox" (
+  "id" uuid PRIMARY KEY,
+  "event_type" varchar NOT NULL,
+  "user_id" uuid NOT NULL,
+  "payload" jsonb NOT NULL,
+  "occurred_at" timestamptz NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL,
+  "sent_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- The relay only reads pending messages, which are few compared to the sent
+-- ones kept for inspection.
+CREATE INDEX ON "outbox" ("next_attempt_at", "occurred_at") WHERE "status" = 'pending';
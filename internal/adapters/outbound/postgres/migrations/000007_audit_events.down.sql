@@ -0,0 +1,2 @@
+-- Drop the "audit_events" table
+DROP TABLE IF EXISTS "audit_events";
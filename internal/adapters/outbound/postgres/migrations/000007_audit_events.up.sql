@@ -0,0 +1,23 @@
+-- Sequences are assigned by the application while it holds the audit lock,
+-- so that they have no gaps and match the order of the hash chain. actor_id
+-- is the nil UUID for anonymous actions.
+CREATE TABLE "audit_events" (
+  "sequence" bigint PRIMARY KEY,
+  "id" uuid UNIQUE NOT NULL,
+  "action" varchar NOT NULL,
+  "actor_id" uuid NOT NULL,
+  "target" varchar NOT NULL,
+  "client_ip" varchar NOT NULL DEFAULT '',
+  "user_agent" varchar NOT NULL DEFAULT '',
+  "request_id" varchar NOT NULL DEFAULT '',
+  "details" jsonb NOT NULL DEFAULT '{}',
+  "occurred_at" timestamptz NOT NULL,
+  "prev_hash" bytea NOT NULL,
+  "hash" bytea NOT NULL
+);
+
+CREATE INDEX ON "audit_events" ("actor_id", "occurred_at");
+
+CREATE INDEX ON "audit_events" ("target", "occurred_at");
+
+CREATE INDEX ON "audit_events" ("occurred_at");
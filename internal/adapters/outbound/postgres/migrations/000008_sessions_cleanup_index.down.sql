@@ -0,0 +1,2 @@
+-- Drop the "sessions_expires_at_idx" index
+DROP INDEX IF EXISTS "sessions_expires_at_idx";
@@ -0,0 +1,4 @@
+-- Drop the "full_at" column and its index
+DROP INDEX IF EXISTS "rate_limit_buckets_full_at_idx";
+
+ALTER TABLE "rate_limit_buckets" DROP COLUMN IF EXISTS "full_at";
@@ -0,0 +1,12 @@
+-- Buckets record when they will have refilled completely, after which they
+-- are indistinguishable from new buckets and may be deleted. The limit of
+-- existing buckets is unknown, so they are assumed to refill within a day.
+ALTER TABLE "rate_limit_buckets"
+  ADD COLUMN "full_at" timestamptz;
+
+UPDATE "rate_limit_buckets" SET "full_at" = "updated_at" + interval '1 day';
+
+ALTER TABLE "rate_limit_buckets"
+  ALTER COLUMN "full_at" SET NOT NULL;
+
+CREATE INDEX "rate_limit_buckets_full_at_idx" ON "rate_limit_buckets" ("full_at");
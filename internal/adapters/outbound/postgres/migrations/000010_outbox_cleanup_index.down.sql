@@ -0,0 +1,3 @@
+-- Drop the outbox cleanup indexes
+DROP INDEX IF EXISTS "outbox_dead_occurred_at_idx";
+DROP INDEX IF EXISTS "outbox_sent_at_idx";
@@ -0,0 +1,3 @@
+-- Let the outbox cleanup job find finished messages without a full scan.
+CREATE INDEX "outbox_sent_at_idx" ON "outbox" ("sent_at") WHERE "status" = 'sent';
+CREATE INDEX "outbox_dead_occurred_at_idx" ON "outbox" ("occurred_at") WHERE "status" = 'dead';
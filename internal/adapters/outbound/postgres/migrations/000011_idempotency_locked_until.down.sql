@@ -0,0 +1,2 @@
+-- Drop the "locked_until" column
+ALTER TABLE "idempotency_keys" DROP COLUMN IF EXISTS "locked_until";
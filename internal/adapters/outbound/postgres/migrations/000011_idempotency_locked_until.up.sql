@@ -0,0 +1,10 @@
+-- In-progress reservations hold their key only until "locked_until", after
+-- which the request is presumed to have crashed and the key may be reserved
+-- again. Existing reservations get the default one minute lease.
+ALTER TABLE "idempotency_keys"
+  ADD COLUMN "locked_until" timestamptz;
+
+UPDATE "idempotency_keys" SET "locked_until" = "created_at" + interval '1 minute';
+
+ALTER TABLE "idempotency_keys"
+  ALTER COLUMN "locked_until" SET NOT NULL;
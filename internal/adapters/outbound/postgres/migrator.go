@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Migrator applies the migrations embedded in the binary to a Postgres
+// database.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// MigrationStatus describes the schema version of a database.
+type MigrationStatus struct {
+	// Version is the last applied migration, or 0 if none has been applied.
+	Version uint
+	// Dirty reports whether the last migration failed part way through. A
+	// dirty database must be repaired by hand and marked clean with
+	// [Migrator.Force].
+	Dirty bool
+	// Latest is the newest migration embedded in the binary.
+	Latest uint
+}
+
+// Pending reports whether migrations embedded in the binary have not been
+// applied to the database.
+func (s MigrationStatus) Pending() bool {
+	return s.Version < s.Latest
+}
+
+// check returns an error if the database schema is unfit for this binary:
+// either dirty or older than its latest migration. A newer schema is accepted,
+// since a rolling deployment migrates before all replicas are replaced.
+func (s MigrationStatus) check() error {
+	if s.Dirty {
+		return fmt.Errorf("database schema version %d is dirty", s.Version)
+	}
+	if s.Pending() {
+		return fmt.Errorf(
+			"database schema version %d is behind the latest migration %d",
+			s.Version,
+			s.Latest,
+		)
+	}
+	return nil
+}
+
+// NewMigrator returns a [Migrator] with its own connection to the database at
+// `url`, which is closed by [Migrator.Close].
+func NewMigrator(url URL) (*Migrator, error) {
+	src, err := iofs.New(migrations, migrationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("create io.FS migration source: %w", err)
+	}
+
+	db, err := sql.Open("pgx", url.Expose())
+	if err != nil {
+		return nil, fmt.Errorf("open sql.DB connection: %w", err)
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf(
+			"create postgres migration driver from db: %w",
+			err,
+		)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf(
+			"create migrator from io.FS source and postgres driver: %w",
+			err,
+		)
+	}
+
+	return &Migrator{m: m}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the last `steps` applied migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	if err := m.m.Steps(-steps); err != nil {
+		return fmt.Errorf("roll back %d migrations: %w", steps, err)
+	}
+	return nil
+}
+
+// Goto applies or rolls back migrations until the schema is at `version`.
+func (m *Migrator) Goto(version uint) error {
+	if err := m.m.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate to version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Force records `version` as the current, clean schema version without
+// running any migration. It is used to recover from a failed migration after
+// repairing the schema by hand. A version of -1 records that no migration has
+// been applied.
+func (m *Migrator) Force(version int) error {
+	if err := m.m.Force(version); err != nil {
+		return fmt.Errorf("force version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Status returns the schema version of the database.
+func (m *Migrator) Status() (MigrationStatus, error) {
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return MigrationStatus{Version: version, Dirty: dirty, Latest: latest}, nil
+}
+
+// Close closes the migrator's database connection.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	if err := errors.Join(srcErr, dbErr); err != nil {
+		return fmt.Errorf("close migrator: %w", err)
+	}
+	return nil
+}
+
+// LatestMigrationVersion returns the version of the newest migration embedded
+// in the binary.
+func LatestMigrationVersion() (uint, error) {
+	src, err := fs.Sub(migrations, migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("open embedded migrations: %w", err)
+	}
+
+	return LatestMigrationVersionIn(src)
+}
+
+// LatestMigrationVersionIn returns the highest version among the up migrations
+// at the root of `fsys`, which are named VERSION_NAME.up.sql, or 0 if there
+// are none.
+func LatestMigrationVersionIn(fsys fs.FS) (uint, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+
+		versionStr, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(versionStr, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("parse version of migration %q: %w", name, err)
+		}
+		latest = max(latest, uint(version))
+	}
+
+	return latest, nil
+}
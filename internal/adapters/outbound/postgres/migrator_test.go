@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigrationVersion(t *testing.T) {
+	t.Parallel()
+
+	got, err := LatestMigrationVersion()
+	require.NoError(t, err)
+	assert.Equal(t, latestMigrationVersion(t), got, "embedded migrations match the migrations directory")
+}
+
+func TestLatestMigrationVersionIn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    uint
+		wantErr bool
+	}{
+		{
+			name: "highest up migration",
+			fsys: fstest.MapFS{
+				"000001_users.up.sql":      {},
+				"000001_users.down.sql":    {},
+				"000010_sessions.up.sql":   {},
+				"000010_sessions.down.sql": {},
+				"000002_roles.up.sql":      {},
+				"000011_pending.down.sql":  {},
+				"README.md":                {},
+			},
+			want: 10,
+		},
+		{
+			name: "no migrations",
+			fsys: fstest.MapFS{},
+			want: 0,
+		},
+		{
+			name:    "invalid version",
+			fsys:    fstest.MapFS{"first_users.up.sql": {}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LatestMigrationVersionIn(tt.fsys)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMigrationStatus_check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  MigrationStatus
+		wantErr bool
+	}{
+		{"up to date", MigrationStatus{Version: 4, Latest: 4}, false},
+		{"ahead of binary", MigrationStatus{Version: 5, Latest: 4}, false},
+		{"behind", MigrationStatus{Version: 3, Latest: 4}, true},
+		{"never migrated", MigrationStatus{Latest: 4}, true},
+		{"dirty", MigrationStatus{Version: 4, Dirty: true, Latest: 4}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.status.check()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
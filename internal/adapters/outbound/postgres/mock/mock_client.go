@@ -12,6 +12,7 @@ package mock_sqlc
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	sqlc "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
@@ -41,6 +42,93 @@ func (m *Mockqueries) EXPECT() *MockqueriesMockRecorder {
 	return m.recorder
 }
 
+// AdvisoryUnlock mocks base method.
+func (m *Mockqueries) AdvisoryUnlock(ctx context.Context, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvisoryUnlock", ctx, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvisoryUnlock indicates an expected call of AdvisoryUnlock.
+func (mr *MockqueriesMockRecorder) AdvisoryUnlock(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvisoryUnlock", reflect.TypeOf((*Mockqueries)(nil).AdvisoryUnlock), ctx, name)
+}
+
+// AppendAuditEvent mocks base method.
+func (m *Mockqueries) AppendAuditEvent(ctx context.Context, arg sqlc.AppendAuditEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendAuditEvent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendAuditEvent indicates an expected call of AppendAuditEvent.
+func (mr *MockqueriesMockRecorder) AppendAuditEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuditEvent", reflect.TypeOf((*Mockqueries)(nil).AppendAuditEvent), ctx, arg)
+}
+
+// AppendOutboxMessage mocks base method.
+func (m *Mockqueries) AppendOutboxMessage(ctx context.Context, arg sqlc.AppendOutboxMessageParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendOutboxMessage", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendOutboxMessage indicates an expected call of AppendOutboxMessage.
+func (mr *MockqueriesMockRecorder) AppendOutboxMessage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendOutboxMessage", reflect.TypeOf((*Mockqueries)(nil).AppendOutboxMessage), ctx, arg)
+}
+
+// ClaimOutboxMessages mocks base method.
+func (m *Mockqueries) ClaimOutboxMessages(ctx context.Context, arg sqlc.ClaimOutboxMessagesParams) ([]sqlc.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxMessages", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxMessages indicates an expected call of ClaimOutboxMessages.
+func (mr *MockqueriesMockRecorder) ClaimOutboxMessages(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxMessages", reflect.TypeOf((*Mockqueries)(nil).ClaimOutboxMessages), ctx, arg)
+}
+
+// CompleteIdempotencyKey mocks base method.
+func (m *Mockqueries) CompleteIdempotencyKey(ctx context.Context, arg sqlc.CompleteIdempotencyKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotencyKey indicates an expected call of CompleteIdempotencyKey.
+func (mr *MockqueriesMockRecorder) CompleteIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotencyKey", reflect.TypeOf((*Mockqueries)(nil).CompleteIdempotencyKey), ctx, arg)
+}
+
+// CreateSession mocks base method.
+func (m *Mockqueries) CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockqueriesMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*Mockqueries)(nil).CreateSession), ctx, arg)
+}
+
 // CreateUser mocks base method.
 func (m *Mockqueries) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +144,94 @@ func (mr *MockqueriesMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockqueries)(nil).CreateUser), ctx, arg)
 }
 
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *Mockqueries) DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", ctx, expiresAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockqueriesMockRecorder) DeleteExpiredIdempotencyKeys(ctx, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*Mockqueries)(nil).DeleteExpiredIdempotencyKeys), ctx, expiresAt)
+}
+
+// DeleteFinishedOutboxMessages mocks base method.
+func (m *Mockqueries) DeleteFinishedOutboxMessages(ctx context.Context, arg sqlc.DeleteFinishedOutboxMessagesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedOutboxMessages", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedOutboxMessages indicates an expected call of DeleteFinishedOutboxMessages.
+func (mr *MockqueriesMockRecorder) DeleteFinishedOutboxMessages(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedOutboxMessages", reflect.TypeOf((*Mockqueries)(nil).DeleteFinishedOutboxMessages), ctx, arg)
+}
+
+// DeleteFullRateLimitBuckets mocks base method.
+func (m *Mockqueries) DeleteFullRateLimitBuckets(ctx context.Context, arg sqlc.DeleteFullRateLimitBucketsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFullRateLimitBuckets", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFullRateLimitBuckets indicates an expected call of DeleteFullRateLimitBuckets.
+func (mr *MockqueriesMockRecorder) DeleteFullRateLimitBuckets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFullRateLimitBuckets", reflect.TypeOf((*Mockqueries)(nil).DeleteFullRateLimitBuckets), ctx, arg)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *Mockqueries) DeleteIdempotencyKey(ctx context.Context, arg sqlc.DeleteIdempotencyKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockqueriesMockRecorder) DeleteIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*Mockqueries)(nil).DeleteIdempotencyKey), ctx, arg)
+}
+
+// DeleteSession mocks base method.
+func (m *Mockqueries) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockqueriesMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*Mockqueries)(nil).DeleteSession), ctx, id)
+}
+
+// DeleteStaleSessions mocks base method.
+func (m *Mockqueries) DeleteStaleSessions(ctx context.Context, arg sqlc.DeleteStaleSessionsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleSessions", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleSessions indicates an expected call of DeleteStaleSessions.
+func (mr *MockqueriesMockRecorder) DeleteStaleSessions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleSessions", reflect.TypeOf((*Mockqueries)(nil).DeleteStaleSessions), ctx, arg)
+}
+
 // DeleteUser mocks base method.
 func (m *Mockqueries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -70,6 +246,81 @@ func (mr *MockqueriesMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Mockqueries)(nil).DeleteUser), ctx, id)
 }
 
+// GetIdempotencyKey mocks base method.
+func (m *Mockqueries) GetIdempotencyKey(ctx context.Context, arg sqlc.GetIdempotencyKeyParams) (sqlc.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(sqlc.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockqueriesMockRecorder) GetIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*Mockqueries)(nil).GetIdempotencyKey), ctx, arg)
+}
+
+// GetLastAuditEvent mocks base method.
+func (m *Mockqueries) GetLastAuditEvent(ctx context.Context) (sqlc.GetLastAuditEventRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastAuditEvent", ctx)
+	ret0, _ := ret[0].(sqlc.GetLastAuditEventRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastAuditEvent indicates an expected call of GetLastAuditEvent.
+func (mr *MockqueriesMockRecorder) GetLastAuditEvent(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAuditEvent", reflect.TypeOf((*Mockqueries)(nil).GetLastAuditEvent), ctx)
+}
+
+// GetRateLimitBucketForUpdate mocks base method.
+func (m *Mockqueries) GetRateLimitBucketForUpdate(ctx context.Context, key string) (sqlc.GetRateLimitBucketForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateLimitBucketForUpdate", ctx, key)
+	ret0, _ := ret[0].(sqlc.GetRateLimitBucketForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateLimitBucketForUpdate indicates an expected call of GetRateLimitBucketForUpdate.
+func (mr *MockqueriesMockRecorder) GetRateLimitBucketForUpdate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateLimitBucketForUpdate", reflect.TypeOf((*Mockqueries)(nil).GetRateLimitBucketForUpdate), ctx, key)
+}
+
+// GetSession mocks base method.
+func (m *Mockqueries) GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockqueriesMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*Mockqueries)(nil).GetSession), ctx, id)
+}
+
+// GetSessionByUserID mocks base method.
+func (m *Mockqueries) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByUserID", ctx, userID)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByUserID indicates an expected call of GetSessionByUserID.
+func (mr *MockqueriesMockRecorder) GetSessionByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByUserID", reflect.TypeOf((*Mockqueries)(nil).GetSessionByUserID), ctx, userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *Mockqueries) GetUserByEmail(ctx context.Context, email string) (sqlc.GetUserByEmailRow, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +351,122 @@ func (mr *MockqueriesMockRecorder) GetUserById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*Mockqueries)(nil).GetUserById), ctx, id)
 }
 
+// InsertRateLimitBucket mocks base method.
+func (m *Mockqueries) InsertRateLimitBucket(ctx context.Context, arg sqlc.InsertRateLimitBucketParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRateLimitBucket", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRateLimitBucket indicates an expected call of InsertRateLimitBucket.
+func (mr *MockqueriesMockRecorder) InsertRateLimitBucket(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRateLimitBucket", reflect.TypeOf((*Mockqueries)(nil).InsertRateLimitBucket), ctx, arg)
+}
+
+// LockAuditLog mocks base method.
+func (m *Mockqueries) LockAuditLog(ctx context.Context, lockKey int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAuditLog", ctx, lockKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAuditLog indicates an expected call of LockAuditLog.
+func (mr *MockqueriesMockRecorder) LockAuditLog(ctx, lockKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAuditLog", reflect.TypeOf((*Mockqueries)(nil).LockAuditLog), ctx, lockKey)
+}
+
+// QueryAuditEvents mocks base method.
+func (m *Mockqueries) QueryAuditEvents(ctx context.Context, arg sqlc.QueryAuditEventsParams) ([]sqlc.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAuditEvents", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAuditEvents indicates an expected call of QueryAuditEvents.
+func (mr *MockqueriesMockRecorder) QueryAuditEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAuditEvents", reflect.TypeOf((*Mockqueries)(nil).QueryAuditEvents), ctx, arg)
+}
+
+// QueryAuditEventsNewestFirst mocks base method.
+func (m *Mockqueries) QueryAuditEventsNewestFirst(ctx context.Context, arg sqlc.QueryAuditEventsNewestFirstParams) ([]sqlc.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAuditEventsNewestFirst", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAuditEventsNewestFirst indicates an expected call of QueryAuditEventsNewestFirst.
+func (mr *MockqueriesMockRecorder) QueryAuditEventsNewestFirst(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAuditEventsNewestFirst", reflect.TypeOf((*Mockqueries)(nil).QueryAuditEventsNewestFirst), ctx, arg)
+}
+
+// ReserveIdempotencyKey mocks base method.
+func (m *Mockqueries) ReserveIdempotencyKey(ctx context.Context, arg sqlc.ReserveIdempotencyKeyParams) (sqlc.ReserveIdempotencyKeyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ReserveIdempotencyKeyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveIdempotencyKey indicates an expected call of ReserveIdempotencyKey.
+func (mr *MockqueriesMockRecorder) ReserveIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveIdempotencyKey", reflect.TypeOf((*Mockqueries)(nil).ReserveIdempotencyKey), ctx, arg)
+}
+
+// TryAdvisoryLock mocks base method.
+func (m *Mockqueries) TryAdvisoryLock(ctx context.Context, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAdvisoryLock", ctx, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAdvisoryLock indicates an expected call of TryAdvisoryLock.
+func (mr *MockqueriesMockRecorder) TryAdvisoryLock(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAdvisoryLock", reflect.TypeOf((*Mockqueries)(nil).TryAdvisoryLock), ctx, name)
+}
+
+// UpdateOutboxMessage mocks base method.
+func (m *Mockqueries) UpdateOutboxMessage(ctx context.Context, arg sqlc.UpdateOutboxMessageParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOutboxMessage", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOutboxMessage indicates an expected call of UpdateOutboxMessage.
+func (mr *MockqueriesMockRecorder) UpdateOutboxMessage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOutboxMessage", reflect.TypeOf((*Mockqueries)(nil).UpdateOutboxMessage), ctx, arg)
+}
+
+// UpdateRateLimitBucket mocks base method.
+func (m *Mockqueries) UpdateRateLimitBucket(ctx context.Context, arg sqlc.UpdateRateLimitBucketParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRateLimitBucket", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRateLimitBucket indicates an expected call of UpdateRateLimitBucket.
+func (mr *MockqueriesMockRecorder) UpdateRateLimitBucket(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRateLimitBucket", reflect.TypeOf((*Mockqueries)(nil).UpdateRateLimitBucket), ctx, arg)
+}
+
 // UpdateUser mocks base method.
 func (m *Mockqueries) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error) {
 	m.ctrl.T.Helper()
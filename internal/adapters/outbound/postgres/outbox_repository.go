@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.OutboxRelayStore = (*Client)(nil)
+
+type txOutboxRepository struct {
+	q queries
+}
+
+func (r txOutboxRepository) Append(ctx context.Context, messages ...*outbox.Message) error {
+	return appendOutbox(ctx, r.q, messages)
+}
+
+func appendOutbox(ctx context.Context, q queries, messages []*outbox.Message) error {
+	for _, msg := range messages {
+		err := q.AppendOutboxMessage(ctx, sqlc.AppendOutboxMessageParams{
+			ID:            msg.Event.ID,
+			EventType:     string(msg.Event.Type),
+			UserID:        msg.Event.UserID,
+			Payload:       msg.Event.Payload,
+			OccurredAt:    msg.Event.OccurredAt,
+			Status:        string(msg.Status),
+			Attempts:      int32(msg.Attempts),
+			LastError:     msg.LastError,
+			NextAttemptAt: msg.NextAttemptAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to append outbox message %s: %w", msg.Event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ProcessOutbox locks the claimed rows with FOR UPDATE SKIP LOCKED for the
+// duration of `process`, so that concurrent relays claim other rows. If the
+// transaction is retried, `process` is called again with the same messages.
+func (c *Client) ProcessOutbox(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	process func(ctx context.Context, messages []*outbox.Message) error,
+) (int, error) {
+	var claimed int
+	err := c.InTx(ctx, pgx.ReadCommitted, func(q sqlc.Querier) error {
+		rows, err := q.ClaimOutboxMessages(ctx, sqlc.ClaimOutboxMessagesParams{
+			Now:         now,
+			MaxMessages: int32(limit),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox messages: %w", err)
+		}
+
+		claimed = len(rows)
+		if claimed == 0 {
+			return nil
+		}
+
+		messages := make([]*outbox.Message, 0, len(rows))
+		for _, row := range rows {
+			messages = append(messages, parseOutboxMessage(row))
+		}
+
+		if err := process(ctx, messages); err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			if err := q.UpdateOutboxMessage(ctx, newUpdateOutboxMessageParams(msg)); err != nil {
+				return fmt.Errorf("failed to update outbox message %s: %w", msg.Event.ID, err)
+			}
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+var _ outbound.OutboxCleaner = (*Client)(nil)
+
+func (c *Client) DeleteFinishedOutboxMessages(
+	ctx context.Context,
+	sentBefore, deadBefore time.Time,
+	limit int,
+) (int64, error) {
+	deleted, err := c.queries.DeleteFinishedOutboxMessages(ctx, sqlc.DeleteFinishedOutboxMessagesParams{
+		SentBefore:  sentBefore,
+		DeadBefore:  deadBefore,
+		MaxMessages: int32(limit),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished outbox messages: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func newUpdateOutboxMessageParams(msg *outbox.Message) sqlc.UpdateOutboxMessageParams {
+	return sqlc.UpdateOutboxMessageParams{
+		ID:            msg.Event.ID,
+		Status:        string(msg.Status),
+		Attempts:      int32(msg.Attempts),
+		LastError:     msg.LastError,
+		NextAttemptAt: msg.NextAttemptAt,
+		SentAt: pgtype.Timestamptz{
+			Time:  msg.SentAt,
+			Valid: !msg.SentAt.IsZero(),
+		},
+	}
+}
+
+func parseOutboxMessage(row sqlc.Outbox) *outbox.Message {
+	msg := &outbox.Message{
+		Event: event.Event{
+			ID:         row.ID,
+			Type:       event.Type(row.EventType),
+			UserID:     row.UserID,
+			OccurredAt: row.OccurredAt.UTC(),
+			Payload:    row.Payload,
+		},
+		Status:        outbox.Status(row.Status),
+		Attempts:      int(row.Attempts),
+		LastError:     row.LastError,
+		NextAttemptAt: row.NextAttemptAt.UTC(),
+	}
+	if row.SentAt.Valid {
+		msg.SentAt = row.SentAt.Time.UTC()
+	}
+	return msg
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultConnectPolicy bounds the attempts of [New] to reach the database.
+var defaultConnectPolicy = retryPolicy{
+	maxAttempts: 10,
+	baseDelay:   250 * time.Millisecond,
+	maxDelay:    5 * time.Second,
+}
+
+// configurePool applies `opts` to `config`, leaving the pgx defaults, or the
+// values set in the connection URL, in place of zero options.
+func configurePool(config *pgxpool.Config, opts Options) {
+	if opts.MaxConns > 0 {
+		config.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		config.MinConns = opts.MinConns
+	}
+	if opts.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = opts.MaxConnLifetime
+	}
+	if opts.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	if opts.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = opts.HealthCheckPeriod
+	}
+
+	config.ConnConfig.Tracer = newQueryTracer(opts.QueryLogger)
+
+	if settings := sessionSettings(opts); len(settings) > 0 {
+		config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			for _, setting := range settings {
+				if _, err := conn.Exec(
+					ctx,
+					"SELECT set_config($1, $2, false)",
+					setting.name,
+					setting.value,
+				); err != nil {
+					return fmt.Errorf("set %s: %w", setting.name, err)
+				}
+			}
+			return nil
+		}
+	}
+}
+
+type sessionSetting struct {
+	name  string
+	value string
+}
+
+// sessionSettings returns the run-time parameters set on every new connection.
+func sessionSettings(opts Options) []sessionSetting {
+	var settings []sessionSetting
+	if opts.ApplicationName != "" {
+		settings = append(settings, sessionSetting{"application_name", opts.ApplicationName})
+	}
+	if opts.StatementTimeout > 0 {
+		settings = append(settings, sessionSetting{
+			"statement_timeout",
+			strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10),
+		})
+	}
+	return settings
+}
+
+// pinger is satisfied by [pgxpool.Pool].
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// pingWithRetry pings the database until it responds, waiting between
+// attempts as set by `policy`. Waits are cut short if `ctx` is done.
+func pingWithRetry(ctx context.Context, db pinger, policy retryPolicy) error {
+	attempts := max(policy.maxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := db.Ping(ctx)
+		if err == nil || attempt == attempts {
+			return err
+		}
+
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry ping: %w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
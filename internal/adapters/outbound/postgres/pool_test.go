@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePinger fails its first `failures` pings.
+type fakePinger struct {
+	failures int
+	pings    int
+}
+
+var errUnreachable = errors.New("connection refused")
+
+func (p *fakePinger) Ping(context.Context) error {
+	p.pings++
+	if p.pings <= p.failures {
+		return errUnreachable
+	}
+	return nil
+}
+
+func TestPingWithRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: 2 * time.Millisecond}
+
+	testCases := []struct {
+		name      string
+		failures  int
+		wantErr   error
+		wantPings int
+	}{
+		{name: "first ping succeeds", failures: 0, wantPings: 1},
+		{name: "succeeds after retries", failures: 2, wantPings: 3},
+		{name: "gives up", failures: 5, wantErr: errUnreachable, wantPings: 3},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &fakePinger{failures: tc.failures}
+			err := pingWithRetry(context.Background(), db, policy)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantPings, db.pings)
+		})
+	}
+}
+
+func TestPingWithRetry_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy := retryPolicy{maxAttempts: 5, baseDelay: time.Hour, maxDelay: time.Hour}
+
+	db := &fakePinger{failures: 5}
+	err := pingWithRetry(ctx, db, policy)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errUnreachable)
+	assert.Equal(t, 1, db.pings)
+}
+
+func TestConfigurePool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero options keep the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := pgxpool.ParseConfig("postgres://user@localhost:5432/db?pool_max_conns=7")
+		require.NoError(t, err)
+		want := *config
+
+		configurePool(config, Options{})
+
+		assert.Equal(t, int32(7), config.MaxConns)
+		assert.Equal(t, want.MinConns, config.MinConns)
+		assert.Equal(t, want.MaxConnLifetime, config.MaxConnLifetime)
+		assert.Equal(t, want.MaxConnIdleTime, config.MaxConnIdleTime)
+		assert.Equal(t, want.HealthCheckPeriod, config.HealthCheckPeriod)
+		assert.Nil(t, config.AfterConnect)
+		assert.NotNil(t, config.ConnConfig.Tracer)
+	})
+
+	t.Run("options override the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := pgxpool.ParseConfig("postgres://user@localhost:5432/db")
+		require.NoError(t, err)
+
+		configurePool(config, Options{
+			MaxConns:          20,
+			MinConns:          2,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   5 * time.Minute,
+			HealthCheckPeriod: 30 * time.Second,
+			ApplicationName:   "codeodessey",
+		})
+
+		assert.Equal(t, int32(20), config.MaxConns)
+		assert.Equal(t, int32(2), config.MinConns)
+		assert.Equal(t, time.Hour, config.MaxConnLifetime)
+		assert.Equal(t, 5*time.Minute, config.MaxConnIdleTime)
+		assert.Equal(t, 30*time.Second, config.HealthCheckPeriod)
+		assert.NotNil(t, config.AfterConnect)
+	})
+}
+
+func TestSessionSettings(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, sessionSettings(Options{}))
+	assert.Equal(t, []sessionSetting{
+		{"application_name", "codeodessey"},
+		{"statement_timeout", "1500"},
+	}, sessionSettings(Options{
+		ApplicationName:  "codeodessey",
+		StatementTimeout: 1500 * time.Millisecond,
+	}))
+}
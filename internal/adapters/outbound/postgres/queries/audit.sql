@@ -0,0 +1,49 @@
+-- name: LockAuditLog :exec
+-- Serializes appends to the audit log until the end of the transaction.
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_key)::bigint);
+
+-- name: GetLastAuditEvent :one
+SELECT sequence, hash FROM audit_events
+ORDER BY sequence DESC
+LIMIT 1;
+
+-- name: AppendAuditEvent :exec
+INSERT INTO audit_events (
+    sequence,
+    id,
+    action,
+    actor_id,
+    target,
+    client_ip,
+    user_agent,
+    request_id,
+    details,
+    occurred_at,
+    prev_hash,
+    hash
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+);
+
+-- name: QueryAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor_id)::uuid IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (sqlc.narg(target)::varchar IS NULL OR target = sqlc.narg(target))
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR occurred_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR occurred_at < sqlc.narg(to_time))
+  AND (sqlc.narg(after_sequence)::bigint IS NULL OR sequence > sqlc.narg(after_sequence))
+  AND (sqlc.narg(before_sequence)::bigint IS NULL OR sequence < sqlc.narg(before_sequence))
+ORDER BY sequence
+LIMIT sqlc.arg(max_events);
+
+-- name: QueryAuditEventsNewestFirst :many
+-- Matches the filters of QueryAuditEvents, in descending order.
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor_id)::uuid IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (sqlc.narg(target)::varchar IS NULL OR target = sqlc.narg(target))
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR occurred_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR occurred_at < sqlc.narg(to_time))
+  AND (sqlc.narg(after_sequence)::bigint IS NULL OR sequence > sqlc.narg(after_sequence))
+  AND (sqlc.narg(before_sequence)::bigint IS NULL OR sequence < sqlc.narg(before_sequence))
+ORDER BY sequence DESC
+LIMIT sqlc.arg(max_events);
@@ -0,0 +1,41 @@
+-- name: ReserveIdempotencyKey :one
+-- Claims the key, replacing any expired record and any in-progress record
+-- whose lease has run out. Returns no rows if the key is held by another
+-- record.
+INSERT INTO idempotency_keys (
+    principal,
+    method,
+    key,
+    fingerprint,
+    locked_until,
+    created_at,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (principal, method, key) DO UPDATE
+SET
+    fingerprint = EXCLUDED.fingerprint,
+    response = NULL,
+    locked_until = EXCLUDED.locked_until,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+    OR (idempotency_keys.response IS NULL AND idempotency_keys.locked_until <= EXCLUDED.created_at)
+RETURNING principal, method, key;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE principal = $1 AND method = $2 AND key = $3;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response = $4
+WHERE principal = $1 AND method = $2 AND key = $3;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE principal = $1 AND method = $2 AND key = $3;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
@@ -0,0 +1,6 @@
+-- name: TryAdvisoryLock :one
+-- Takes the session-level advisory lock named by `name` if it is free.
+SELECT pg_try_advisory_lock(hashtextextended(sqlc.arg(name)::text, 0));
+
+-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(hashtextextended(sqlc.arg(name)::text, 0));
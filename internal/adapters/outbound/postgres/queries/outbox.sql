@@ -0,0 +1,44 @@
+-- name: AppendOutboxMessage :exec
+INSERT INTO outbox (
+    id,
+    event_type,
+    user_id,
+    payload,
+    occurred_at,
+    status,
+    attempts,
+    last_error,
+    next_attempt_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: ClaimOutboxMessages :many
+-- Locks the oldest due messages, skipping those locked by other relays.
+SELECT * FROM outbox
+WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(now)
+ORDER BY occurred_at
+LIMIT sqlc.arg(max_messages)
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateOutboxMessage :exec
+UPDATE outbox
+SET
+    status = $2,
+    attempts = $3,
+    last_error = $4,
+    next_attempt_at = $5,
+    sent_at = $6
+WHERE id = $1;
+
+-- name: DeleteFinishedOutboxMessages :execrows
+-- Deletes a batch of messages that were sent, or died and occurred, before the
+-- given cutoffs. Rows locked by concurrent writers are skipped.
+DELETE FROM outbox
+WHERE id IN (
+    SELECT finished.id FROM outbox AS finished
+    WHERE (finished.status = 'sent' AND finished.sent_at < sqlc.arg(sent_before)::timestamptz)
+       OR (finished.status = 'dead' AND finished.occurred_at < sqlc.arg(dead_before))
+    LIMIT sqlc.arg(max_messages)
+    FOR UPDATE SKIP LOCKED
+);
@@ -0,0 +1,31 @@
+-- name: InsertRateLimitBucket :exec
+INSERT INTO rate_limit_buckets (
+    key,
+    tokens,
+    updated_at,
+    full_at
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (key) DO NOTHING;
+
+-- name: GetRateLimitBucketForUpdate :one
+SELECT key, tokens, updated_at FROM rate_limit_buckets
+WHERE key = $1
+FOR UPDATE;
+
+-- name: UpdateRateLimitBucket :exec
+UPDATE rate_limit_buckets
+SET
+    tokens = $2,
+    updated_at = $3,
+    full_at = $4
+WHERE key = $1;
+
+-- name: DeleteFullRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE key IN (
+    SELECT bucket.key FROM rate_limit_buckets AS bucket
+    WHERE bucket.full_at <= sqlc.arg(full_before)
+    LIMIT sqlc.arg(max_buckets)
+    FOR UPDATE SKIP LOCKED
+);
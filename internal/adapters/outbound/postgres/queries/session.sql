@@ -17,8 +17,22 @@ WHERE id = $1 LIMIT 1;
 
 -- name: GetSessionByUserID :one
 SELECT * FROM sessions
-WHERE user_id = $1 LIMIT 1;
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
 
 -- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE id = $1;
+
+-- name: DeleteStaleSessions :execrows
+-- Deletes a batch of sessions that expired, or were blocked and created,
+-- before the given cutoffs. Rows locked by concurrent writers are skipped.
+DELETE FROM sessions
+WHERE id IN (
+    SELECT stale.id FROM sessions AS stale
+    WHERE stale.expires_at < sqlc.arg(expired_before)
+       OR (stale.is_blocked AND stale.created_at < sqlc.arg(blocked_before))
+    LIMIT sqlc.arg(max_sessions)
+    FOR UPDATE SKIP LOCKED
+);
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+)
+
+// RateLimitStore is a [ratelimit.Store] backed by the `rate_limit_buckets`
+// table, allowing limits to be shared by every replica of the service.
+type RateLimitStore struct {
+	client *Client
+	now    func() time.Time
+}
+
+var _ ratelimit.Store = (*RateLimitStore)(nil)
+
+func NewRateLimitStore(client *Client) *RateLimitStore {
+	return &RateLimitStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Take locks the bucket row for `key` for the duration of a transaction, so
+// that concurrent requests from different replicas are serialized.
+func (s *RateLimitStore) Take(
+	ctx context.Context,
+	key string,
+	limit ratelimit.Limit,
+) (ratelimit.Result, error) {
+	var result ratelimit.Result
+	err := s.client.InTx(ctx, pgx.ReadCommitted, func(q sqlc.Querier) error {
+		var err error
+		result, err = s.take(ctx, q, key, limit)
+		return err
+	})
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return result, nil
+}
+
+func (s *RateLimitStore) take(
+	ctx context.Context,
+	queries sqlc.Querier,
+	key string,
+	limit ratelimit.Limit,
+) (ratelimit.Result, error) {
+	now := s.now().UTC()
+
+	initial := ratelimit.NewBucket(limit, now)
+	if err := queries.InsertRateLimitBucket(ctx, sqlc.InsertRateLimitBucketParams{
+		Key:       key,
+		Tokens:    initial.Tokens,
+		UpdatedAt: initial.UpdatedAt,
+		FullAt:    initial.FullAt(limit),
+	}); err != nil {
+		return ratelimit.Result{}, fmt.Errorf("insert rate limit bucket %q: %w", key, err)
+	}
+
+	row, err := queries.GetRateLimitBucketForUpdate(ctx, key)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("lock rate limit bucket %q: %w", key, err)
+	}
+
+	bucket := ratelimit.Bucket{Tokens: row.Tokens, UpdatedAt: row.UpdatedAt}
+	bucket, result := bucket.Take(limit, now)
+
+	if err := queries.UpdateRateLimitBucket(ctx, sqlc.UpdateRateLimitBucketParams{
+		Key:       key,
+		Tokens:    bucket.Tokens,
+		UpdatedAt: bucket.UpdatedAt,
+		FullAt:    bucket.FullAt(limit),
+	}); err != nil {
+		return ratelimit.Result{}, fmt.Errorf("update rate limit bucket %q: %w", key, err)
+	}
+
+	return result, nil
+}
+
+// pruneBatchSize is the most buckets deleted by one statement of
+// [RateLimitStore.Prune].
+const pruneBatchSize = 1000
+
+// Prune deletes the buckets that have refilled completely, and returns the
+// number deleted. Buckets are created for every client and method, so
+// without pruning the table grows with the number of distinct clients.
+func (s *RateLimitStore) Prune(ctx context.Context) (int64, error) {
+	now := s.now().UTC()
+
+	var total int64
+	for {
+		deleted, err := s.client.queries.DeleteFullRateLimitBuckets(ctx, sqlc.DeleteFullRateLimitBucketsParams{
+			FullBefore: now,
+			MaxBuckets: pruneBatchSize,
+		})
+		total += deleted
+		if err != nil {
+			return total, fmt.Errorf("delete full rate limit buckets: %w", err)
+		}
+		if deleted < pruneBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/config"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/ratelimit"
+)
+
+func TestRateLimitStore_Prune(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadConfig("../../../..")
+	require.NoError(t, err)
+
+	client, err := New(context.Background(), NewURL(cfg), Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	limit := ratelimit.Limit{Rate: 1, Burst: 2}
+
+	store := NewRateLimitStore(client)
+	store.now = func() time.Time { return now }
+
+	full, empty := uuid.NewString(), uuid.NewString()
+	_, err = store.Take(ctx, full, limit)
+	require.NoError(t, err)
+	for i := 0; i < limit.Burst; i++ {
+		_, err = store.Take(ctx, empty, limit)
+		require.NoError(t, err)
+	}
+
+	// One second later the bucket that lost a token is full again, and the
+	// one that lost two is not.
+	store.now = func() time.Time { return now.Add(time.Second) }
+	deleted, err := store.Prune(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = client.queries.GetRateLimitBucketForUpdate(ctx, full)
+	assert.True(t, errors.Is(err, pgx.ErrNoRows), "full bucket was not deleted")
+
+	_, err = client.queries.GetRateLimitBucketForUpdate(ctx, empty)
+	assert.NoError(t, err, "refilling bucket was deleted")
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+)
+
+func TestParseSession(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		clientIP string
+		want     string
+	}{
+		{name: "Normalized address", clientIP: "203.0.113.5", want: "203.0.113.5"},
+		{name: "Legacy address with port", clientIP: "203.0.113.5:443", want: "203.0.113.5"},
+		{name: "Legacy forwarding header", clientIP: "203.0.113.5, 10.0.0.1", want: ""},
+		{name: "Unknown client", clientIP: "", want: ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := parseSession(sqlc.Session{ClientIp: tc.clientIP, ExpiresAt: time.Now()})
+			assert.Equal(t, tc.want, session.ClientIP.String())
+		})
+	}
+}
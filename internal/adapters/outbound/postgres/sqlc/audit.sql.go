@@ -0,0 +1,221 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: audit.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const appendAuditEvent = `-- name: AppendAuditEvent :exec
+INSERT INTO audit_events (
+    sequence,
+    id,
+    action,
+    actor_id,
+    target,
+    client_ip,
+    user_agent,
+    request_id,
+    details,
+    occurred_at,
+    prev_hash,
+    hash
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+)
+`
+
+type AppendAuditEventParams struct {
+	Sequence   int64     `json:"sequence"`
+	ID         uuid.UUID `json:"id"`
+	Action     string    `json:"action"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	Target     string    `json:"target"`
+	ClientIp   string    `json:"client_ip"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	Details    []byte    `json:"details"`
+	OccurredAt time.Time `json:"occurred_at"`
+	PrevHash   []byte    `json:"prev_hash"`
+	Hash       []byte    `json:"hash"`
+}
+
+func (q *Queries) AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error {
+	_, err := q.db.Exec(ctx, appendAuditEvent,
+		arg.Sequence,
+		arg.ID,
+		arg.Action,
+		arg.ActorID,
+		arg.Target,
+		arg.ClientIp,
+		arg.UserAgent,
+		arg.RequestID,
+		arg.Details,
+		arg.OccurredAt,
+		arg.PrevHash,
+		arg.Hash,
+	)
+	return err
+}
+
+const getLastAuditEvent = `-- name: GetLastAuditEvent :one
+SELECT sequence, hash FROM audit_events
+ORDER BY sequence DESC
+LIMIT 1
+`
+
+type GetLastAuditEventRow struct {
+	Sequence int64  `json:"sequence"`
+	Hash     []byte `json:"hash"`
+}
+
+func (q *Queries) GetLastAuditEvent(ctx context.Context) (GetLastAuditEventRow, error) {
+	row := q.db.QueryRow(ctx, getLastAuditEvent)
+	var i GetLastAuditEventRow
+	err := row.Scan(&i.Sequence, &i.Hash)
+	return i, err
+}
+
+const lockAuditLog = `-- name: LockAuditLog :exec
+SELECT pg_advisory_xact_lock($1::bigint)
+`
+
+// Serializes appends to the audit log until the end of the transaction.
+func (q *Queries) LockAuditLog(ctx context.Context, lockKey int64) error {
+	_, err := q.db.Exec(ctx, lockAuditLog, lockKey)
+	return err
+}
+
+const queryAuditEvents = `-- name: QueryAuditEvents :many
+SELECT sequence, id, action, actor_id, target, client_ip, user_agent, request_id, details, occurred_at, prev_hash, hash FROM audit_events
+WHERE ($1::uuid IS NULL OR actor_id = $1)
+  AND ($2::varchar IS NULL OR target = $2)
+  AND ($3::timestamptz IS NULL OR occurred_at >= $3)
+  AND ($4::timestamptz IS NULL OR occurred_at < $4)
+  AND ($5::bigint IS NULL OR sequence > $5)
+  AND ($6::bigint IS NULL OR sequence < $6)
+ORDER BY sequence
+LIMIT $7
+`
+
+type QueryAuditEventsParams struct {
+	ActorID        pgtype.UUID        `json:"actor_id"`
+	Target         pgtype.Text        `json:"target"`
+	FromTime       pgtype.Timestamptz `json:"from_time"`
+	ToTime         pgtype.Timestamptz `json:"to_time"`
+	AfterSequence  pgtype.Int8        `json:"after_sequence"`
+	BeforeSequence pgtype.Int8        `json:"before_sequence"`
+	MaxEvents      int32              `json:"max_events"`
+}
+
+func (q *Queries) QueryAuditEvents(ctx context.Context, arg QueryAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, queryAuditEvents,
+		arg.ActorID,
+		arg.Target,
+		arg.FromTime,
+		arg.ToTime,
+		arg.AfterSequence,
+		arg.BeforeSequence,
+		arg.MaxEvents,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.Sequence,
+			&i.ID,
+			&i.Action,
+			&i.ActorID,
+			&i.Target,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Details,
+			&i.OccurredAt,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const queryAuditEventsNewestFirst = `-- name: QueryAuditEventsNewestFirst :many
+SELECT sequence, id, action, actor_id, target, client_ip, user_agent, request_id, details, occurred_at, prev_hash, hash FROM audit_events
+WHERE ($1::uuid IS NULL OR actor_id = $1)
+  AND ($2::varchar IS NULL OR target = $2)
+  AND ($3::timestamptz IS NULL OR occurred_at >= $3)
+  AND ($4::timestamptz IS NULL OR occurred_at < $4)
+  AND ($5::bigint IS NULL OR sequence > $5)
+  AND ($6::bigint IS NULL OR sequence < $6)
+ORDER BY sequence DESC
+LIMIT $7
+`
+
+type QueryAuditEventsNewestFirstParams struct {
+	ActorID        pgtype.UUID        `json:"actor_id"`
+	Target         pgtype.Text        `json:"target"`
+	FromTime       pgtype.Timestamptz `json:"from_time"`
+	ToTime         pgtype.Timestamptz `json:"to_time"`
+	AfterSequence  pgtype.Int8        `json:"after_sequence"`
+	BeforeSequence pgtype.Int8        `json:"before_sequence"`
+	MaxEvents      int32              `json:"max_events"`
+}
+
+// Matches the filters of QueryAuditEvents, in descending order.
+func (q *Queries) QueryAuditEventsNewestFirst(ctx context.Context, arg QueryAuditEventsNewestFirstParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, queryAuditEventsNewestFirst,
+		arg.ActorID,
+		arg.Target,
+		arg.FromTime,
+		arg.ToTime,
+		arg.AfterSequence,
+		arg.BeforeSequence,
+		arg.MaxEvents,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.Sequence,
+			&i.ID,
+			&i.Action,
+			&i.ActorID,
+			&i.Target,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Details,
+			&i.OccurredAt,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,147 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: idempotency.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response = $4
+WHERE principal = $1 AND method = $2 AND key = $3
+`
+
+type CompleteIdempotencyKeyParams struct {
+	Principal string `json:"principal"`
+	Method    string `json:"method"`
+	Key       string `json:"key"`
+	Response  []byte `json:"response"`
+}
+
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, completeIdempotencyKey,
+		arg.Principal,
+		arg.Method,
+		arg.Key,
+		arg.Response,
+	)
+	return err
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredIdempotencyKeys, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE principal = $1 AND method = $2 AND key = $3
+`
+
+type DeleteIdempotencyKeyParams struct {
+	Principal string `json:"principal"`
+	Method    string `json:"method"`
+	Key       string `json:"key"`
+}
+
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, deleteIdempotencyKey, arg.Principal, arg.Method, arg.Key)
+	return err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT principal, method, key, fingerprint, response, created_at, expires_at, locked_until FROM idempotency_keys
+WHERE principal = $1 AND method = $2 AND key = $3
+`
+
+type GetIdempotencyKeyParams struct {
+	Principal string `json:"principal"`
+	Method    string `json:"method"`
+	Key       string `json:"key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, arg.Principal, arg.Method, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Principal,
+		&i.Method,
+		&i.Key,
+		&i.Fingerprint,
+		&i.Response,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const reserveIdempotencyKey = `-- name: ReserveIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    principal,
+    method,
+    key,
+    fingerprint,
+    locked_until,
+    created_at,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (principal, method, key) DO UPDATE
+SET
+    fingerprint = EXCLUDED.fingerprint,
+    response = NULL,
+    locked_until = EXCLUDED.locked_until,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+    OR (idempotency_keys.response IS NULL AND idempotency_keys.locked_until <= EXCLUDED.created_at)
+RETURNING principal, method, key
+`
+
+type ReserveIdempotencyKeyParams struct {
+	Principal   string    `json:"principal"`
+	Method      string    `json:"method"`
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	LockedUntil time.Time `json:"locked_until"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ReserveIdempotencyKeyRow struct {
+	Principal string `json:"principal"`
+	Method    string `json:"method"`
+	Key       string `json:"key"`
+}
+
+// Claims the key, replacing any expired record and any in-progress record
+// whose lease has run out. Returns no rows if the key is held by another
+// record.
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (ReserveIdempotencyKeyRow, error) {
+	row := q.db.QueryRow(ctx, reserveIdempotencyKey,
+		arg.Principal,
+		arg.Method,
+		arg.Key,
+		arg.Fingerprint,
+		arg.LockedUntil,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var i ReserveIdempotencyKeyRow
+	err := row.Scan(&i.Principal, &i.Method, &i.Key)
+	return i, err
+}
@@ -0,0 +1,145 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func reserveParams(now time.Time, ttl time.Duration) ReserveIdempotencyKeyParams {
+	return ReserveIdempotencyKeyParams{
+		Principal:   "user:" + uuid.NewString(),
+		Method:      "/user.UserService/Register",
+		Key:         uuid.NewString(),
+		Fingerprint: "fingerprint",
+		LockedUntil: now.Add(time.Minute),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+//nolint:paralleltest
+func TestReserveIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	arg := reserveParams(now, time.Hour)
+
+	_, err := testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.NoError(t, err)
+
+	// A second reservation of an unexpired key is refused.
+	_, err = testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.True(t, errors.Is(err, pgx.ErrNoRows))
+
+	err = testQueries.CompleteIdempotencyKey(ctx, CompleteIdempotencyKeyParams{
+		Principal: arg.Principal,
+		Method:    arg.Method,
+		Key:       arg.Key,
+		Response:  []byte("response"),
+	})
+	require.NoError(t, err)
+
+	record, err := testQueries.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Principal: arg.Principal,
+		Method:    arg.Method,
+		Key:       arg.Key,
+	})
+	require.NoError(t, err)
+	require.Equal(t, arg.Fingerprint, record.Fingerprint)
+	require.Equal(t, []byte("response"), record.Response)
+	require.Equal(t, arg.ExpiresAt, record.ExpiresAt.UTC())
+}
+
+//nolint:paralleltest
+func TestReserveIdempotencyKey_ReplacesExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	arg := reserveParams(now.Add(-2*time.Hour), time.Hour)
+
+	_, err := testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.NoError(t, err)
+
+	arg.CreatedAt = now
+	arg.LockedUntil = now.Add(time.Minute)
+	arg.ExpiresAt = now.Add(time.Hour)
+	arg.Fingerprint = "other"
+	_, err = testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.NoError(t, err)
+
+	record, err := testQueries.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Principal: arg.Principal,
+		Method:    arg.Method,
+		Key:       arg.Key,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "other", record.Fingerprint)
+	require.Nil(t, record.Response)
+}
+
+//nolint:paralleltest
+func TestReserveIdempotencyKey_ReplacesAbandoned(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	arg := reserveParams(now.Add(-2*time.Minute), time.Hour)
+
+	_, err := testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.NoError(t, err)
+
+	// The first request's lease ran out a minute ago without it completing.
+	arg.CreatedAt = now
+	arg.LockedUntil = now.Add(time.Minute)
+	arg.Fingerprint = "other"
+	_, err = testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.NoError(t, err)
+
+	err = testQueries.CompleteIdempotencyKey(ctx, CompleteIdempotencyKeyParams{
+		Principal: arg.Principal,
+		Method:    arg.Method,
+		Key:       arg.Key,
+		Response:  []byte("response"),
+	})
+	require.NoError(t, err)
+
+	// Completed records are kept after their lease, until they expire.
+	arg.CreatedAt = now.Add(10 * time.Minute)
+	arg.LockedUntil = arg.CreatedAt.Add(time.Minute)
+	_, err = testQueries.ReserveIdempotencyKey(ctx, arg)
+	require.True(t, errors.Is(err, pgx.ErrNoRows))
+}
+
+//nolint:paralleltest
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	expired := reserveParams(now.Add(-2*time.Hour), time.Hour)
+	_, err := testQueries.ReserveIdempotencyKey(ctx, expired)
+	require.NoError(t, err)
+
+	live := reserveParams(now, time.Hour)
+	_, err = testQueries.ReserveIdempotencyKey(ctx, live)
+	require.NoError(t, err)
+
+	deleted, err := testQueries.DeleteExpiredIdempotencyKeys(ctx, now)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = testQueries.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Principal: expired.Principal,
+		Method:    expired.Method,
+		Key:       expired.Key,
+	})
+	require.True(t, errors.Is(err, pgx.ErrNoRows))
+
+	_, err = testQueries.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Principal: live.Principal,
+		Method:    live.Method,
+		Key:       live.Key,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,33 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: lock.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const advisoryUnlock = `-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(hashtextextended($1::text, 0))
+`
+
+func (q *Queries) AdvisoryUnlock(ctx context.Context, name string) (bool, error) {
+	row := q.db.QueryRow(ctx, advisoryUnlock, name)
+	var pg_advisory_unlock bool
+	err := row.Scan(&pg_advisory_unlock)
+	return pg_advisory_unlock, err
+}
+
+const tryAdvisoryLock = `-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(hashtextextended($1::text, 0))
+`
+
+// Takes the session-level advisory lock named by `name` if it is free.
+func (q *Queries) TryAdvisoryLock(ctx context.Context, name string) (bool, error) {
+	row := q.db.QueryRow(ctx, tryAdvisoryLock, name)
+	var pg_try_advisory_lock bool
+	err := row.Scan(&pg_try_advisory_lock)
+	return pg_try_advisory_lock, err
+}
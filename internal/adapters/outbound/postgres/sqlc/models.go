@@ -8,8 +8,56 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditEvent struct {
+	Sequence   int64     `json:"sequence"`
+	ID         uuid.UUID `json:"id"`
+	Action     string    `json:"action"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	Target     string    `json:"target"`
+	ClientIp   string    `json:"client_ip"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	Details    []byte    `json:"details"`
+	OccurredAt time.Time `json:"occurred_at"`
+	PrevHash   []byte    `json:"prev_hash"`
+	Hash       []byte    `json:"hash"`
+}
+
+type IdempotencyKey struct {
+	Principal   string    `json:"principal"`
+	Method      string    `json:"method"`
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	Response    []byte    `json:"response"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type Outbox struct {
+	ID            uuid.UUID          `json:"id"`
+	EventType     string             `json:"event_type"`
+	UserID        uuid.UUID          `json:"user_id"`
+	Payload       []byte             `json:"payload"`
+	OccurredAt    time.Time          `json:"occurred_at"`
+	Status        string             `json:"status"`
+	Attempts      int32              `json:"attempts"`
+	LastError     string             `json:"last_error"`
+	NextAttemptAt time.Time          `json:"next_attempt_at"`
+	SentAt        pgtype.Timestamptz `json:"sent_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type RateLimitBucket struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+	FullAt    time.Time `json:"full_at"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	UserID       uuid.UUID `json:"user_id"`
@@ -0,0 +1,162 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: outbox.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const appendOutboxMessage = `-- name: AppendOutboxMessage :exec
+INSERT INTO outbox (
+    id,
+    event_type,
+    user_id,
+    payload,
+    occurred_at,
+    status,
+    attempts,
+    last_error,
+    next_attempt_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+`
+
+type AppendOutboxMessageParams struct {
+	ID            uuid.UUID `json:"id"`
+	EventType     string    `json:"event_type"`
+	UserID        uuid.UUID `json:"user_id"`
+	Payload       []byte    `json:"payload"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Status        string    `json:"status"`
+	Attempts      int32     `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+}
+
+func (q *Queries) AppendOutboxMessage(ctx context.Context, arg AppendOutboxMessageParams) error {
+	_, err := q.db.Exec(ctx, appendOutboxMessage,
+		arg.ID,
+		arg.EventType,
+		arg.UserID,
+		arg.Payload,
+		arg.OccurredAt,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+	)
+	return err
+}
+
+const claimOutboxMessages = `-- name: ClaimOutboxMessages :many
+SELECT id, event_type, user_id, payload, occurred_at, status, attempts, last_error, next_attempt_at, sent_at, created_at FROM outbox
+WHERE status = 'pending' AND next_attempt_at <= $1
+ORDER BY occurred_at
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type ClaimOutboxMessagesParams struct {
+	Now         time.Time `json:"now"`
+	MaxMessages int32     `json:"max_messages"`
+}
+
+// Locks the oldest due messages, skipping those locked by other relays.
+func (q *Queries) ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, claimOutboxMessages, arg.Now, arg.MaxMessages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.UserID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteFinishedOutboxMessages = `-- name: DeleteFinishedOutboxMessages :execrows
+DELETE FROM outbox
+WHERE id IN (
+    SELECT finished.id FROM outbox AS finished
+    WHERE (finished.status = 'sent' AND finished.sent_at < $1::timestamptz)
+       OR (finished.status = 'dead' AND finished.occurred_at < $2)
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type DeleteFinishedOutboxMessagesParams struct {
+	SentBefore  time.Time `json:"sent_before"`
+	DeadBefore  time.Time `json:"dead_before"`
+	MaxMessages int32     `json:"max_messages"`
+}
+
+// Deletes a batch of messages that were sent, or died and occurred, before the
+// given cutoffs. Rows locked by concurrent writers are skipped.
+func (q *Queries) DeleteFinishedOutboxMessages(ctx context.Context, arg DeleteFinishedOutboxMessagesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFinishedOutboxMessages, arg.SentBefore, arg.DeadBefore, arg.MaxMessages)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateOutboxMessage = `-- name: UpdateOutboxMessage :exec
+UPDATE outbox
+SET
+    status = $2,
+    attempts = $3,
+    last_error = $4,
+    next_attempt_at = $5,
+    sent_at = $6
+WHERE id = $1
+`
+
+type UpdateOutboxMessageParams struct {
+	ID            uuid.UUID          `json:"id"`
+	Status        string             `json:"status"`
+	Attempts      int32              `json:"attempts"`
+	LastError     string             `json:"last_error"`
+	NextAttemptAt time.Time          `json:"next_attempt_at"`
+	SentAt        pgtype.Timestamptz `json:"sent_at"`
+}
+
+func (q *Queries) UpdateOutboxMessage(ctx context.Context, arg UpdateOutboxMessageParams) error {
+	_, err := q.db.Exec(ctx, updateOutboxMessage,
+		arg.ID,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.SentAt,
+	)
+	return err
+}
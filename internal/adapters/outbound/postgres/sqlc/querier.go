@@ -6,19 +6,52 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AdvisoryUnlock(ctx context.Context, name string) (bool, error)
+	AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error
+	AppendOutboxMessage(ctx context.Context, arg AppendOutboxMessageParams) error
+	// Locks the oldest due messages, skipping those locked by other relays.
+	ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]Outbox, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error)
+	// Deletes a batch of messages that were sent, or died and occurred, before the
+	// given cutoffs. Rows locked by concurrent writers are skipped.
+	DeleteFinishedOutboxMessages(ctx context.Context, arg DeleteFinishedOutboxMessagesParams) (int64, error)
+	DeleteFullRateLimitBuckets(ctx context.Context, arg DeleteFullRateLimitBucketsParams) (int64, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
 	DeleteSession(ctx context.Context, id uuid.UUID) error
+	// Deletes a batch of sessions that expired, or were blocked and created,
+	// before the given cutoffs. Rows locked by concurrent writers are skipped.
+	DeleteStaleSessions(ctx context.Context, arg DeleteStaleSessionsParams) (int64, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLastAuditEvent(ctx context.Context) (GetLastAuditEventRow, error)
+	GetRateLimitBucketForUpdate(ctx context.Context, key string) (GetRateLimitBucketForUpdateRow, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetSessionByUserID(ctx context.Context, userID uuid.UUID) (Session, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserById(ctx context.Context, id uuid.UUID) (GetUserByIdRow, error)
+	InsertRateLimitBucket(ctx context.Context, arg InsertRateLimitBucketParams) error
+	// Serializes appends to the audit log until the end of the transaction.
+	LockAuditLog(ctx context.Context, lockKey int64) error
+	QueryAuditEvents(ctx context.Context, arg QueryAuditEventsParams) ([]AuditEvent, error)
+	// Matches the filters of QueryAuditEvents, in descending order.
+	QueryAuditEventsNewestFirst(ctx context.Context, arg QueryAuditEventsNewestFirstParams) ([]AuditEvent, error)
+	// Claims the key, replacing any expired record and any in-progress record
+	// whose lease has run out. Returns no rows if the key is held by another
+	// record.
+	ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (ReserveIdempotencyKeyRow, error)
+	// Takes the session-level advisory lock named by `name` if it is free.
+	TryAdvisoryLock(ctx context.Context, name string) (bool, error)
+	UpdateOutboxMessage(ctx context.Context, arg UpdateOutboxMessageParams) error
+	UpdateRateLimitBucket(ctx context.Context, arg UpdateRateLimitBucketParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UserExists(ctx context.Context, id uuid.UUID) (bool, error)
 }
@@ -0,0 +1,107 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: rate_limit.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const deleteFullRateLimitBuckets = `-- name: DeleteFullRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE key IN (
+    SELECT bucket.key FROM rate_limit_buckets AS bucket
+    WHERE bucket.full_at <= $1
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type DeleteFullRateLimitBucketsParams struct {
+	FullBefore time.Time `json:"full_before"`
+	MaxBuckets int32     `json:"max_buckets"`
+}
+
+func (q *Queries) DeleteFullRateLimitBuckets(ctx context.Context, arg DeleteFullRateLimitBucketsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFullRateLimitBuckets, arg.FullBefore, arg.MaxBuckets)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getRateLimitBucketForUpdate = `-- name: GetRateLimitBucketForUpdate :one
+SELECT key, tokens, updated_at FROM rate_limit_buckets
+WHERE key = $1
+FOR UPDATE
+`
+
+type GetRateLimitBucketForUpdateRow struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) GetRateLimitBucketForUpdate(ctx context.Context, key string) (GetRateLimitBucketForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getRateLimitBucketForUpdate, key)
+	var i GetRateLimitBucketForUpdateRow
+	err := row.Scan(&i.Key, &i.Tokens, &i.UpdatedAt)
+	return i, err
+}
+
+const insertRateLimitBucket = `-- name: InsertRateLimitBucket :exec
+INSERT INTO rate_limit_buckets (
+    key,
+    tokens,
+    updated_at,
+    full_at
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (key) DO NOTHING
+`
+
+type InsertRateLimitBucketParams struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+	FullAt    time.Time `json:"full_at"`
+}
+
+func (q *Queries) InsertRateLimitBucket(ctx context.Context, arg InsertRateLimitBucketParams) error {
+	_, err := q.db.Exec(ctx, insertRateLimitBucket,
+		arg.Key,
+		arg.Tokens,
+		arg.UpdatedAt,
+		arg.FullAt,
+	)
+	return err
+}
+
+const updateRateLimitBucket = `-- name: UpdateRateLimitBucket :exec
+UPDATE rate_limit_buckets
+SET
+    tokens = $2,
+    updated_at = $3,
+    full_at = $4
+WHERE key = $1
+`
+
+type UpdateRateLimitBucketParams struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+	FullAt    time.Time `json:"full_at"`
+}
+
+func (q *Queries) UpdateRateLimitBucket(ctx context.Context, arg UpdateRateLimitBucketParams) error {
+	_, err := q.db.Exec(ctx, updateRateLimitBucket,
+		arg.Key,
+		arg.Tokens,
+		arg.UpdatedAt,
+		arg.FullAt,
+	)
+	return err
+}
@@ -70,6 +70,33 @@ func (q *Queries) DeleteSession(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteStaleSessions = `-- name: DeleteStaleSessions :execrows
+DELETE FROM sessions
+WHERE id IN (
+    SELECT stale.id FROM sessions AS stale
+    WHERE stale.expires_at < $1
+       OR (stale.is_blocked AND stale.created_at < $2)
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type DeleteStaleSessionsParams struct {
+	ExpiredBefore time.Time `json:"expired_before"`
+	BlockedBefore time.Time `json:"blocked_before"`
+	MaxSessions   int32     `json:"max_sessions"`
+}
+
+// Deletes a batch of sessions that expired, or were blocked and created,
+// before the given cutoffs. Rows locked by concurrent writers are skipped.
+func (q *Queries) DeleteStaleSessions(ctx context.Context, arg DeleteStaleSessionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleSessions, arg.ExpiredBefore, arg.BlockedBefore, arg.MaxSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getSession = `-- name: GetSession :one
 SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at FROM sessions
 WHERE id = $1 LIMIT 1
@@ -93,7 +120,9 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 
 const getSessionByUserID = `-- name: GetSessionByUserID :one
 SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at FROM sessions
-WHERE user_id = $1 LIMIT 1
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1
 `
 
 func (q *Queries) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (Session, error) {
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+const tracerName = "github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres"
+
+// unnamedQuery is the operation name used for SQL that was not generated by
+// sqlc, such as transaction control statements.
+const unnamedQuery = "query"
+
+// sqlcNameRegex matches the `-- name: GetUserById :one` header that sqlc
+// prepends to every generated query.
+var sqlcNameRegex = regexp.MustCompile(`^\s*--\s*name:\s*(\w+)`)
+
+// queryTracer is a [pgx.QueryTracer] that wraps each query in an
+// OpenTelemetry span named after the sqlc statement, and logs it if a logger
+// is set. Query arguments are never logged.
+type queryTracer struct {
+	tracer trace.Tracer
+	logger logger.Logger
+}
+
+var _ pgx.QueryTracer = (*queryTracer)(nil)
+
+func newQueryTracer(log logger.Logger) *queryTracer {
+	return &queryTracer{tracer: otel.Tracer(tracerName), logger: log}
+}
+
+type queryStartKey struct{}
+
+// queryStart records a query for logging when it ends.
+type queryStart struct {
+	name string
+	at   time.Time
+}
+
+func (t *queryTracer) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryStartData,
+) context.Context {
+	name := queryName(data.SQL)
+	ctx, _ = t.tracer.Start(
+		ctx,
+		"postgres."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	if t.logger != nil {
+		ctx = context.WithValue(ctx, queryStartKey{}, queryStart{name: name, at: time.Now()})
+	}
+	return ctx
+}
+
+func (t *queryTracer) TraceQueryEnd(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryEndData,
+) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+
+	if t.logger != nil {
+		t.logQuery(ctx, data)
+	}
+}
+
+func (t *queryTracer) logQuery(ctx context.Context, data pgx.TraceQueryEndData) {
+	fields := map[string]interface{}{
+		"rows_affected": data.CommandTag.RowsAffected(),
+	}
+	if start, ok := ctx.Value(queryStartKey{}).(queryStart); ok {
+		fields["query"] = start.name
+		fields["duration_ms"] = time.Since(start.at).Milliseconds()
+	}
+	if data.Err != nil {
+		fields["error"] = data.Err.Error()
+	}
+
+	t.logger.WithContext(ctx).Debug(ctx, "postgres query", fields)
+}
+
+// queryName extracts the sqlc statement name from `sql`, or returns
+// [unnamedQuery] for hand-written SQL.
+func queryName(sql string) string {
+	if match := sqlcNameRegex.FindStringSubmatch(sql); match != nil {
+		return match[1]
+	}
+	return unnamedQuery
+}
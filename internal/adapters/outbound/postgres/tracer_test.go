@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+func Test_queryName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			name: "sqlc one query",
+			sql:  "-- name: GetUserById :one\nSELECT id FROM users WHERE id = $1",
+			want: "GetUserById",
+		},
+		{
+			name: "sqlc exec query with leading whitespace",
+			sql:  "\n  -- name: DeleteSession :exec\nDELETE FROM sessions WHERE id = $1",
+			want: "DeleteSession",
+		},
+		{
+			name: "hand-written query",
+			sql:  "begin",
+			want: unnamedQuery,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, queryName(tc.sql))
+		})
+	}
+}
+
+// debugLogger is a [logger.Logger] that keeps the fields of debug entries.
+type debugLogger struct {
+	logger.Logger
+	entries []map[string]interface{}
+}
+
+func (l *debugLogger) Debug(_ context.Context, _ string, fields map[string]interface{}) {
+	l.entries = append(l.entries, fields)
+}
+
+func (l *debugLogger) WithContext(context.Context) logger.Logger {
+	return l
+}
+
+func TestQueryTracer_Logging(t *testing.T) {
+	t.Parallel()
+
+	log := &debugLogger{}
+	tracer := newQueryTracer(log)
+	queryErr := errors.New("unique violation")
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL:  "-- name: CreateUser :one\nINSERT INTO users VALUES ($1)",
+		Args: []any{"secret"},
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: queryErr})
+
+	require.Len(t, log.entries, 1)
+	fields := log.entries[0]
+	assert.Equal(t, "CreateUser", fields["query"])
+	assert.Equal(t, queryErr.Error(), fields["error"])
+	assert.Contains(t, fields, "duration_ms")
+	assert.NotContains(t, fmt.Sprint(fields), "secret", "arguments are not logged")
+
+	silent := newQueryTracer(nil)
+	ctx = silent.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "begin"})
+	silent.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+)
+
+// SQLSTATEs of transactions aborted by Postgres that succeed when retried.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// retryPolicy bounds the retries of transactions aborted by serialization
+// failures or deadlocks. Retries wait a random duration of up to baseDelay,
+// doubling after each attempt but never exceeding maxDelay.
+type retryPolicy struct {
+	// maxAttempts is the total number of attempts, including the first.
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// defaultRetryPolicy is the retry policy of clients created with [New].
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: 5,
+	baseDelay:   10 * time.Millisecond,
+	maxDelay:    500 * time.Millisecond,
+}
+
+// txRetryPolicy returns the default retry policy with the attempts configured
+// in `opts`.
+func txRetryPolicy(opts Options) retryPolicy {
+	policy := defaultRetryPolicy
+	if opts.MaxTxAttempts > 0 {
+		policy.maxAttempts = opts.MaxTxAttempts
+	}
+	return policy
+}
+
+// delay returns the jittered wait before retry number `retry`, starting at 1.
+func (p retryPolicy) delay(retry int) time.Duration {
+	ceiling := p.baseDelay << (retry - 1)
+	if ceiling <= 0 || ceiling > p.maxDelay {
+		ceiling = p.maxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+// txBeginner starts transactions. It is satisfied by [pgxpool.Pool].
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error)
+}
+
+// InTx runs `fn` in a transaction at the given isolation level, committing if
+// `fn` returns nil. `fn` is called again in a new transaction if the
+// transaction is aborted by a serialization failure or deadlock, so it must
+// not have side effects outside the transaction. Waits between attempts are
+// cut short if `ctx` is done.
+func (c *Client) InTx(
+	ctx context.Context,
+	isoLevel pgx.TxIsoLevel,
+	fn func(q sqlc.Querier) error,
+) error {
+	return runInTx(ctx, c.db, pgx.TxOptions{IsoLevel: isoLevel}, c.retryPolicy, func(tx pgx.Tx) error {
+		return fn(sqlc.New(tx))
+	})
+}
+
+func runInTx(
+	ctx context.Context,
+	db txBeginner,
+	opts pgx.TxOptions,
+	policy retryPolicy,
+	fn func(tx pgx.Tx) error,
+) error {
+	attempts := max(policy.maxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := attemptTx(ctx, db, opts, fn)
+		if err == nil || !isRetryable(err) || attempt == attempts {
+			return err
+		}
+
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry transaction: %w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+func attemptTx(
+	ctx context.Context,
+	db txBeginner,
+	opts pgx.TxOptions,
+	fn func(tx pgx.Tx) error,
+) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// isRetryable reports whether `err` aborted a transaction that may succeed if
+// retried.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx is a [pgx.Tx] that records whether it was committed. Only Commit and
+// Rollback may be called.
+type fakeTx struct {
+	pgx.Tx
+	commitErr error
+	committed bool
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	if tx.commitErr != nil {
+		return tx.commitErr
+	}
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	return nil
+}
+
+// fakeBeginner hands out fakeTxs, failing the commit of the first
+// `commitErrs` of them.
+type fakeBeginner struct {
+	commitErrs []error
+	txs        []*fakeTx
+	opts       []pgx.TxOptions
+}
+
+func (b *fakeBeginner) BeginTx(_ context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	if len(b.txs) < len(b.commitErrs) {
+		tx.commitErr = b.commitErrs[len(b.txs)]
+	}
+	b.txs = append(b.txs, tx)
+	b.opts = append(b.opts, opts)
+	return tx, nil
+}
+
+func TestRunInTx(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: 2 * time.Millisecond}
+	serializationErr := &pgconn.PgError{Code: serializationFailure}
+	deadlockErr := &pgconn.PgError{Code: deadlockDetected}
+	uniqueErr := &pgconn.PgError{Code: "23505"}
+
+	testCases := []struct {
+		name         string
+		fnErrs       []error
+		commitErrs   []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			name:         "Commits on success",
+			wantAttempts: 1,
+		},
+		{
+			name:         "Retries a serialization failure on commit",
+			commitErrs:   []error{serializationErr},
+			wantAttempts: 2,
+		},
+		{
+			name:         "Retries a deadlock in the function",
+			fnErrs:       []error{deadlockErr, serializationErr},
+			wantAttempts: 3,
+		},
+		{
+			name:         "Does not retry other errors",
+			fnErrs:       []error{uniqueErr},
+			wantErr:      uniqueErr,
+			wantAttempts: 1,
+		},
+		{
+			name:         "Gives up after the last attempt",
+			fnErrs:       []error{deadlockErr, deadlockErr, deadlockErr, deadlockErr},
+			wantErr:      deadlockErr,
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &fakeBeginner{commitErrs: tc.commitErrs}
+			opts := pgx.TxOptions{IsoLevel: pgx.Serializable}
+			calls := 0
+
+			err := runInTx(context.Background(), db, opts, policy, func(pgx.Tx) error {
+				calls++
+				if calls <= len(tc.fnErrs) {
+					return tc.fnErrs[calls-1]
+				}
+				return nil
+			})
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, db.txs, tc.wantAttempts)
+			assert.Equal(t, tc.wantErr == nil, db.txs[len(db.txs)-1].committed)
+			for _, txOpts := range db.opts {
+				assert.Equal(t, opts, txOpts)
+			}
+		})
+	}
+}
+
+func TestRunInTx_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := retryPolicy{maxAttempts: 5, baseDelay: time.Hour, maxDelay: time.Hour}
+	deadlockErr := &pgconn.PgError{Code: deadlockDetected}
+
+	db := &fakeBeginner{}
+	err := runInTx(ctx, db, pgx.TxOptions{}, policy, func(pgx.Tx) error {
+		cancel()
+		return deadlockErr
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, deadlockErr)
+	assert.Len(t, db.txs, 1)
+}
+
+func TestTxRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, defaultRetryPolicy, txRetryPolicy(Options{}))
+
+	policy := txRetryPolicy(Options{MaxTxAttempts: 2})
+	assert.Equal(t, 2, policy.maxAttempts)
+	assert.Equal(t, defaultRetryPolicy.baseDelay, policy.baseDelay)
+	assert.Equal(t, defaultRetryPolicy.maxDelay, policy.maxDelay)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxAttempts: 10, baseDelay: 10 * time.Millisecond, maxDelay: 50 * time.Millisecond}
+	ceilings := []time.Duration{10, 20, 40, 50, 50}
+
+	for i, ceiling := range ceilings {
+		for j := 0; j < 100; j++ {
+			delay := policy.delay(i + 1)
+			assert.Positive(t, delay)
+			assert.LessOrEqual(t, delay, ceiling*time.Millisecond)
+		}
+	}
+
+	assert.Zero(t, retryPolicy{}.delay(1))
+	assert.False(t, isRetryable(errors.New("plain error")))
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/postgres/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.UnitOfWork = (*Client)(nil)
+
+// Do runs `fn` in a read committed transaction, which is retried as described
+// by [Client.InTx].
+func (c *Client) Do(ctx context.Context, fn func(repos outbound.Repositories) error) error {
+	return c.InTx(ctx, pgx.ReadCommitted, func(q sqlc.Querier) error {
+		return fn(txRepositories{q: q})
+	})
+}
+
+// txRepositories serves the repository ports from the queries of a single
+// transaction.
+type txRepositories struct {
+	q queries
+}
+
+func (r txRepositories) Users() outbound.UserRepository {
+	return txUserRepository(r)
+}
+
+func (r txRepositories) Sessions() outbound.SessionRepository {
+	return txSessionRepository(r)
+}
+
+func (r txRepositories) Outbox() outbound.OutboxRepository {
+	return txOutboxRepository(r)
+}
+
+func (r txRepositories) AuditLog() outbound.AuditRecorder {
+	return txAuditRecorder(r)
+}
+
+type txUserRepository struct {
+	q queries
+}
+
+func (r txUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	return getUserById(ctx, r.q, id)
+}
+
+func (r txUserRepository) GetUserByEmail(ctx context.Context, email user.EmailAddress) (*user.User, error) {
+	return getUserByEmail(ctx, r.q, email)
+}
+
+func (r txUserRepository) CreateUser(ctx context.Context, req *user.RegistrationRequest) (*user.User, error) {
+	return createUser(ctx, r.q, req)
+}
+
+func (r txUserRepository) UpdateUser(ctx context.Context, req *user.UpdateRequest) (*user.User, error) {
+	return updateUser(ctx, r.q, req)
+}
+
+type txSessionRepository struct {
+	q queries
+}
+
+func (r txSessionRepository) CreateSession(ctx context.Context, session *auth.Sessions) error {
+	return createSession(ctx, r.q, session)
+}
+
+func (r txSessionRepository) GetSession(ctx context.Context, id uuid.UUID) (*auth.Sessions, error) {
+	return getSession(ctx, r.q, id)
+}
+
+func (r txSessionRepository) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*auth.Sessions, error) {
+	return getSessionByUserID(ctx, r.q, userID)
+}
+
+func (r txSessionRepository) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	return deleteSession(ctx, r.q, id)
+}
+
+type txAuditRecorder struct {
+	q queries
+}
+
+func (r txAuditRecorder) Record(ctx context.Context, entry *audit.Entry) error {
+	return recordAuditEvent(ctx, r.q, entry)
+}
@@ -26,7 +26,7 @@ func (c *Client) GetUserByID(
 	ctx context.Context,
 	id uuid.UUID,
 ) (*user.User, error) {
-	return getUserById(ctx, c.queries, id)
+	return getUserById(ctx, c.reader(ctx), id)
 }
 
 func getUserById(
@@ -60,7 +60,7 @@ func (c *Client) GetUserByEmail(
 	ctx context.Context,
 	email user.EmailAddress,
 ) (*user.User, error) {
-	return getUserByEmail(ctx, c.queries, email)
+	return getUserByEmail(ctx, c.reader(ctx), email)
 }
 
 func getUserByEmail(
@@ -110,11 +110,7 @@ func createUser(
 		if errors.As(err, &pgErr) {
 			return nil, createUserErrToDomain(pgErr, req)
 		}
-		return nil, fmt.Errorf(
-			"create user record from request %#v: %w",
-			req,
-			err,
-		)
+		return nil, fmt.Errorf("create user record %q: %w", req.Username(), err)
 	}
 
 	return parseUser(
@@ -143,42 +139,51 @@ func createUserErrToDomain(
 	pgErr *pgconn.PgError,
 	req *user.RegistrationRequest,
 ) error {
-	switch pgErr.Code {
-	case "23505": // unique_violation
-		switch pgErr.ConstraintName {
-		case "users_username_key":
-			return user.NewDuplicateUsernameError(req.Username())
-		case "users_email_key":
-			return user.NewDuplicateEmailError(req.Email())
-		}
+	if err := uniqueViolationToDomain(pgErr, req.Username(), req.Email()); err != nil {
+		return err
 	}
 	return fmt.Errorf("unexpected database error: %w", pgErr)
 }
 
+// uniqueViolationToDomain returns the duplicate error corresponding to a
+// violated unique constraint or index of the users table, or nil if `pgErr`
+// is any other error. Both the column constraints and the indexes over them
+// are matched, since either may be reported.
+func uniqueViolationToDomain(
+	pgErr *pgconn.PgError,
+	username user.Username,
+	email user.EmailAddress,
+) error {
+	if pgErr.Code != "23505" { // unique_violation
+		return nil
+	}
+
+	switch {
+	case strings.Contains(pgErr.ConstraintName, "username"):
+		return user.NewDuplicateUsernameError(username)
+	case strings.Contains(pgErr.ConstraintName, "email"):
+		return user.NewDuplicateEmailError(email)
+	}
+	return nil
+}
+
 // UpdateUser updates the user record and returns the updated [user.User]
-// Returns [user.ValidationError] if database constriants are violated
+// Returns [user.ValidationError] if database constriants are violated and
+// [user.ConcurrentModificationError] if the request's ETag is stale
 func (c *Client) UpdateUser(
 	ctx context.Context,
 	req *user.UpdateRequest,
 ) (*user.User, error) {
-	tx, err := c.db.Begin(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("begin UpdateUser transaction: %w", err)
-	}
-
-	defer func() { _ = tx.Rollback(ctx) }()
-
-	queries := sqlc.New(tx)
-	usr, err := updateUser(ctx, queries, req)
+	var usr *user.User
+	err := c.InTx(ctx, pgx.ReadCommitted, func(q sqlc.Querier) error {
+		var err error
+		usr, err = updateUser(ctx, q, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		// TODO: Handle serialization failures
-		return nil, fmt.Errorf("commit UpdateUser transaction: %w", err)
-	}
-
 	return usr, nil
 }
 
@@ -208,11 +213,14 @@ func updateUser(
 		}
 
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			if strings.Contains(pgErr.ConstraintName, "email") {
-				return nil, user.NewDuplicateEmailError(
-					req.Email().UnwrapOrZero(),
-				)
+		if errors.As(err, &pgErr) {
+			domainErr := uniqueViolationToDomain(
+				pgErr,
+				req.Username().UnwrapOrZero(),
+				req.Email().UnwrapOrZero(),
+			)
+			if domainErr != nil {
+				return nil, domainErr
 			}
 		}
 
@@ -234,6 +242,9 @@ func updateUser(
 func parseUpdateUserParams(req *user.UpdateRequest) sqlc.UpdateUserParams {
 	params := sqlc.UpdateUserParams{
 		ID: req.UserID(),
+		// The update only applies to the version of the user identified by
+		// the request's ETag.
+		UpdatedAt: req.ETag().UpdatedAt(),
 	}
 
 	if req.Username().IsSome() {
@@ -286,6 +297,9 @@ func parseUser(
 		)
 	}
 
+	// timestamptz values are read in the local time zone. Times are returned in
+	// UTC so that ETags are independent of the server's time zone.
+	updatedAt = updatedAt.UTC()
 	eTag := etag.New(parsedID, updatedAt)
 
 	parsedEmail, err := user.ParseEmailAddress(email)
@@ -319,8 +333,8 @@ func parseUser(
 		parsedEmail,
 		parsedPasswordHash,
 		parsedRole,
-		createdAt,
-		passwordChangedAt,
+		createdAt.UTC(),
+		passwordChangedAt.UTC(),
 		updatedAt,
 	), nil
 }
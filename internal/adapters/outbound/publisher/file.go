@@ -0,0 +1,61 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// FilePublisher publishes events by appending them to a file as JSON lines.
+// Each event is synced to disk before Publish returns, so that events
+// reported as published survive a crash.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var _ outbound.EventPublisher = (*FilePublisher)(nil)
+
+// NewFilePublisher opens the file at `path` for appending, creating it if
+// necessary.
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open event file %q: %w", path, err)
+	}
+
+	return &FilePublisher{file: file}, nil
+}
+
+func (p *FilePublisher) Publish(_ context.Context, e event.Event) error {
+	line, err := json.Marshal(newRecord(e))
+	if err != nil {
+		return fmt.Errorf("encode event %s: %w", e.ID, err)
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.file.Write(line); err != nil {
+		return fmt.Errorf("write event %s: %w", e.ID, err)
+	}
+	if err := p.file.Sync(); err != nil {
+		return fmt.Errorf("sync event %s: %w", e.ID, err)
+	}
+
+	return nil
+}
+
+// Close closes the file.
+func (p *FilePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.file.Close()
+}
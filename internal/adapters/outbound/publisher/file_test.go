@@ -0,0 +1,61 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+)
+
+func TestFilePublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	events := []event.Event{randomEvent(t), randomEvent(t), randomEvent(t)}
+
+	// Reopening the file appends to it.
+	for _, batch := range [][]event.Event{events[:1], events[1:]} {
+		publisher, err := NewFilePublisher(path)
+		require.NoError(t, err)
+		for _, e := range batch {
+			require.NoError(t, publisher.Publish(context.Background(), e))
+		}
+		require.NoError(t, publisher.Close())
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var got []record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var r record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		got = append(got, r)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, got, len(events))
+	for i, e := range events {
+		assert.Equal(t, e.ID, got[i].ID)
+		assert.Equal(t, e.Type, got[i].Type)
+		assert.Equal(t, e.UserID, got[i].UserID)
+		assert.True(t, e.OccurredAt.Equal(got[i].OccurredAt))
+		assert.JSONEq(t, string(e.Payload), string(got[i].Payload))
+	}
+}
+
+func TestNewFilePublisher_ReturnsAnError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFilePublisher(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+	assert.Error(t, err)
+}
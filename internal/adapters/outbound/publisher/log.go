@@ -0,0 +1,33 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+// LogPublisher publishes events by logging them at the info level. It suits
+// development, and deployments with no consumers other than log pipelines.
+type LogPublisher struct {
+	logger logger.Logger
+}
+
+var _ outbound.EventPublisher = (*LogPublisher)(nil)
+
+func NewLogPublisher(log logger.Logger) *LogPublisher {
+	return &LogPublisher{logger: log}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, e event.Event) error {
+	r := newRecord(e)
+	p.logger.Info(ctx, "domain event", map[string]interface{}{
+		"event_id":    r.ID.String(),
+		"event_type":  string(r.Type),
+		"user_id":     r.UserID.String(),
+		"occurred_at": r.OccurredAt,
+		"payload":     r.Payload,
+	})
+	return nil
+}
@@ -0,0 +1,26 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	log := &infoLogger{}
+	e := randomEvent(t)
+
+	require.NoError(t, NewLogPublisher(log).Publish(context.Background(), e))
+
+	require.Len(t, log.fields, 1)
+	fields := log.fields[0]
+	assert.Equal(t, e.ID.String(), fields["event_id"])
+	assert.Equal(t, string(e.Type), fields["event_type"])
+	assert.Equal(t, e.UserID.String(), fields["user_id"])
+	assert.Equal(t, json.RawMessage(e.Payload), fields["payload"])
+}
@@ -0,0 +1,32 @@
+// Package publisher implements [outbound.EventPublisher] for consumers outside
+// the process.
+package publisher
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+)
+
+// record is the JSON encoding of an event written by the log and file
+// publishers.
+type record struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       event.Type      `json:"type"`
+	UserID     uuid.UUID       `json:"user_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func newRecord(e event.Event) record {
+	return record{
+		ID:         e.ID,
+		Type:       e.Type,
+		UserID:     e.UserID,
+		OccurredAt: e.OccurredAt,
+		Payload:    e.Payload,
+	}
+}
@@ -0,0 +1,48 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+func randomEvent(t *testing.T) event.Event {
+	t.Helper()
+
+	e, err := event.NewUserRegistered(user.RandomUser(t), time.Now())
+	require.NoError(t, err)
+	return e
+}
+
+// infoLogger is a [logger.Logger] that keeps the fields of info entries.
+type infoLogger struct {
+	mu     sync.Mutex
+	fields []map[string]interface{}
+}
+
+var _ logger.Logger = (*infoLogger)(nil)
+
+func (l *infoLogger) Info(_ context.Context, _ string, fields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fields = append(l.fields, fields)
+}
+
+func (l *infoLogger) Error(context.Context, error, string, map[string]interface{}) {}
+
+func (l *infoLogger) Debug(context.Context, string, map[string]interface{}) {}
+
+func (l *infoLogger) Warn(context.Context, string, map[string]interface{}) {}
+
+func (l *infoLogger) Fatal(context.Context, string, map[string]interface{}) {}
+
+func (l *infoLogger) WithContext(context.Context) logger.Logger {
+	return l
+}
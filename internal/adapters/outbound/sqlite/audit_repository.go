@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.AuditLogger = (*Client)(nil)
+
+func (c *Client) Record(ctx context.Context, entry *audit.Entry) error {
+	return c.inTx(ctx, func(q queries) error {
+		return recordAuditEvent(ctx, q, entry)
+	})
+}
+
+// recordAuditEvent appends `entry` in a transaction, which holds the
+// database's write lock from the moment it begins, so the last entry cannot
+// change between reading its hash and inserting the new entry.
+func recordAuditEvent(ctx context.Context, q queries, entry *audit.Entry) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event details: %w", err)
+	}
+
+	last, err := q.GetLastAuditEvent(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get last audit event: %w", err)
+	}
+
+	entry.Seal(last.Sequence, last.Hash)
+	err = q.AppendAuditEvent(ctx, sqlc.AppendAuditEventParams{
+		Sequence:   entry.Sequence,
+		ID:         entry.ID,
+		Action:     string(entry.Action),
+		ActorID:    entry.ActorID,
+		Target:     entry.Target,
+		ClientIp:   entry.ClientIP,
+		UserAgent:  entry.UserAgent,
+		RequestID:  entry.RequestID,
+		Details:    details,
+		OccurredAt: toTimestamp(entry.OccurredAt),
+		PrevHash:   entry.PrevHash,
+		Hash:       entry.Hash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append audit event %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+func (c *Client) Query(ctx context.Context, filter audit.Filter) ([]*audit.Entry, error) {
+	params := sqlc.QueryAuditEventsParams{
+		ActorID: sql.NullString{
+			String: filter.ActorID.UnwrapOrZero().String(),
+			Valid:  filter.ActorID.IsSome(),
+		},
+		Target: sql.NullString{
+			String: filter.Target.UnwrapOrZero(),
+			Valid:  filter.Target.IsSome(),
+		},
+		FromTime:       math.MinInt64,
+		ToTime:         math.MaxInt64,
+		AfterSequence:  filter.AfterSequence,
+		BeforeSequence: math.MaxInt64,
+		MaxEvents:      int64(filter.Limit),
+	}
+	if !filter.From.IsZero() {
+		params.FromTime = toTimestamp(filter.From)
+	}
+	if !filter.To.IsZero() {
+		params.ToTime = toTimestamp(filter.To)
+	}
+	if filter.BeforeSequence != 0 {
+		params.BeforeSequence = filter.BeforeSequence
+	}
+
+	var rows []sqlc.AuditEvent
+	var err error
+	if filter.NewestFirst {
+		rows, err = c.queries.QueryAuditEventsNewestFirst(ctx, sqlc.QueryAuditEventsNewestFirstParams(params))
+	} else {
+		rows, err = c.queries.QueryAuditEvents(ctx, params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+
+	entries := make([]*audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := parseAuditEvent(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseAuditEvent(row sqlc.AuditEvent) (*audit.Entry, error) {
+	var details map[string]string
+	if err := json.Unmarshal(row.Details, &details); err != nil {
+		return nil, fmt.Errorf("failed to decode details of audit event %s: %w", row.ID, err)
+	}
+
+	// SQLite reads the empty hash before the first entry back as NULL.
+	prevHash := row.PrevHash
+	if prevHash == nil {
+		prevHash = []byte{}
+	}
+
+	return &audit.Entry{
+		Sequence:   row.Sequence,
+		ID:         row.ID,
+		Action:     audit.Action(row.Action),
+		ActorID:    row.ActorID,
+		Target:     row.Target,
+		ClientIP:   row.ClientIp,
+		UserAgent:  row.UserAgent,
+		RequestID:  row.RequestID,
+		Details:    details,
+		OccurredAt: fromTimestamp(row.OccurredAt),
+		PrevHash:   prevHash,
+		Hash:       row.Hash,
+	}, nil
+}
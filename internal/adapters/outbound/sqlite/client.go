@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "modernc.org/sqlite"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite/sqlc"
+)
+
+const migrationsPath = "migrations"
+
+// busyTimeout is how long a connection waits for another connection's write
+// transaction before failing with SQLITE_BUSY.
+const busyTimeout = 5 * time.Second
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type queries interface {
+	sqlc.Querier
+}
+
+// Client is a SQLite client.
+type Client struct {
+	db      *sql.DB
+	queries queries
+}
+
+// New opens the SQLite database at `path`, creating it if necessary, and
+// applies all migrations.
+func New(ctx context.Context, path string) (*Client, error) {
+	if path == "" {
+		return nil, errors.New("sqlite database path is required")
+	}
+
+	db, err := sql.Open("sqlite", dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database at %q: %w", path, err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping sqlite database at %q: %w", path, err)
+	}
+
+	client := &Client{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+
+	if err := client.migrate(path); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// dsn returns the connection string for the database at `path`. Every
+// connection enforces foreign keys and waits for concurrent writers, and
+// transactions take the write lock when they begin, so that a transaction
+// never fails to upgrade a read lock held while another connection writes.
+func dsn(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds()))
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+
+	return "file:" + path + "?" + params.Encode()
+}
+
+// Close closes the database.
+func (c *Client) Close() error {
+	return c.db.Close()
+}
+
+// migrate runs all migrations.
+func (c *Client) migrate(path string) (err error) {
+	migrator, err := newMigrator(path)
+	if err != nil {
+		return fmt.Errorf("create migrator: %w", err)
+	}
+
+	defer func() {
+		if _, closeErr := migrator.Close(); closeErr != nil {
+			fmt.Printf("error closing migrator: %v\n", closeErr)
+		}
+	}()
+
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate db: %w", err)
+	}
+
+	return nil
+}
+
+// newMigrator returns a migrator with its own connection to the database at
+// `path`, since closing the migrator closes its connection.
+func newMigrator(path string) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations, migrationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("create io.FS migration source: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("open sql.DB connection: %w", err)
+	}
+
+	driver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create sqlite migration driver from db: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", driver)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"create migrator from io.FS source and sqlite driver: %w",
+			err,
+		)
+	}
+
+	return m, nil
+}
+
+// toTimestamp converts `t` to the stored representation of timestamps:
+// microseconds since the Unix epoch.
+func toTimestamp(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// fromTimestamp converts a stored timestamp to a UTC time.
+func fromTimestamp(micros int64) time.Time {
+	return time.UnixMicro(micros).UTC()
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/outboundtest"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	client, err := New(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates and migrates the database", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "test.db")
+		client, err := New(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+
+		// Reopening an up-to-date database is not an error.
+		client, err = New(context.Background(), path)
+		require.NoError(t, err)
+		assert.NoError(t, client.Close())
+	})
+
+	t.Run("returns an error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(context.Background(), "")
+		assert.Error(t, err)
+
+		_, err = New(context.Background(), filepath.Join(t.TempDir(), "missing", "test.db"))
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Contract(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+	outboundtest.Run(t, func(*testing.T) outboundtest.Repositories {
+		return client
+	})
+	outboundtest.RunUnitOfWork(t, func(*testing.T) outboundtest.TransactionalRepositories {
+		return client
+	})
+	outboundtest.RunOutbox(t, func(*testing.T) outboundtest.OutboxStore {
+		return client
+	})
+	outboundtest.RunOutboxCleaner(t, func(*testing.T) outboundtest.OutboxCleanupStore {
+		return client
+	})
+	outboundtest.RunAuditLogger(t, func(*testing.T) outboundtest.AuditStore {
+		return client
+	})
+	outboundtest.RunSessionCleaner(t, func(*testing.T) outboundtest.SessionStore {
+		return client
+	})
+}
@@ -0,0 +1,4 @@
+// Package sqlite provides an API to a SQLite database that aligns with the
+// domain outbound interfaces. It suits single-node deployments where running
+// Postgres is not warranted.
+package sqlite
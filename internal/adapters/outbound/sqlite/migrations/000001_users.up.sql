@@ -0,0 +1,30 @@
+-- Timestamps are stored as microseconds since the Unix epoch, the resolution
+-- of Postgres timestamps. Unset timestamps hold 0001-01-01 00:00:00Z, as in
+-- Postgres.
+CREATE TABLE users (
+  id UUID PRIMARY KEY NOT NULL,
+  username TEXT UNIQUE NOT NULL,
+  email TEXT UNIQUE NOT NULL,
+  password_hash TEXT NOT NULL,
+  role TEXT NOT NULL DEFAULT 'Reader',
+  created_at INTEGER NOT NULL,
+  password_changed_at INTEGER NOT NULL DEFAULT -62135596800000000,
+  updated_at INTEGER NOT NULL DEFAULT -62135596800000000
+);
+
+-- SQLite triggers cannot assign to NEW, so updated_at is set by a follow-up
+-- update, which does not fire the trigger again because it advances
+-- updated_at. The new value is always later than the old one, so that every
+-- update changes the user's ETag even within the resolution of the clock.
+CREATE TRIGGER update_users_updated_at
+AFTER UPDATE ON users
+FOR EACH ROW
+WHEN NEW.updated_at <= OLD.updated_at
+BEGIN
+  UPDATE users
+  SET updated_at = MAX(
+    CAST(unixepoch('subsec') * 1000000 AS INTEGER),
+    OLD.updated_at + 1
+  )
+  WHERE id = NEW.id;
+END;
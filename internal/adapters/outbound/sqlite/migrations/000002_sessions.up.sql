@@ -0,0 +1,12 @@
+CREATE TABLE sessions (
+  id UUID PRIMARY KEY NOT NULL,
+  user_id UUID NOT NULL REFERENCES users (id),
+  refresh_token TEXT NOT NULL,
+  user_agent TEXT NOT NULL,
+  client_ip TEXT NOT NULL,
+  is_blocked BOOLEAN NOT NULL DEFAULT false,
+  expires_at INTEGER NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
@@ -0,0 +1 @@
+-- The original case of emails is not retained, so there is nothing to restore.
@@ -0,0 +1,4 @@
+-- Emails are normalized to lower case by the domain, so that the unique
+-- constraint treats addresses differing only in case as duplicates. This fails
+-- if two users registered such addresses, which must be merged by hand first.
+UPDATE users SET email = lower(email) WHERE email <> lower(email);
@@ -0,0 +1,16 @@
+-- sent_at holds 0001-01-01 00:00:00Z until the message is sent.
+CREATE TABLE outbox (
+  id UUID PRIMARY KEY NOT NULL,
+  event_type TEXT NOT NULL,
+  user_id UUID NOT NULL,
+  payload BLOB NOT NULL,
+  occurred_at INTEGER NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  next_attempt_at INTEGER NOT NULL,
+  sent_at INTEGER NOT NULL DEFAULT -62135596800000000
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at, occurred_at)
+WHERE status = 'pending';
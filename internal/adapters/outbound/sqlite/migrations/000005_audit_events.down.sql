@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS audit_events_occurred_at_idx;
+
+DROP INDEX IF EXISTS audit_events_target_idx;
+
+DROP INDEX IF EXISTS audit_events_actor_idx;
+
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,22 @@
+-- actor_id is the nil UUID for anonymous actions. details holds a JSON object
+-- of strings.
+CREATE TABLE audit_events (
+  sequence INTEGER PRIMARY KEY NOT NULL,
+  id UUID UNIQUE NOT NULL,
+  action TEXT NOT NULL,
+  actor_id UUID NOT NULL,
+  target TEXT NOT NULL,
+  client_ip TEXT NOT NULL DEFAULT '',
+  user_agent TEXT NOT NULL DEFAULT '',
+  request_id TEXT NOT NULL DEFAULT '',
+  details BLOB NOT NULL,
+  occurred_at INTEGER NOT NULL,
+  prev_hash BLOB NOT NULL,
+  hash BLOB NOT NULL
+);
+
+CREATE INDEX audit_events_actor_idx ON audit_events (actor_id, occurred_at);
+
+CREATE INDEX audit_events_target_idx ON audit_events (target, occurred_at);
+
+CREATE INDEX audit_events_occurred_at_idx ON audit_events (occurred_at);
@@ -0,0 +1,2 @@
+-- Lets the session cleanup job find expired sessions without a full scan.
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS outbox_dead_occurred_at_idx;
+DROP INDEX IF EXISTS outbox_sent_at_idx;
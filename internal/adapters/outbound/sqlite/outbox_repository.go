@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// outboxLease is how long claimed messages are hidden from other relays. A
+// relay that stops while processing leaves its messages to be claimed again
+// once the lease expires.
+const outboxLease = time.Minute
+
+var _ outbound.OutboxRelayStore = (*Client)(nil)
+
+func appendOutbox(ctx context.Context, q queries, messages []*outbox.Message) error {
+	for _, msg := range messages {
+		err := q.AppendOutboxMessage(ctx, sqlc.AppendOutboxMessageParams{
+			ID:            msg.Event.ID,
+			EventType:     string(msg.Event.Type),
+			UserID:        msg.Event.UserID,
+			Payload:       msg.Event.Payload,
+			OccurredAt:    toTimestamp(msg.Event.OccurredAt),
+			Status:        string(msg.Status),
+			Attempts:      int64(msg.Attempts),
+			LastError:     msg.LastError,
+			NextAttemptAt: toTimestamp(msg.NextAttemptAt),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to append outbox message %s: %w", msg.Event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ProcessOutbox claims messages by postponing their next attempt by
+// [outboxLease], then calls `process` outside of any transaction. SQLite
+// transactions hold the database's write lock, which `process` must not keep
+// from the writes of in-process event handlers.
+func (c *Client) ProcessOutbox(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	process func(ctx context.Context, messages []*outbox.Message) error,
+) (int, error) {
+	messages, err := c.claimOutbox(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	// The claimed state is kept to release the messages if processing fails.
+	due := make(map[uuid.UUID]time.Time, len(messages))
+	for _, msg := range messages {
+		due[msg.Event.ID] = msg.NextAttemptAt
+	}
+
+	if processErr := process(ctx, messages); processErr != nil {
+		err := c.inTx(ctx, func(q queries) error {
+			for id, nextAttemptAt := range due {
+				err := q.RescheduleOutboxMessage(ctx, sqlc.RescheduleOutboxMessageParams{
+					NextAttemptAt: toTimestamp(nextAttemptAt),
+					ID:            id,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to release outbox message %s: %w", id, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return len(messages), fmt.Errorf("%w (release claimed messages: %w)", processErr, err)
+		}
+		return len(messages), processErr
+	}
+
+	err = c.inTx(ctx, func(q queries) error {
+		for _, msg := range messages {
+			if err := q.UpdateOutboxMessage(ctx, newUpdateOutboxMessageParams(msg)); err != nil {
+				return fmt.Errorf("failed to update outbox message %s: %w", msg.Event.ID, err)
+			}
+		}
+		return nil
+	})
+	return len(messages), err
+}
+
+func (c *Client) claimOutbox(ctx context.Context, now time.Time, limit int) ([]*outbox.Message, error) {
+	var messages []*outbox.Message
+	err := c.inTx(ctx, func(q queries) error {
+		rows, err := q.ListDueOutboxMessages(ctx, sqlc.ListDueOutboxMessagesParams{
+			Now:         toTimestamp(now),
+			MaxMessages: int64(limit),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list due outbox messages: %w", err)
+		}
+
+		messages = make([]*outbox.Message, 0, len(rows))
+		for _, row := range rows {
+			err := q.RescheduleOutboxMessage(ctx, sqlc.RescheduleOutboxMessageParams{
+				NextAttemptAt: toTimestamp(now.Add(outboxLease)),
+				ID:            row.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to claim outbox message %s: %w", row.ID, err)
+			}
+			messages = append(messages, parseOutboxMessage(row))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+var _ outbound.OutboxCleaner = (*Client)(nil)
+
+func (c *Client) DeleteFinishedOutboxMessages(
+	ctx context.Context,
+	sentBefore, deadBefore time.Time,
+	limit int,
+) (int64, error) {
+	deleted, err := c.queries.DeleteFinishedOutboxMessages(ctx, sqlc.DeleteFinishedOutboxMessagesParams{
+		SentBefore:  toTimestamp(sentBefore),
+		DeadBefore:  toTimestamp(deadBefore),
+		MaxMessages: int64(limit),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished outbox messages: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func newUpdateOutboxMessageParams(msg *outbox.Message) sqlc.UpdateOutboxMessageParams {
+	return sqlc.UpdateOutboxMessageParams{
+		Status:        string(msg.Status),
+		Attempts:      int64(msg.Attempts),
+		LastError:     msg.LastError,
+		NextAttemptAt: toTimestamp(msg.NextAttemptAt),
+		SentAt:        toTimestamp(msg.SentAt),
+		ID:            msg.Event.ID,
+	}
+}
+
+func parseOutboxMessage(row sqlc.Outbox) *outbox.Message {
+	return &outbox.Message{
+		Event: event.Event{
+			ID:         row.ID,
+			Type:       event.Type(row.EventType),
+			UserID:     row.UserID,
+			OccurredAt: fromTimestamp(row.OccurredAt),
+			Payload:    row.Payload,
+		},
+		Status:        outbox.Status(row.Status),
+		Attempts:      int(row.Attempts),
+		LastError:     row.LastError,
+		NextAttemptAt: fromTimestamp(row.NextAttemptAt),
+		SentAt:        fromTimestamp(row.SentAt),
+	}
+}
@@ -0,0 +1,45 @@
+-- name: GetLastAuditEvent :one
+SELECT sequence, hash FROM audit_events
+ORDER BY sequence DESC
+LIMIT 1;
+
+-- name: AppendAuditEvent :exec
+INSERT INTO audit_events (
+    sequence,
+    id,
+    action,
+    actor_id,
+    target,
+    client_ip,
+    user_agent,
+    request_id,
+    details,
+    occurred_at,
+    prev_hash,
+    hash
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: QueryAuditEvents :many
+SELECT * FROM audit_events
+WHERE (CAST(sqlc.narg(actor_id) AS TEXT) IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (CAST(sqlc.narg(target) AS TEXT) IS NULL OR target = sqlc.narg(target))
+  AND occurred_at >= sqlc.arg(from_time)
+  AND occurred_at < sqlc.arg(to_time)
+  AND sequence > sqlc.arg(after_sequence)
+  AND sequence < sqlc.arg(before_sequence)
+ORDER BY sequence
+LIMIT sqlc.arg(max_events);
+
+-- name: QueryAuditEventsNewestFirst :many
+-- Matches the filters of QueryAuditEvents, in descending order.
+SELECT * FROM audit_events
+WHERE (CAST(sqlc.narg(actor_id) AS TEXT) IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (CAST(sqlc.narg(target) AS TEXT) IS NULL OR target = sqlc.narg(target))
+  AND occurred_at >= sqlc.arg(from_time)
+  AND occurred_at < sqlc.arg(to_time)
+  AND sequence > sqlc.arg(after_sequence)
+  AND sequence < sqlc.arg(before_sequence)
+ORDER BY sequence DESC
+LIMIT sqlc.arg(max_events);
@@ -0,0 +1,46 @@
+-- name: AppendOutboxMessage :exec
+INSERT INTO outbox (
+    id,
+    event_type,
+    user_id,
+    payload,
+    occurred_at,
+    status,
+    attempts,
+    last_error,
+    next_attempt_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListDueOutboxMessages :many
+SELECT * FROM outbox
+WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(now)
+ORDER BY occurred_at
+LIMIT sqlc.arg(max_messages);
+
+-- name: RescheduleOutboxMessage :exec
+UPDATE outbox
+SET next_attempt_at = ?
+WHERE id = ?;
+
+-- name: UpdateOutboxMessage :exec
+UPDATE outbox
+SET
+    status = ?,
+    attempts = ?,
+    last_error = ?,
+    next_attempt_at = ?,
+    sent_at = ?
+WHERE id = ?;
+
+-- name: DeleteFinishedOutboxMessages :execrows
+-- Deletes a batch of messages that were sent, or died and occurred, before the
+-- given cutoffs.
+DELETE FROM outbox
+WHERE id IN (
+    SELECT finished.id FROM outbox AS finished
+    WHERE (finished.status = 'sent' AND finished.sent_at < sqlc.arg(sent_before))
+       OR (finished.status = 'dead' AND finished.occurred_at < sqlc.arg(dead_before))
+    LIMIT sqlc.arg(max_messages)
+);
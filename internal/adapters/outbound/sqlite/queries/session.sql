@@ -0,0 +1,38 @@
+-- name: CreateSession :one
+INSERT INTO sessions (
+    id,
+    user_id,
+    refresh_token,
+    user_agent,
+    client_ip,
+    is_blocked,
+    expires_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+) RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE id = ? LIMIT 1;
+
+-- name: GetSessionByUserID :one
+SELECT * FROM sessions
+WHERE user_id = ?
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE id = ?;
+
+-- name: DeleteStaleSessions :execrows
+-- Deletes a batch of sessions that expired, or were blocked and created,
+-- before the given cutoffs.
+DELETE FROM sessions
+WHERE id IN (
+    SELECT stale.id FROM sessions AS stale
+    WHERE stale.expires_at < sqlc.arg(expired_before)
+       OR (stale.is_blocked AND stale.created_at < sqlc.arg(blocked_before))
+    LIMIT sqlc.arg(max_sessions)
+);
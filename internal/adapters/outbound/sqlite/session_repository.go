@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+var _ outbound.SessionRepository = (*Client)(nil)
+
+func (c *Client) CreateSession(ctx context.Context, session *auth.Sessions) error {
+	return createSession(ctx, c.queries, session)
+}
+
+func createSession(ctx context.Context, q queries, session *auth.Sessions) error {
+	arg := sqlc.CreateSessionParams{
+		ID:           session.ID,
+		UserID:       session.UserID,
+		RefreshToken: session.RefreshToken,
+		UserAgent:    session.UserAgent,
+		ClientIp:     session.ClientIP.String(),
+		IsBlocked:    session.IsBlocked,
+		ExpiresAt:    toTimestamp(session.ExpiresAt),
+		CreatedAt:    toTimestamp(time.Now()),
+	}
+
+	_, err := q.CreateSession(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) GetSession(ctx context.Context, id uuid.UUID) (*auth.Sessions, error) {
+	return getSession(ctx, c.queries, id)
+}
+
+func getSession(ctx context.Context, q queries, id uuid.UUID) (*auth.Sessions, error) {
+	session, err := q.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get session: %w", auth.ErrSessionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return parseSession(session), nil
+}
+
+func (c *Client) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*auth.Sessions, error) {
+	return getSessionByUserID(ctx, c.queries, userID)
+}
+
+func getSessionByUserID(ctx context.Context, q queries, userID uuid.UUID) (*auth.Sessions, error) {
+	session, err := q.GetSessionByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get session by user ID: %w", auth.ErrSessionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get session by user ID: %w", err)
+	}
+
+	return parseSession(session), nil
+}
+
+func (c *Client) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	return deleteSession(ctx, c.queries, id)
+}
+
+func deleteSession(ctx context.Context, q queries, id uuid.UUID) error {
+	err := q.DeleteSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+var _ outbound.SessionCleaner = (*Client)(nil)
+
+func (c *Client) DeleteStaleSessions(
+	ctx context.Context,
+	expiredBefore, blockedBefore time.Time,
+	limit int,
+) (int64, error) {
+	deleted, err := c.queries.DeleteStaleSessions(ctx, sqlc.DeleteStaleSessionsParams{
+		ExpiredBefore: toTimestamp(expiredBefore),
+		BlockedBefore: toTimestamp(blockedBefore),
+		MaxSessions:   int64(limit),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale sessions: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// parseSession converts a stored session. Sessions written before client IPs
+// were normalized may hold a raw forwarding header instead of an address; they
+// are read with an unknown client rather than failing the lookup.
+func parseSession(session sqlc.Session) *auth.Sessions {
+	clientIP, err := auth.ParseClientIP(session.ClientIp)
+	if err != nil {
+		clientIP = auth.ClientIP{}
+	}
+
+	return &auth.Sessions{
+		ID:           session.ID,
+		UserID:       session.UserID,
+		RefreshToken: session.RefreshToken,
+		UserAgent:    session.UserAgent,
+		ClientIP:     clientIP,
+		IsBlocked:    session.IsBlocked,
+		ExpiresAt:    fromTimestamp(session.ExpiresAt),
+		CreatedAt:    fromTimestamp(session.CreatedAt),
+	}
+}
@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite/sqlc"
+)
+
+func TestParseSession(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		clientIP string
+		want     string
+	}{
+		{name: "Normalized address", clientIP: "203.0.113.5", want: "203.0.113.5"},
+		{name: "Legacy address with port", clientIP: "203.0.113.5:443", want: "203.0.113.5"},
+		{name: "Legacy forwarding header", clientIP: "203.0.113.5, 10.0.0.1", want: ""},
+		{name: "Unknown client", clientIP: "", want: ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := parseSession(sqlc.Session{ClientIp: tc.clientIP, ExpiresAt: toTimestamp(time.Now())})
+			assert.Equal(t, tc.want, session.ClientIP.String())
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: audit.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const appendAuditEvent = `-- name: AppendAuditEvent :exec
+INSERT INTO audit_events (
+    sequence,
+    id,
+    action,
+    actor_id,
+    target,
+    client_ip,
+    user_agent,
+    request_id,
+    details,
+    occurred_at,
+    prev_hash,
+    hash
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type AppendAuditEventParams struct {
+	Sequence   int64     `json:"sequence"`
+	ID         uuid.UUID `json:"id"`
+	Action     string    `json:"action"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	Target     string    `json:"target"`
+	ClientIp   string    `json:"client_ip"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	Details    []byte    `json:"details"`
+	OccurredAt int64     `json:"occurred_at"`
+	PrevHash   []byte    `json:"prev_hash"`
+	Hash       []byte    `json:"hash"`
+}
+
+func (q *Queries) AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error {
+	_, err := q.db.ExecContext(ctx, appendAuditEvent,
+		arg.Sequence,
+		arg.ID,
+		arg.Action,
+		arg.ActorID,
+		arg.Target,
+		arg.ClientIp,
+		arg.UserAgent,
+		arg.RequestID,
+		arg.Details,
+		arg.OccurredAt,
+		arg.PrevHash,
+		arg.Hash,
+	)
+	return err
+}
+
+const getLastAuditEvent = `-- name: GetLastAuditEvent :one
+SELECT sequence, hash FROM audit_events
+ORDER BY sequence DESC
+LIMIT 1
+`
+
+type GetLastAuditEventRow struct {
+	Sequence int64  `json:"sequence"`
+	Hash     []byte `json:"hash"`
+}
+
+func (q *Queries) GetLastAuditEvent(ctx context.Context) (GetLastAuditEventRow, error) {
+	row := q.db.QueryRowContext(ctx, getLastAuditEvent)
+	var i GetLastAuditEventRow
+	err := row.Scan(&i.Sequence, &i.Hash)
+	return i, err
+}
+
+const queryAuditEvents = `-- name: QueryAuditEvents :many
+SELECT sequence, id, "action", actor_id, target, client_ip, user_agent, request_id, details, occurred_at, prev_hash, hash FROM audit_events
+WHERE (CAST(?1 AS TEXT) IS NULL OR actor_id = ?1)
+  AND (CAST(?2 AS TEXT) IS NULL OR target = ?2)
+  AND occurred_at >= ?3
+  AND occurred_at < ?4
+  AND sequence > ?5
+  AND sequence < ?6
+ORDER BY sequence
+LIMIT ?7
+`
+
+type QueryAuditEventsParams struct {
+	ActorID        sql.NullString `json:"actor_id"`
+	Target         sql.NullString `json:"target"`
+	FromTime       int64          `json:"from_time"`
+	ToTime         int64          `json:"to_time"`
+	AfterSequence  int64          `json:"after_sequence"`
+	BeforeSequence int64          `json:"before_sequence"`
+	MaxEvents      int64          `json:"max_events"`
+}
+
+func (q *Queries) QueryAuditEvents(ctx context.Context, arg QueryAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, queryAuditEvents,
+		arg.ActorID,
+		arg.Target,
+		arg.FromTime,
+		arg.ToTime,
+		arg.AfterSequence,
+		arg.BeforeSequence,
+		arg.MaxEvents,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.Sequence,
+			&i.ID,
+			&i.Action,
+			&i.ActorID,
+			&i.Target,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Details,
+			&i.OccurredAt,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const queryAuditEventsNewestFirst = `-- name: QueryAuditEventsNewestFirst :many
+SELECT sequence, id, "action", actor_id, target, client_ip, user_agent, request_id, details, occurred_at, prev_hash, hash FROM audit_events
+WHERE (CAST(?1 AS TEXT) IS NULL OR actor_id = ?1)
+  AND (CAST(?2 AS TEXT) IS NULL OR target = ?2)
+  AND occurred_at >= ?3
+  AND occurred_at < ?4
+  AND sequence > ?5
+  AND sequence < ?6
+ORDER BY sequence DESC
+LIMIT ?7
+`
+
+type QueryAuditEventsNewestFirstParams struct {
+	ActorID        sql.NullString `json:"actor_id"`
+	Target         sql.NullString `json:"target"`
+	FromTime       int64          `json:"from_time"`
+	ToTime         int64          `json:"to_time"`
+	AfterSequence  int64          `json:"after_sequence"`
+	BeforeSequence int64          `json:"before_sequence"`
+	MaxEvents      int64          `json:"max_events"`
+}
+
+// Matches the filters of QueryAuditEvents, in descending order.
+func (q *Queries) QueryAuditEventsNewestFirst(ctx context.Context, arg QueryAuditEventsNewestFirstParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, queryAuditEventsNewestFirst,
+		arg.ActorID,
+		arg.Target,
+		arg.FromTime,
+		arg.ToTime,
+		arg.AfterSequence,
+		arg.BeforeSequence,
+		arg.MaxEvents,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.Sequence,
+			&i.ID,
+			&i.Action,
+			&i.ActorID,
+			&i.Target,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Details,
+			&i.OccurredAt,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,59 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+
+package sqlc
+
+import (
+	"github.com/google/uuid"
+)
+
+type AuditEvent struct {
+	Sequence   int64     `json:"sequence"`
+	ID         uuid.UUID `json:"id"`
+	Action     string    `json:"action"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	Target     string    `json:"target"`
+	ClientIp   string    `json:"client_ip"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	Details    []byte    `json:"details"`
+	OccurredAt int64     `json:"occurred_at"`
+	PrevHash   []byte    `json:"prev_hash"`
+	Hash       []byte    `json:"hash"`
+}
+
+type Outbox struct {
+	ID            uuid.UUID `json:"id"`
+	EventType     string    `json:"event_type"`
+	UserID        uuid.UUID `json:"user_id"`
+	Payload       []byte    `json:"payload"`
+	OccurredAt    int64     `json:"occurred_at"`
+	Status        string    `json:"status"`
+	Attempts      int64     `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt int64     `json:"next_attempt_at"`
+	SentAt        int64     `json:"sent_at"`
+}
+
+type Session struct {
+	ID           uuid.UUID `json:"id"`
+	UserID       uuid.UUID `json:"user_id"`
+	RefreshToken string    `json:"refresh_token"`
+	UserAgent    string    `json:"user_agent"`
+	ClientIp     string    `json:"client_ip"`
+	IsBlocked    bool      `json:"is_blocked"`
+	ExpiresAt    int64     `json:"expires_at"`
+	CreatedAt    int64     `json:"created_at"`
+}
+
+type User struct {
+	ID                uuid.UUID `json:"id"`
+	Username          string    `json:"username"`
+	Email             string    `json:"email"`
+	PasswordHash      string    `json:"password_hash"`
+	Role              string    `json:"role"`
+	CreatedAt         int64     `json:"created_at"`
+	PasswordChangedAt int64     `json:"password_changed_at"`
+	UpdatedAt         int64     `json:"updated_at"`
+}
@@ -0,0 +1,175 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: outbox.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const appendOutboxMessage = `-- name: AppendOutboxMessage :exec
+INSERT INTO outbox (
+    id,
+    event_type,
+    user_id,
+    payload,
+    occurred_at,
+    status,
+    attempts,
+    last_error,
+    next_attempt_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type AppendOutboxMessageParams struct {
+	ID            uuid.UUID `json:"id"`
+	EventType     string    `json:"event_type"`
+	UserID        uuid.UUID `json:"user_id"`
+	Payload       []byte    `json:"payload"`
+	OccurredAt    int64     `json:"occurred_at"`
+	Status        string    `json:"status"`
+	Attempts      int64     `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt int64     `json:"next_attempt_at"`
+}
+
+func (q *Queries) AppendOutboxMessage(ctx context.Context, arg AppendOutboxMessageParams) error {
+	_, err := q.db.ExecContext(ctx, appendOutboxMessage,
+		arg.ID,
+		arg.EventType,
+		arg.UserID,
+		arg.Payload,
+		arg.OccurredAt,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+	)
+	return err
+}
+
+const deleteFinishedOutboxMessages = `-- name: DeleteFinishedOutboxMessages :execrows
+DELETE FROM outbox
+WHERE id IN (
+    SELECT finished.id FROM outbox AS finished
+    WHERE (finished.status = 'sent' AND finished.sent_at < ?1)
+       OR (finished.status = 'dead' AND finished.occurred_at < ?2)
+    LIMIT ?3
+)
+`
+
+type DeleteFinishedOutboxMessagesParams struct {
+	SentBefore  int64 `json:"sent_before"`
+	DeadBefore  int64 `json:"dead_before"`
+	MaxMessages int64 `json:"max_messages"`
+}
+
+// Deletes a batch of messages that were sent, or died and occurred, before the
+// given cutoffs.
+func (q *Queries) DeleteFinishedOutboxMessages(ctx context.Context, arg DeleteFinishedOutboxMessagesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFinishedOutboxMessages, arg.SentBefore, arg.DeadBefore, arg.MaxMessages)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listDueOutboxMessages = `-- name: ListDueOutboxMessages :many
+SELECT id, event_type, user_id, payload, occurred_at, status, attempts, last_error, next_attempt_at, sent_at FROM outbox
+WHERE status = 'pending' AND next_attempt_at <= ?1
+ORDER BY occurred_at
+LIMIT ?2
+`
+
+type ListDueOutboxMessagesParams struct {
+	Now         int64 `json:"now"`
+	MaxMessages int64 `json:"max_messages"`
+}
+
+func (q *Queries) ListDueOutboxMessages(ctx context.Context, arg ListDueOutboxMessagesParams) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, listDueOutboxMessages, arg.Now, arg.MaxMessages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.UserID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rescheduleOutboxMessage = `-- name: RescheduleOutboxMessage :exec
+UPDATE outbox
+SET next_attempt_at = ?
+WHERE id = ?
+`
+
+type RescheduleOutboxMessageParams struct {
+	NextAttemptAt int64     `json:"next_attempt_at"`
+	ID            uuid.UUID `json:"id"`
+}
+
+func (q *Queries) RescheduleOutboxMessage(ctx context.Context, arg RescheduleOutboxMessageParams) error {
+	_, err := q.db.ExecContext(ctx, rescheduleOutboxMessage, arg.NextAttemptAt, arg.ID)
+	return err
+}
+
+const updateOutboxMessage = `-- name: UpdateOutboxMessage :exec
+UPDATE outbox
+SET
+    status = ?,
+    attempts = ?,
+    last_error = ?,
+    next_attempt_at = ?,
+    sent_at = ?
+WHERE id = ?
+`
+
+type UpdateOutboxMessageParams struct {
+	Status        string    `json:"status"`
+	Attempts      int64     `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt int64     `json:"next_attempt_at"`
+	SentAt        int64     `json:"sent_at"`
+	ID            uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateOutboxMessage(ctx context.Context, arg UpdateOutboxMessageParams) error {
+	_, err := q.db.ExecContext(ctx, updateOutboxMessage,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.SentAt,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Querier interface {
+	AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error
+	AppendOutboxMessage(ctx context.Context, arg AppendOutboxMessageParams) error
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Deletes a batch of messages that were sent, or died and occurred, before the
+	// given cutoffs.
+	DeleteFinishedOutboxMessages(ctx context.Context, arg DeleteFinishedOutboxMessagesParams) (int64, error)
+	DeleteSession(ctx context.Context, id uuid.UUID) error
+	// Deletes a batch of sessions that expired, or were blocked and created,
+	// before the given cutoffs.
+	DeleteStaleSessions(ctx context.Context, arg DeleteStaleSessionsParams) (int64, error)
+	GetLastAuditEvent(ctx context.Context) (GetLastAuditEventRow, error)
+	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSessionByUserID(ctx context.Context, userID uuid.UUID) (Session, error)
+	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
+	GetUserById(ctx context.Context, id uuid.UUID) (GetUserByIdRow, error)
+	ListDueOutboxMessages(ctx context.Context, arg ListDueOutboxMessagesParams) ([]Outbox, error)
+	QueryAuditEvents(ctx context.Context, arg QueryAuditEventsParams) ([]AuditEvent, error)
+	// Matches the filters of QueryAuditEvents, in descending order.
+	QueryAuditEventsNewestFirst(ctx context.Context, arg QueryAuditEventsNewestFirstParams) ([]AuditEvent, error)
+	RescheduleOutboxMessage(ctx context.Context, arg RescheduleOutboxMessageParams) error
+	UpdateOutboxMessage(ctx context.Context, arg UpdateOutboxMessageParams) error
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error)
+	UserExists(ctx context.Context, id uuid.UUID) (int64, error)
+}
+
+var _ Querier = (*Queries)(nil)
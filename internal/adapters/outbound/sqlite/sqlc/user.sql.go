@@ -0,0 +1,179 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: user.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (
+    id,
+    username,
+    email,
+    password_hash,
+    created_at
+)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id, username, email, password_hash, role, created_at, password_changed_at, updated_at
+`
+
+type CreateUserParams struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"password_hash"`
+	CreatedAt    int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUser,
+		arg.ID,
+		arg.Username,
+		arg.Email,
+		arg.PasswordHash,
+		arg.CreatedAt,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.CreatedAt,
+		&i.PasswordChangedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, username, password_hash, password_changed_at, role, created_at, updated_at
+FROM users
+WHERE email = ? LIMIT 1
+`
+
+type GetUserByEmailRow struct {
+	ID                uuid.UUID `json:"id"`
+	Email             string    `json:"email"`
+	Username          string    `json:"username"`
+	PasswordHash      string    `json:"password_hash"`
+	PasswordChangedAt int64     `json:"password_changed_at"`
+	Role              string    `json:"role"`
+	CreatedAt         int64     `json:"created_at"`
+	UpdatedAt         int64     `json:"updated_at"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i GetUserByEmailRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.PasswordHash,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserById = `-- name: GetUserById :one
+SELECT id, email, username, password_hash, password_changed_at, role, created_at, updated_at
+FROM users
+WHERE id = ? LIMIT 1
+`
+
+type GetUserByIdRow struct {
+	ID                uuid.UUID `json:"id"`
+	Email             string    `json:"email"`
+	Username          string    `json:"username"`
+	PasswordHash      string    `json:"password_hash"`
+	PasswordChangedAt int64     `json:"password_changed_at"`
+	Role              string    `json:"role"`
+	CreatedAt         int64     `json:"created_at"`
+	UpdatedAt         int64     `json:"updated_at"`
+}
+
+func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (GetUserByIdRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserById, id)
+	var i GetUserByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.PasswordHash,
+		&i.PasswordChangedAt,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUser = `-- name: UpdateUser :execrows
+UPDATE users
+SET
+    username = COALESCE(?1, username),
+    email = COALESCE(?2, email),
+    password_hash = COALESCE(?3, password_hash),
+    role = COALESCE(?4, role),
+    password_changed_at = CASE
+        WHEN ?3 IS NOT NULL THEN ?5
+        ELSE password_changed_at
+    END
+WHERE
+    id = ?6
+    AND
+    updated_at = ?7
+`
+
+type UpdateUserParams struct {
+	Username     sql.NullString `json:"username"`
+	Email        sql.NullString `json:"email"`
+	PasswordHash sql.NullString `json:"password_hash"`
+	Role         sql.NullString `json:"role"`
+	Now          int64          `json:"now"`
+	ID           uuid.UUID      `json:"id"`
+	UpdatedAt    int64          `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUser,
+		arg.Username,
+		arg.Email,
+		arg.PasswordHash,
+		arg.Role,
+		arg.Now,
+		arg.ID,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const userExists = `-- name: UserExists :one
+SELECT EXISTS(
+    SELECT 1
+    FROM users
+    WHERE id = ?
+)
+`
+
+func (q *Queries) UserExists(ctx context.Context, id uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, userExists, id)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
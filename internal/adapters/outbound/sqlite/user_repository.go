@@ -0,0 +1,295 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/adapters/outbound/sqlite/sqlc"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+)
+
+var _ outbound.UserRepository = (*Client)(nil)
+
+// GetUserByID returns the [user.User] with the given ID or
+// [user.NotFoundError] if no such user is found
+func (c *Client) GetUserByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*user.User, error) {
+	return getUserByID(ctx, c.queries, id)
+}
+
+func getUserByID(
+	ctx context.Context,
+	q queries,
+	id uuid.UUID,
+) (*user.User, error) {
+	row, err := q.GetUserById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.NewNotFoundByIDError(id)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return parseUser(
+		row.ID,
+		row.Username,
+		row.Email,
+		row.PasswordHash,
+		row.Role,
+		row.PasswordChangedAt,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
+
+// GetUserByEmail returns the [user.User] with the given email, or
+// [user.NotFoundError] if no user exists with email
+func (c *Client) GetUserByEmail(
+	ctx context.Context,
+	email user.EmailAddress,
+) (*user.User, error) {
+	return getUserByEmail(ctx, c.queries, email)
+}
+
+func getUserByEmail(
+	ctx context.Context,
+	q queries,
+	email user.EmailAddress,
+) (*user.User, error) {
+	row, err := q.GetUserByEmail(ctx, email.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.NewNotFoundByEmailError(email)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return parseUser(
+		row.ID,
+		row.Username,
+		row.Email,
+		row.PasswordHash,
+		row.Role,
+		row.PasswordChangedAt,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
+
+// CreateUser creates a new user record from the given
+// [user.RegistrationRequest] and returns the created [user.User].
+//
+// Returns [user.ValidationError] if database constraints are violated.
+func (c *Client) CreateUser(
+	ctx context.Context,
+	req *user.RegistrationRequest,
+) (*user.User, error) {
+	return createUser(ctx, c.queries, req)
+}
+
+func createUser(
+	ctx context.Context,
+	q queries,
+	req *user.RegistrationRequest,
+) (*user.User, error) {
+	row, err := q.CreateUser(ctx, sqlc.CreateUserParams{
+		ID:           uuid.New(),
+		Username:     req.Username().String(),
+		Email:        req.Email().String(),
+		PasswordHash: string(req.PasswordHash().Bytes()),
+		CreatedAt:    toTimestamp(time.Now()),
+	})
+	if err != nil {
+		if domainErr := uniqueViolationToDomain(err, req.Username(), req.Email()); domainErr != nil {
+			return nil, domainErr
+		}
+		return nil, fmt.Errorf("create user record %q: %w", req.Username(), err)
+	}
+
+	return parseUser(
+		row.ID,
+		row.Username,
+		row.Email,
+		row.PasswordHash,
+		row.Role,
+		row.PasswordChangedAt,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
+
+// uniqueViolationToDomain returns the duplicate error corresponding to a
+// violated unique constraint of the users table, or nil if `err` is any other
+// error. The errors match those of the Postgres adapter.
+func uniqueViolationToDomain(
+	err error,
+	username user.Username,
+	email user.EmailAddress,
+) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code() != sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(sqliteErr.Error(), "users.username"):
+		return user.NewDuplicateUsernameError(username)
+	case strings.Contains(sqliteErr.Error(), "users.email"):
+		return user.NewDuplicateEmailError(email)
+	}
+	return nil
+}
+
+// UpdateUser updates the user record and returns the updated [user.User]
+// Returns [user.ValidationError] if database constriants are violated and
+// [user.ConcurrentModificationError] if the request's ETag is stale
+func (c *Client) UpdateUser(
+	ctx context.Context,
+	req *user.UpdateRequest,
+) (*user.User, error) {
+	var usr *user.User
+	err := c.inTx(ctx, func(q queries) error {
+		var err error
+		usr, err = updateUser(ctx, q, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usr, nil
+}
+
+func updateUser(
+	ctx context.Context,
+	q queries,
+	req *user.UpdateRequest,
+) (*user.User, error) {
+	exists, err := q.UserExists(ctx, req.UserID())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"query existence of user with ID %q: %w",
+			req.UserID().String(), err)
+	}
+	if exists == 0 {
+		return nil, user.NewNotFoundByIDError(req.UserID())
+	}
+
+	updated, err := q.UpdateUser(ctx, parseUpdateUserParams(req))
+	if err != nil {
+		domainErr := uniqueViolationToDomain(
+			err,
+			req.Username().UnwrapOrZero(),
+			req.Email().UnwrapOrZero(),
+		)
+		if domainErr != nil {
+			return nil, domainErr
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if updated == 0 {
+		return nil, &user.ConcurrentModificationError{
+			ID:   req.UserID(),
+			ETag: req.ETag(),
+		}
+	}
+
+	// The updated_at trigger runs after the update, so the row is read back
+	// rather than returned by the update.
+	return getUserByID(ctx, q, req.UserID())
+}
+
+func parseUpdateUserParams(req *user.UpdateRequest) sqlc.UpdateUserParams {
+	params := sqlc.UpdateUserParams{
+		ID:        req.UserID(),
+		UpdatedAt: toTimestamp(req.ETag().UpdatedAt()),
+		Now:       toTimestamp(time.Now()),
+	}
+
+	if req.Username().IsSome() {
+		params.Username = sql.NullString{
+			String: req.Username().UnwrapOrZero().String(),
+			Valid:  true,
+		}
+	}
+
+	if req.Email().IsSome() {
+		params.Email = sql.NullString{
+			String: req.Email().UnwrapOrZero().String(),
+			Valid:  true,
+		}
+	}
+
+	if req.PasswordHash().IsSome() {
+		params.PasswordHash = sql.NullString{
+			String: string(req.PasswordHash().UnwrapOrZero().Bytes()),
+			Valid:  true,
+		}
+	}
+
+	if req.Role().IsSome() {
+		params.Role = sql.NullString{
+			String: req.Role().UnwrapOrZero().String(),
+			Valid:  true,
+		}
+	}
+
+	return params
+}
+
+func parseUser(
+	id uuid.UUID,
+	username string,
+	email string,
+	passwordHash string,
+	role string,
+	passwordChangedAt int64,
+	createdAt int64,
+	updatedAt int64,
+) (*user.User, error) {
+	parsedUpdatedAt := fromTimestamp(updatedAt)
+
+	parsedEmail, err := user.ParseEmailAddress(email)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedUsername, err := user.ParseUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	roleInt, err := user.RoleStringToInt(role)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role from database: %w", err)
+	}
+
+	parsedRole, err := user.ParseRole(roleInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.NewUser(
+		id,
+		etag.New(id, parsedUpdatedAt),
+		parsedUsername,
+		parsedEmail,
+		user.NewPasswordHashFromTrustedSource([]byte(passwordHash)),
+		parsedRole,
+		fromTimestamp(createdAt),
+		fromTimestamp(passwordChangedAt),
+		parsedUpdatedAt,
+	), nil
+}
@@ -0,0 +1,144 @@
+// Package audit records security-relevant actions in the audit log and serves
+// it to admins.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
+)
+
+var tracer = otel.Tracer("github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit")
+
+const (
+	// DefaultQueryLimit is the number of entries returned by queries without
+	// a limit, and MaxQueryLimit the most returned by any query.
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+type AuditService struct {
+	auditLog outbound.AuditLogger
+	metrics  outbound.Metrics
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+func NewAuditService(auditLog outbound.AuditLogger, metrics outbound.Metrics, log logger.Logger) *AuditService {
+	return &AuditService{auditLog, metrics, log, time.Now}
+}
+
+// RecordIn records that the source of `ctx` performed `action` on `target` in
+// the unit of work of `repos`, so that the entry is committed together with
+// the change it describes. An error fails the unit of work.
+func (s *AuditService) RecordIn(
+	ctx context.Context,
+	repos outbound.Repositories,
+	action domainAudit.Action,
+	target string,
+	details map[string]string,
+) error {
+	entry := s.newEntry(ctx, action, target, details)
+	if err := repos.AuditLog().Record(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit event %q: %w", action, err)
+	}
+	return nil
+}
+
+// Record records that the source of `ctx` performed `action` on `target`, for
+// actions that change nothing that could be committed with the entry, such as
+// logins. The action has already happened by the time it is recorded, so
+// failures are logged and counted rather than returned to fail the request.
+func (s *AuditService) Record(
+	ctx context.Context,
+	action domainAudit.Action,
+	target string,
+	details map[string]string,
+) {
+	entry := s.newEntry(ctx, action, target, details)
+	if err := s.auditLog.Record(ctx, entry); err != nil {
+		s.metrics.AuditRecordFailed()
+		s.logger.Error(ctx, err, "failed to record audit event", map[string]interface{}{
+			"action":   string(action),
+			"target":   target,
+			"actor_id": entry.ActorID.String(),
+		})
+	}
+}
+
+func (s *AuditService) newEntry(
+	ctx context.Context,
+	action domainAudit.Action,
+	target string,
+	details map[string]string,
+) *domainAudit.Entry {
+	return domainAudit.NewEntry(action, domainAudit.SourceFromContext(ctx), target, details, s.now())
+}
+
+// Query returns the page of entries matching `filter`, after recording the
+// query itself. The entries are not returned unless the query was recorded.
+// The limit is clamped to [MaxQueryLimit], and defaults to
+// [DefaultQueryLimit].
+func (s *AuditService) Query(
+	ctx context.Context,
+	filter domainAudit.Filter,
+) (_ *domainAudit.Page, err error) {
+	ctx, span := tracer.Start(ctx, "AuditService.Query")
+	defer tracing.End(span, &err)
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = DefaultQueryLimit
+	case filter.Limit > MaxQueryLimit:
+		filter.Limit = MaxQueryLimit
+	}
+
+	entry := s.newEntry(ctx, domainAudit.ActionAuditQueried, domainAudit.AuditLogTarget, queryDetails(filter))
+	if err := s.auditLog.Record(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to record audit query: %w", err)
+	}
+
+	// One more entry than the limit tells whether entries follow the page.
+	query := filter
+	query.Limit++
+	entries, err := s.auditLog.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	return domainAudit.NewPage(filter, entries), nil
+}
+
+// queryDetails describes `filter` in the details of the entry recording it.
+func queryDetails(filter domainAudit.Filter) map[string]string {
+	details := map[string]string{"limit": strconv.Itoa(filter.Limit)}
+	if filter.ActorID.IsSome() {
+		details["actor_id"] = filter.ActorID.UnwrapOrZero().String()
+	}
+	if filter.Target.IsSome() {
+		details["target"] = filter.Target.UnwrapOrZero()
+	}
+	if !filter.From.IsZero() {
+		details["from"] = filter.From.UTC().Format(time.RFC3339Nano)
+	}
+	if !filter.To.IsZero() {
+		details["to"] = filter.To.UTC().Format(time.RFC3339Nano)
+	}
+	if filter.AfterSequence != 0 {
+		details["after_sequence"] = strconv.FormatInt(filter.AfterSequence, 10)
+	}
+	if filter.BeforeSequence != 0 {
+		details["before_sequence"] = strconv.FormatInt(filter.BeforeSequence, 10)
+	}
+	if filter.NewestFirst {
+		details["newest_first"] = "true"
+	}
+	return details
+}
@@ -0,0 +1,252 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+func Test_AuditService_Record(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		storeErr error
+	}{
+		{name: "store succeeds"},
+		{name: "store fails", storeErr: errors.New("store unavailable")},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			source := domainAudit.Source{
+				ActorID:   uuid.New(),
+				ClientIP:  "192.0.2.1",
+				UserAgent: "test",
+				RequestID: "req-1",
+			}
+			targetID := uuid.New()
+
+			auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+			auditLog.EXPECT().
+				Record(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+					assert.Equal(t, domainAudit.ActionRoleChanged, entry.Action)
+					assert.Equal(t, source.ActorID, entry.ActorID)
+					assert.Equal(t, domainAudit.UserTarget(targetID), entry.Target)
+					assert.Equal(t, source.ClientIP, entry.ClientIP)
+					assert.Equal(t, source.UserAgent, entry.UserAgent)
+					assert.Equal(t, source.RequestID, entry.RequestID)
+					assert.Equal(t, map[string]string{"role": "Admin"}, entry.Details)
+					return tc.storeErr
+				})
+
+			metrics := mock_outbound.NewMockMetrics(ctrl)
+			if tc.storeErr != nil {
+				metrics.EXPECT().AuditRecordFailed()
+			}
+
+			svc := NewAuditService(auditLog, metrics, logger.NewZerologLogger(false))
+			ctx := domainAudit.WithSource(context.Background(), source)
+
+			// Failures are logged and counted, so Record has nothing to return
+			// either way.
+			svc.Record(ctx, domainAudit.ActionRoleChanged, domainAudit.UserTarget(targetID), map[string]string{"role": "Admin"})
+		})
+	}
+}
+
+func Test_AuditService_RecordIn(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		storeErr error
+	}{
+		{name: "store succeeds"},
+		{name: "store fails", storeErr: errors.New("store unavailable")},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			targetID := uuid.New()
+
+			recorder := mock_outbound.NewMockAuditRecorder(ctrl)
+			recorder.EXPECT().
+				Record(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+					assert.Equal(t, domainAudit.ActionSessionRevoked, entry.Action)
+					assert.Equal(t, domainAudit.SessionTarget(targetID), entry.Target)
+					return tc.storeErr
+				})
+			repos := mock_outbound.NewMockRepositories(ctrl)
+			repos.EXPECT().AuditLog().Return(recorder)
+
+			// The audit log outside the unit of work must not be used, and
+			// failures are returned rather than counted.
+			svc := NewAuditService(
+				mock_outbound.NewMockAuditLogger(ctrl),
+				mock_outbound.NewMockMetrics(ctrl),
+				logger.NewZerologLogger(false),
+			)
+
+			err := svc.RecordIn(context.Background(), repos, domainAudit.ActionSessionRevoked, domainAudit.SessionTarget(targetID), nil)
+			if tc.storeErr != nil {
+				require.ErrorIs(t, err, tc.storeErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_AuditService_Query(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		filter      domainAudit.Filter
+		stored      int
+		wantLimit   int
+		wantDetails map[string]string
+		wantEntries int
+		wantCursor  int64
+		queryErr    error
+	}{
+		{
+			name:        "default limit",
+			filter:      domainAudit.Filter{},
+			stored:      1,
+			wantLimit:   DefaultQueryLimit,
+			wantDetails: map[string]string{"limit": "100"},
+			wantEntries: 1,
+		},
+		{
+			name:      "clamped limit",
+			filter:    domainAudit.Filter{ActorID: option.Some(actorID), From: from, Limit: MaxQueryLimit + 1},
+			stored:    1,
+			wantLimit: MaxQueryLimit,
+			wantDetails: map[string]string{
+				"limit":    "1000",
+				"actor_id": actorID.String(),
+				"from":     "2024-01-01T00:00:00Z",
+			},
+			wantEntries: 1,
+		},
+		{
+			name:      "more entries than the limit",
+			filter:    domainAudit.Filter{BeforeSequence: 10, NewestFirst: true, Limit: 2},
+			stored:    3,
+			wantLimit: 2,
+			wantDetails: map[string]string{
+				"limit":           "2",
+				"before_sequence": "10",
+				"newest_first":    "true",
+			},
+			wantEntries: 2,
+			wantCursor:  8,
+		},
+		{
+			name:        "after sequence",
+			filter:      domainAudit.Filter{AfterSequence: 7, Limit: 2},
+			stored:      2,
+			wantLimit:   2,
+			wantDetails: map[string]string{"limit": "2", "after_sequence": "7"},
+			wantEntries: 2,
+		},
+		{
+			name:        "query fails",
+			filter:      domainAudit.Filter{Target: option.Some("audit_log"), Limit: 5},
+			wantLimit:   5,
+			wantDetails: map[string]string{"limit": "5", "target": "audit_log"},
+			queryErr:    errors.New("store unavailable"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			entries := make([]*domainAudit.Entry, tc.stored)
+			for i := range entries {
+				entries[i] = &domainAudit.Entry{Sequence: int64(9 - i)}
+			}
+
+			auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+			gomock.InOrder(
+				auditLog.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+						assert.Equal(t, domainAudit.ActionAuditQueried, entry.Action)
+						assert.Equal(t, domainAudit.AuditLogTarget, entry.Target)
+						assert.Equal(t, tc.wantDetails, entry.Details)
+						return nil
+					}),
+				auditLog.EXPECT().
+					Query(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter domainAudit.Filter) ([]*domainAudit.Entry, error) {
+						// One more entry is asked for, to tell whether entries
+						// follow the page.
+						assert.Equal(t, tc.wantLimit+1, filter.Limit)
+						if tc.queryErr != nil {
+							return nil, tc.queryErr
+						}
+						return entries, nil
+					}),
+			)
+
+			svc := NewAuditService(auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+			got, err := svc.Query(context.Background(), tc.filter)
+
+			if tc.queryErr != nil {
+				require.ErrorIs(t, err, tc.queryErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entries[:tc.wantEntries], got.Entries)
+			assert.Equal(t, tc.wantCursor, got.NextCursor)
+		})
+	}
+}
+
+func Test_AuditService_Query_RecordFails(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	storeErr := errors.New("store unavailable")
+
+	// Entries are not returned unless the query was recorded, so Query must
+	// not be called.
+	auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+	auditLog.EXPECT().Record(gomock.Any(), gomock.Any()).Return(storeErr)
+
+	svc := NewAuditService(auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+	got, err := svc.Query(context.Background(), domainAudit.Filter{})
+
+	require.ErrorIs(t, err, storeErr)
+	assert.Nil(t, got)
+}
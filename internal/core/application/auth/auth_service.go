@@ -3,29 +3,46 @@ package auth
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session"
 
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
 )
 
+var tracer = otel.Tracer("github.com/teamkweku/code-odessey-hex-arch/internal/core/application/auth")
+
 type AuthService struct {
 	tokenService   inbound.TokenService
 	sessionService *session.SessionService
+	userService    inbound.UserService
+	auditService   *audit.AuditService
+	now            func() time.Time
 }
 
-func NewAuthService(tokenService inbound.TokenService, sessionService *session.SessionService) *AuthService {
-	return &AuthService{tokenService, sessionService}
+func NewAuthService(
+	tokenService inbound.TokenService,
+	sessionService *session.SessionService,
+	userService inbound.UserService,
+	auditService *audit.AuditService,
+) *AuthService {
+	return &AuthService{tokenService, sessionService, userService, auditService, time.Now}
 }
 
 func (as *AuthService) CreateToken(
 	user *user.User,
-	durationStr string,
+	duration time.Duration,
 ) (string, *auth.Payload, error) {
-	token, payload, err := as.tokenService.CreateToken(user, durationStr)
+	token, payload, err := as.tokenService.CreateToken(user, duration)
 	if err != nil {
 		return "", nil, fmt.Errorf("auth service - create token: %w", err)
 	}
@@ -48,8 +65,11 @@ func (as *AuthService) CreateSession(
 	refreshToken string,
 	refreshPayload *auth.Payload,
 	userAgent string,
-	clientIP string,
-) (*auth.Sessions, error) {
+	clientIP auth.ClientIP,
+) (_ *auth.Sessions, err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.CreateSession")
+	defer tracing.End(span, &err)
+
 	session, err := auth.NewSessions(
 		user.ID(),
 		refreshToken,
@@ -68,11 +88,64 @@ func (as *AuthService) CreateSession(
 	return session, nil
 }
 
-func (as *AuthService) GetSession(ctx context.Context, sessionID uuid.UUID) (*auth.Sessions, error) {
-	session, err := as.sessionService.GetSessionByUserID(ctx, sessionID)
+func (as *AuthService) GetSession(ctx context.Context, sessionID uuid.UUID) (_ *auth.Sessions, err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.GetSession")
+	defer tracing.End(span, &err)
+
+	session, err := as.sessionService.GetSession(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
 	return session, nil
 }
+
+// RenewAccessToken issues an access token lasting `duration` to the holder
+// of `refreshToken`, whose session must still be valid. The session is the
+// one the token was issued for, which shares the ID of the token's payload.
+func (as *AuthService) RenewAccessToken(
+	ctx context.Context,
+	refreshToken string,
+	duration time.Duration,
+) (_ string, _ *auth.Payload, err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.RenewAccessToken")
+	defer tracing.End(span, &err)
+
+	refreshPayload, err := as.VerifyToken(refreshToken)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// A lagging replica could miss the session of a login made moments ago,
+	// or a block or role change made since.
+	readCtx := outbound.WithReadYourWrites(ctx)
+
+	session, err := as.sessionService.GetSession(readCtx, refreshPayload.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.UserID != refreshPayload.UserID {
+		return "", nil, auth.NewInvalidSessionError("session belongs to another user")
+	}
+	if err := session.CheckRefresh(refreshToken, as.now()); err != nil {
+		return "", nil, err
+	}
+
+	usr, err := as.userService.GetUser(readCtx, refreshPayload.UserID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	token, payload, err := as.CreateToken(usr, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	as.auditService.Record(
+		domainAudit.WithActor(ctx, usr.ID()),
+		domainAudit.ActionTokenRefreshed,
+		domainAudit.SessionTarget(session.ID),
+		nil,
+	)
+	return token, payload, nil
+}
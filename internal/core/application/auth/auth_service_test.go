@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	mock_inbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/inbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+var anyError = errors.New("any error")
+
+// readYourWrites matches contexts requiring reads to observe every committed
+// write.
+var readYourWrites = gomock.Cond(func(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && outbound.ReadYourWrites(ctx)
+})
+
+func Test_service_RenewAccessToken(t *testing.T) {
+	t.Parallel()
+
+	const refreshToken = "refresh-token"
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	usr := domainUser.RandomUser(t)
+	refreshPayload := &auth.Payload{ID: uuid.New(), UserID: usr.ID(), ExpiredAt: now.Add(time.Hour)}
+	accessPayload := &auth.Payload{ID: uuid.New(), UserID: usr.ID(), ExpiredAt: now.Add(time.Minute)}
+
+	validSession := func() *auth.Sessions {
+		return &auth.Sessions{
+			ID:           refreshPayload.ID,
+			UserID:       usr.ID(),
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshPayload.ExpiredAt,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		verifyErr   error
+		session     func() *auth.Sessions
+		sessionErr  error
+		wantInvalid bool
+		wantErr     bool
+		wantRenewed bool
+	}{
+		{
+			name:        "renews the token of a valid session",
+			session:     validSession,
+			wantRenewed: true,
+		},
+		{
+			name:      "invalid refresh token",
+			verifyErr: anyError,
+			wantErr:   true,
+		},
+		{
+			name:       "session not found",
+			sessionErr: anyError,
+			wantErr:    true,
+		},
+		{
+			name: "session of another user",
+			session: func() *auth.Sessions {
+				s := validSession()
+				s.UserID = uuid.New()
+				return s
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "blocked session",
+			session: func() *auth.Sessions {
+				s := validSession()
+				s.IsBlocked = true
+				return s
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "session issued for another token",
+			session: func() *auth.Sessions {
+				s := validSession()
+				s.RefreshToken = "other-token"
+				return s
+			},
+			wantInvalid: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			tokens := mock_inbound.NewMockTokenService(ctrl)
+			if tc.verifyErr != nil {
+				tokens.EXPECT().VerifyToken(refreshToken).Return(nil, tc.verifyErr)
+			} else {
+				tokens.EXPECT().VerifyToken(refreshToken).Return(refreshPayload, nil)
+			}
+
+			sessions := mock_outbound.NewMockSessionRepository(ctrl)
+			if tc.verifyErr == nil {
+				var stored *auth.Sessions
+				if tc.session != nil {
+					stored = tc.session()
+				}
+				sessions.EXPECT().
+					GetSession(readYourWrites, refreshPayload.ID).
+					Return(stored, tc.sessionErr)
+			}
+
+			users := mock_inbound.NewMockUserService(ctrl)
+			auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+			if tc.wantRenewed {
+				users.EXPECT().GetUser(readYourWrites, usr.ID()).Return(usr, nil)
+				tokens.EXPECT().CreateToken(usr, time.Minute).Return("access-token", accessPayload, nil)
+				auditLog.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+						assert.Equal(t, domainAudit.ActionTokenRefreshed, entry.Action)
+						assert.Equal(t, domainAudit.SessionTarget(refreshPayload.ID), entry.Target)
+						assert.Equal(t, usr.ID(), entry.ActorID)
+						return nil
+					})
+			}
+
+			auditService := audit.NewAuditService(auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+			sessionService := session.NewSessionService(
+				sessions,
+				mock_outbound.NewMockUnitOfWork(ctrl),
+				mock_outbound.NewMockMetrics(ctrl),
+				auditService,
+			)
+			service := NewAuthService(tokens, sessionService, users, auditService)
+			service.now = func() time.Time { return now }
+
+			token, payload, err := service.RenewAccessToken(context.Background(), refreshToken, time.Minute)
+
+			switch {
+			case tc.wantInvalid:
+				var validationErr *auth.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, auth.SessionFieldType, validationErr.Field)
+			case tc.wantErr:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, "access-token", token)
+				assert.Equal(t, accessPayload, payload)
+			}
+		})
+	}
+}
+
+func Test_service_GetSession(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	stored := &auth.Sessions{ID: uuid.New(), UserID: uuid.New()}
+
+	sessions := mock_outbound.NewMockSessionRepository(ctrl)
+	sessions.EXPECT().GetSession(gomock.Any(), stored.ID).Return(stored, nil)
+
+	auditService := audit.NewAuditService(mock_outbound.NewMockAuditLogger(ctrl), mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+	sessionService := session.NewSessionService(
+		sessions,
+		mock_outbound.NewMockUnitOfWork(ctrl),
+		mock_outbound.NewMockMetrics(ctrl),
+		auditService,
+	)
+	service := NewAuthService(mock_inbound.NewMockTokenService(ctrl), sessionService, mock_inbound.NewMockUserService(ctrl), auditService)
+
+	got, err := service.GetSession(context.Background(), stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, stored, got)
+}
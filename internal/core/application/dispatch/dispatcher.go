@@ -0,0 +1,260 @@
+// Package dispatch delivers domain events to the handlers of other modules in
+// the same process, so that modules react to each other's events without
+// depending on each other.
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+// ErrClosed is returned by [Dispatcher.Dispatch] once the dispatcher is
+// closed.
+var ErrClosed = errors.New("dispatcher is closed")
+
+// Handler handles a dispatched event.
+type Handler func(ctx context.Context, e event.Event) error
+
+// TypedHandler handles a dispatched event and its decoded payload.
+type TypedHandler[T any] func(ctx context.Context, e event.Event, payload T) error
+
+// Mode selects how a handler receives events.
+type Mode int
+
+const (
+	// Sync handlers run before Dispatch returns, and their errors are
+	// returned by it.
+	Sync Mode = iota
+	// Async handlers run on the dispatcher's worker pool after Dispatch
+	// returns. Their errors are logged.
+	Async
+)
+
+// Options configures a [Dispatcher]. Zero values select the defaults.
+type Options struct {
+	// Workers is the number of goroutines running asynchronous handlers.
+	// Defaults to 4.
+	Workers int
+	// QueueSize is the number of asynchronous deliveries awaiting a worker.
+	// Dispatch blocks while the queue is full. Defaults to 256.
+	QueueSize int
+}
+
+const (
+	defaultWorkers   = 4
+	defaultQueueSize = 256
+)
+
+type subscription struct {
+	name    string
+	mode    Mode
+	handler Handler
+}
+
+type delivery struct {
+	ctx context.Context
+	sub subscription
+	e   event.Event
+}
+
+// Dispatcher delivers events to the handlers subscribed to their type. A
+// failing or panicking handler never prevents the others from receiving the
+// event.
+type Dispatcher struct {
+	logger logger.Logger
+
+	mu            sync.RWMutex
+	subscriptions map[event.Type][]subscription
+	// wildcards receive events of every type.
+	wildcards []subscription
+	closed    bool
+
+	queue   chan delivery
+	workers sync.WaitGroup
+}
+
+var _ outbound.EventPublisher = (*Dispatcher)(nil)
+
+// NewDispatcher returns a dispatcher whose workers run until it is closed.
+func NewDispatcher(opts Options, log logger.Logger) *Dispatcher {
+	if opts.Workers <= 0 {
+		opts.Workers = defaultWorkers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+
+	d := &Dispatcher{
+		logger:        log,
+		subscriptions: make(map[event.Type][]subscription),
+		queue:         make(chan delivery, opts.QueueSize),
+	}
+
+	d.workers.Add(opts.Workers)
+	for range opts.Workers {
+		go d.work()
+	}
+
+	return d
+}
+
+// Subscribe registers `handler` under `name` for events of type `typ`. The
+// name identifies the handler in logs and errors.
+func (d *Dispatcher) Subscribe(typ event.Type, name string, mode Mode, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.subscriptions[typ] = append(d.subscriptions[typ], subscription{
+		name:    name,
+		mode:    mode,
+		handler: handler,
+	})
+}
+
+// SubscribeAll registers `handler` under `name` for events of every type. It
+// receives each event after the handlers subscribed to its type.
+func (d *Dispatcher) SubscribeAll(name string, mode Mode, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.wildcards = append(d.wildcards, subscription{
+		name:    name,
+		mode:    mode,
+		handler: handler,
+	})
+}
+
+// Subscribe registers `handler` for events of type `typ`, whose payloads it
+// receives decoded as T. T must be the payload type of `typ`, such as
+// [event.UserRegistered] for [event.TypeUserRegistered].
+func Subscribe[T any](d *Dispatcher, typ event.Type, name string, mode Mode, handler TypedHandler[T]) {
+	d.Subscribe(typ, name, mode, func(ctx context.Context, e event.Event) error {
+		payload, err := event.DecodePayload[T](e)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, e, payload)
+	})
+}
+
+// Dispatch runs the synchronous handlers of `e` in the order they subscribed,
+// and queues it for the asynchronous ones. It returns the errors of the
+// synchronous handlers, or an error if `ctx` is done while the queue is full.
+//
+// Asynchronous handlers receive a context carrying the values of `ctx`, but
+// not its cancellation.
+func (d *Dispatcher) Dispatch(ctx context.Context, e event.Event) error {
+	d.mu.RLock()
+	closed := d.closed
+	subs := slices.Concat(d.subscriptions[e.Type], d.wildcards)
+	d.mu.RUnlock()
+
+	if closed {
+		return ErrClosed
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.mode != Sync {
+			continue
+		}
+		if err := d.handle(ctx, sub, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, sub := range subs {
+		if sub.mode != Async {
+			continue
+		}
+		if err := d.enqueue(ctx, delivery{ctx: context.WithoutCancel(ctx), sub: sub, e: e}); err != nil {
+			errs = append(errs, fmt.Errorf("queue event %s for %s: %w", e.ID, sub.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// enqueue queues `dlv` unless the dispatcher is closed. The read lock keeps
+// Close from closing the queue during the send.
+func (d *Dispatcher) enqueue(ctx context.Context, dlv delivery) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return ErrClosed
+	}
+
+	select {
+	case d.queue <- dlv:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Publish dispatches `e`, so that the dispatcher can publish the events of
+// the outbox.
+func (d *Dispatcher) Publish(ctx context.Context, e event.Event) error {
+	return d.Dispatch(ctx, e)
+}
+
+// Close stops accepting events and waits for the queued deliveries to be
+// handled. If `ctx` is done first, Close returns its error and the remaining
+// deliveries are handled in the background.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("drain dispatcher: %w", ctx.Err())
+	}
+}
+
+func (d *Dispatcher) work() {
+	defer d.workers.Done()
+
+	for dlv := range d.queue {
+		if err := d.handle(dlv.ctx, dlv.sub, dlv.e); err != nil {
+			d.logger.Error(dlv.ctx, err, "async event handler failed", map[string]interface{}{
+				"handler":    dlv.sub.name,
+				"event_id":   dlv.e.ID.String(),
+				"event_type": string(dlv.e.Type),
+			})
+		}
+	}
+}
+
+// handle calls the handler of `sub`, turning a panic into an error.
+func (d *Dispatcher) handle(ctx context.Context, sub subscription, e event.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler %s panicked on event %s: %v", sub.name, e.ID, r)
+		}
+	}()
+
+	if err := sub.handler(ctx, e); err != nil {
+		return fmt.Errorf("handler %s failed on event %s: %w", sub.name, e.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+var errHandler = errors.New("handler failed")
+
+func newEvent(t *testing.T, typ event.Type, payload any) event.Event {
+	t.Helper()
+
+	encoded, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return event.Event{
+		ID:         uuid.New(),
+		Type:       typ,
+		UserID:     uuid.New(),
+		OccurredAt: time.Now(),
+		Payload:    encoded,
+	}
+}
+
+func newTestDispatcher(t *testing.T, opts Options) *Dispatcher {
+	t.Helper()
+
+	d := NewDispatcher(opts, logger.NewZerologLogger(false))
+	t.Cleanup(func() { _ = d.Close(context.Background()) })
+	return d
+}
+
+func TestDispatcher_DispatchSync(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		handler Handler
+		wantErr bool
+	}{
+		{
+			name:    "handlers succeed",
+			handler: func(context.Context, event.Event) error { return nil },
+		},
+		{
+			name:    "a handler fails",
+			handler: func(context.Context, event.Event) error { return errHandler },
+			wantErr: true,
+		},
+		{
+			name:    "a handler panics",
+			handler: func(context.Context, event.Event) error { panic("boom") },
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := newTestDispatcher(t, Options{})
+			e := newEvent(t, event.TypeUserRegistered, event.UserRegistered{})
+
+			var calls []string
+			d.Subscribe(e.Type, "first", Sync, func(ctx context.Context, got event.Event) error {
+				calls = append(calls, "first")
+				return tc.handler(ctx, got)
+			})
+			d.Subscribe(e.Type, "second", Sync, func(context.Context, event.Event) error {
+				calls = append(calls, "second")
+				return nil
+			})
+			d.Subscribe(event.TypeSessionCreated, "other type", Sync, func(context.Context, event.Event) error {
+				calls = append(calls, "other type")
+				return nil
+			})
+			d.SubscribeAll("every type", Sync, func(context.Context, event.Event) error {
+				calls = append(calls, "every type")
+				return nil
+			})
+
+			err := d.Dispatch(context.Background(), e)
+
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "first")
+			} else {
+				assert.NoError(t, err)
+			}
+			// A failing handler does not keep the next one from running.
+			assert.Equal(t, []string{"first", "second", "every type"}, calls)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDispatcher(t, Options{})
+	want := event.UserRegistered{Username: "ada", Email: "ada@example.com", Role: "Reader"}
+
+	var got event.UserRegistered
+	Subscribe(d, event.TypeUserRegistered, "typed", Sync,
+		func(_ context.Context, _ event.Event, payload event.UserRegistered) error {
+			got = payload
+			return nil
+		})
+
+	require.NoError(t, d.Dispatch(context.Background(), newEvent(t, event.TypeUserRegistered, want)))
+	assert.Equal(t, want, got)
+
+	malformed := newEvent(t, event.TypeUserRegistered, want)
+	malformed.Payload = []byte("not json")
+	assert.Error(t, d.Dispatch(context.Background(), malformed))
+}
+
+func TestDispatcher_DispatchAsync(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher(Options{Workers: 2}, logger.NewZerologLogger(false))
+	e := newEvent(t, event.TypeSessionCreated, event.SessionCreated{})
+
+	var handled atomic.Int32
+	d.Subscribe(e.Type, "counter", Async, func(ctx context.Context, _ event.Event) error {
+		// Asynchronous handlers outlive the dispatching request.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		handled.Add(1)
+		return nil
+	})
+	d.Subscribe(e.Type, "failing", Async, func(context.Context, event.Event) error {
+		return errHandler
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	const dispatched = 20
+	for range dispatched {
+		// Errors of asynchronous handlers are not returned.
+		require.NoError(t, d.Dispatch(ctx, e))
+	}
+	cancel()
+
+	require.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(dispatched), handled.Load())
+	assert.ErrorIs(t, d.Dispatch(context.Background(), e), ErrClosed)
+}
+
+func TestDispatcher_DispatchQueueFull(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher(Options{Workers: 1, QueueSize: 1}, logger.NewZerologLogger(false))
+	e := newEvent(t, event.TypeSessionCreated, event.SessionCreated{})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	d.Subscribe(e.Type, "blocked", Async, func(context.Context, event.Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+
+	// The worker holds the first delivery and the queue the second.
+	require.NoError(t, d.Dispatch(context.Background(), e))
+	<-started
+	require.NoError(t, d.Dispatch(context.Background(), e))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, d.Dispatch(ctx, e), context.DeadlineExceeded)
+
+	// Close gives up waiting once its context is done.
+	closeCtx, cancelClose := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelClose()
+	assert.ErrorIs(t, d.Close(closeCtx), context.DeadlineExceeded)
+
+	close(release)
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestDispatcher_ConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDispatcher(t, Options{})
+	e := newEvent(t, event.TypeUserUpdated, event.UserUpdated{})
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			mode := Sync
+			if i%2 == 0 {
+				mode = Async
+			}
+			d.Subscribe(e.Type, "noop", mode, func(context.Context, event.Event) error { return nil })
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, d.Dispatch(context.Background(), e))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,133 @@
+// Package jobs runs background jobs periodically, on one replica at a time.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
+)
+
+var tracer = otel.Tracer("github.com/teamkweku/code-odessey-hex-arch/internal/core/application/jobs")
+
+// Job is a task run periodically by a [Runner].
+type Job struct {
+	// Name identifies the job in logs and metrics, and names its lock.
+	Name string
+	// Interval is the time between the starts of consecutive runs.
+	Interval time.Duration
+	// Run performs the job and returns the number of items it processed.
+	Run func(ctx context.Context) (int64, error)
+}
+
+// Runner runs registered jobs at their intervals. Each run takes the job's
+// lock first, and is skipped if another replica holds it.
+type Runner struct {
+	locker  outbound.JobLocker
+	metrics outbound.JobMetrics
+	logger  logger.Logger
+	jobs    []Job
+	now     func() time.Time
+}
+
+func NewRunner(locker outbound.JobLocker, metrics outbound.JobMetrics, log logger.Logger) *Runner {
+	return &Runner{
+		locker:  locker,
+		metrics: metrics,
+		logger:  log,
+		now:     time.Now,
+	}
+}
+
+// Register adds `job` to the jobs started by [Runner.Run]. It must not be
+// called once the runner is running.
+func (r *Runner) Register(job Job) {
+	r.jobs = append(r.jobs, job)
+}
+
+// Run runs every registered job once, then again at its interval, until
+// `ctx` is done. It returns once the runs in progress have returned.
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range r.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			r.schedule(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+func (r *Runner) schedule(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.RunOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce runs `job` if no other replica is running it, and records the
+// outcome in the logs and metrics.
+func (r *Runner) RunOnce(ctx context.Context, job Job) outbound.JobResult {
+	start := r.now()
+	processed, ran, err := r.run(ctx, job)
+	duration := r.now().Sub(start)
+
+	result := outbound.JobSucceeded
+	switch {
+	case err != nil:
+		result = outbound.JobFailed
+	case !ran:
+		result = outbound.JobSkipped
+	}
+	r.metrics.JobRun(job.Name, result, duration, processed)
+
+	fields := map[string]interface{}{
+		"job":         job.Name,
+		"result":      string(result),
+		"duration_ms": duration.Milliseconds(),
+		"processed":   processed,
+	}
+	switch result {
+	case outbound.JobFailed:
+		if ctx.Err() == nil {
+			r.logger.Error(ctx, err, "background job failed", fields)
+		}
+	case outbound.JobSkipped:
+		r.logger.Debug(ctx, "background job running elsewhere, skipped", fields)
+	default:
+		r.logger.Info(ctx, "background job completed", fields)
+	}
+
+	return result
+}
+
+func (r *Runner) run(ctx context.Context, job Job) (processed int64, ran bool, err error) {
+	ctx, span := tracer.Start(ctx, "Runner.RunOnce")
+	defer tracing.End(span, &err)
+
+	ran, err = r.locker.RunExclusive(ctx, "job:"+job.Name, func(ctx context.Context) error {
+		var err error
+		processed, err = job.Run(ctx)
+		return err
+	})
+	if err != nil {
+		return processed, ran, fmt.Errorf("run job %q: %w", job.Name, err)
+	}
+
+	return processed, ran, nil
+}
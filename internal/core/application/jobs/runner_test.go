@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+var errJob = errors.New("job failed")
+
+func Test_Runner_RunOnce(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		locked        bool
+		jobErr        error
+		wantResult    outbound.JobResult
+		wantProcessed int64
+	}{
+		{
+			name:          "job succeeds",
+			wantResult:    outbound.JobSucceeded,
+			wantProcessed: 3,
+		},
+		{
+			name:          "job fails",
+			jobErr:        errJob,
+			wantResult:    outbound.JobFailed,
+			wantProcessed: 3,
+		},
+		{
+			name:       "job running elsewhere",
+			locked:     true,
+			wantResult: outbound.JobSkipped,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			locker := mock_outbound.NewMockJobLocker(ctrl)
+			locker.EXPECT().
+				RunExclusive(gomock.Any(), "job:cleanup", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ string, fn func(context.Context) error) (bool, error) {
+					if tc.locked {
+						return false, nil
+					}
+					return true, fn(ctx)
+				})
+
+			metrics := mock_outbound.NewMockJobMetrics(ctrl)
+			metrics.EXPECT().
+				JobRun("cleanup", tc.wantResult, gomock.Any(), tc.wantProcessed)
+
+			runner := NewRunner(locker, metrics, logger.NewZerologLogger(false))
+			result := runner.RunOnce(context.Background(), Job{
+				Name:     "cleanup",
+				Interval: time.Minute,
+				Run: func(context.Context) (int64, error) {
+					return 3, tc.jobErr
+				},
+			})
+
+			assert.Equal(t, tc.wantResult, result)
+		})
+	}
+}
+
+func Test_Runner_Run(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The job runs as soon as the runner starts, despite the long interval.
+	// The runner stops once its context is done.
+	locker := mock_outbound.NewMockJobLocker(ctrl)
+	locker.EXPECT().
+		RunExclusive(gomock.Any(), "job:cleanup", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, fn func(context.Context) error) (bool, error) {
+			return true, fn(ctx)
+		})
+
+	metrics := mock_outbound.NewMockJobMetrics(ctrl)
+	metrics.EXPECT().JobRun("cleanup", outbound.JobSucceeded, gomock.Any(), int64(0))
+
+	runner := NewRunner(locker, metrics, logger.NewZerologLogger(false))
+	runner.Register(Job{
+		Name:     "cleanup",
+		Interval: time.Hour,
+		Run: func(context.Context) (int64, error) {
+			cancel()
+			return 0, nil
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runner.Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner did not stop")
+	}
+}
@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// CleanupJobName identifies the outbox cleanup job.
+const CleanupJobName = "outbox-cleanup"
+
+// CleanerOptions configures a [Cleaner]. Zero values select the defaults.
+type CleanerOptions struct {
+	// BatchSize is the most messages deleted by one statement. Defaults to
+	// 500.
+	BatchSize int
+	// SentRetention is how long sent messages are kept after they were sent.
+	// Defaults to a week.
+	SentRetention time.Duration
+	// DeadRetention is how long dead messages are kept for inspection after
+	// their event occurred. Defaults to 30 days.
+	DeadRetention time.Duration
+}
+
+const (
+	defaultCleanupBatchSize     = 500
+	defaultCleanupSentRetention = 7 * 24 * time.Hour
+	defaultCleanupDeadRetention = 30 * 24 * time.Hour
+)
+
+// Cleaner deletes outbox messages that were sent or dead-lettered long ago.
+type Cleaner struct {
+	store outbound.OutboxCleaner
+	opts  CleanerOptions
+	now   func() time.Time
+}
+
+func NewCleaner(store outbound.OutboxCleaner, opts CleanerOptions) *Cleaner {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultCleanupBatchSize
+	}
+	if opts.SentRetention <= 0 {
+		opts.SentRetention = defaultCleanupSentRetention
+	}
+	if opts.DeadRetention <= 0 {
+		opts.DeadRetention = defaultCleanupDeadRetention
+	}
+
+	return &Cleaner{store: store, opts: opts, now: time.Now}
+}
+
+// Run deletes finished messages in batches until a batch comes back short,
+// and returns the number deleted. Batches keep each statement's locks brief.
+func (c *Cleaner) Run(ctx context.Context) (int64, error) {
+	now := c.now()
+	sentBefore := now.Add(-c.opts.SentRetention)
+	deadBefore := now.Add(-c.opts.DeadRetention)
+
+	var total int64
+	for {
+		deleted, err := c.store.DeleteFinishedOutboxMessages(ctx, sentBefore, deadBefore, c.opts.BatchSize)
+		total += deleted
+		if err != nil {
+			return total, fmt.Errorf("delete finished outbox messages: %w", err)
+		}
+		if deleted < int64(c.opts.BatchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
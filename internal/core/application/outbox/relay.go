@@ -0,0 +1,142 @@
+// Package outbox publishes the events stored in the transactional outbox.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	domainOutbox "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
+)
+
+var tracer = otel.Tracer("github.com/teamkweku/code-odessey-hex-arch/internal/core/application/outbox")
+
+// RelayOptions configures a [Relay]. Zero values select the defaults.
+type RelayOptions struct {
+	// BatchSize is the most messages claimed at once. Defaults to 100.
+	BatchSize int
+	// PollInterval is the wait before checking the outbox again after it held
+	// no more due messages. Defaults to one second.
+	PollInterval time.Duration
+	// RetryPolicy schedules the retries of failed messages. Defaults to
+	// [domainOutbox.DefaultRetryPolicy].
+	RetryPolicy domainOutbox.RetryPolicy
+}
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+)
+
+// Relay publishes outbox messages until they are sent or dead. Several relays
+// may run against the same store, each claiming different messages.
+type Relay struct {
+	store     outbound.OutboxRelayStore
+	publisher outbound.EventPublisher
+	opts      RelayOptions
+	logger    logger.Logger
+	now       func() time.Time
+}
+
+func NewRelay(
+	store outbound.OutboxRelayStore,
+	publisher outbound.EventPublisher,
+	opts RelayOptions,
+	log logger.Logger,
+) *Relay {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.RetryPolicy.MaxAttempts <= 0 {
+		opts.RetryPolicy = domainOutbox.DefaultRetryPolicy
+	}
+
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		opts:      opts,
+		logger:    log,
+		now:       time.Now,
+	}
+}
+
+// Run relays batches of messages until `ctx` is done. Full batches are
+// followed immediately by the next one, so that a backlog drains quickly.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		claimed, err := r.RelayBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error(ctx, err, "relay outbox batch", nil)
+		}
+
+		wait := r.opts.PollInterval
+		if err == nil && claimed == r.opts.BatchSize {
+			wait = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// RelayBatch publishes one batch of due messages and returns the number of
+// messages claimed. Failed messages are scheduled for a retry, or
+// dead-lettered once they exhaust the retry policy.
+func (r *Relay) RelayBatch(ctx context.Context) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "Relay.RelayBatch")
+	defer tracing.End(span, &err)
+
+	claimed, err := r.store.ProcessOutbox(
+		ctx,
+		r.now(),
+		r.opts.BatchSize,
+		func(ctx context.Context, messages []*domainOutbox.Message) error {
+			for _, msg := range messages {
+				r.publish(ctx, msg)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return claimed, fmt.Errorf("process outbox: %w", err)
+	}
+
+	return claimed, nil
+}
+
+func (r *Relay) publish(ctx context.Context, msg *domainOutbox.Message) {
+	err := r.publisher.Publish(ctx, msg.Event)
+	if err == nil {
+		msg.MarkSent(r.now())
+		return
+	}
+
+	msg.MarkFailed(r.now(), err, r.opts.RetryPolicy)
+
+	fields := map[string]interface{}{
+		"event_id":   msg.Event.ID.String(),
+		"event_type": string(msg.Event.Type),
+		"attempts":   msg.Attempts,
+	}
+	if msg.Status == domainOutbox.StatusDead {
+		r.logger.Error(ctx, err, "outbox message dead-lettered", fields)
+		return
+	}
+
+	fields["error"] = err.Error()
+	fields["next_attempt_at"] = msg.NextAttemptAt
+	r.logger.Warn(ctx, "publish outbox message failed", fields)
+}
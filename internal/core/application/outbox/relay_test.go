@@ -0,0 +1,185 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	domainOutbox "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+)
+
+var errPublish = errors.New("publish failed")
+
+func newMessage(attempts int) *domainOutbox.Message {
+	msg := domainOutbox.NewMessage(event.Event{
+		ID:         uuid.New(),
+		Type:       event.TypeUserRegistered,
+		UserID:     uuid.New(),
+		OccurredAt: time.Now(),
+		Payload:    []byte(`{}`),
+	})
+	msg.Attempts = attempts
+	return msg
+}
+
+func Test_Relay_RelayBatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	policy := domainOutbox.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+
+	testCases := []struct {
+		name          string
+		attempts      int
+		publishErr    error
+		wantStatus    domainOutbox.Status
+		wantAttempts  int
+		wantNextRetry time.Time
+	}{
+		{
+			name:         "publish succeeds",
+			wantStatus:   domainOutbox.StatusSent,
+			wantAttempts: 1,
+		},
+		{
+			name:          "publish fails",
+			attempts:      1,
+			publishErr:    errPublish,
+			wantStatus:    domainOutbox.StatusPending,
+			wantAttempts:  2,
+			wantNextRetry: now.Add(2 * time.Second),
+		},
+		{
+			name:         "last attempt fails",
+			attempts:     2,
+			publishErr:   errPublish,
+			wantStatus:   domainOutbox.StatusDead,
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_outbound.NewMockOutboxRelayStore(ctrl)
+			publisher := mock_outbound.NewMockEventPublisher(ctrl)
+			relay := NewRelay(
+				store,
+				publisher,
+				RelayOptions{BatchSize: 10, RetryPolicy: policy},
+				logger.NewZerologLogger(false),
+			)
+			relay.now = func() time.Time { return now }
+
+			msg := newMessage(tc.attempts)
+			store.EXPECT().
+				ProcessOutbox(gomock.Any(), now, 10, gomock.Any()).
+				DoAndReturn(func(
+					ctx context.Context,
+					_ time.Time,
+					_ int,
+					process func(context.Context, []*domainOutbox.Message) error,
+				) (int, error) {
+					return 1, process(ctx, []*domainOutbox.Message{msg})
+				})
+			publisher.EXPECT().
+				Publish(gomock.Any(), msg.Event).
+				Return(tc.publishErr)
+
+			claimed, err := relay.RelayBatch(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, claimed)
+			assert.Equal(t, tc.wantStatus, msg.Status)
+			assert.Equal(t, tc.wantAttempts, msg.Attempts)
+			if tc.publishErr != nil {
+				assert.Equal(t, tc.publishErr.Error(), msg.LastError)
+			}
+			if !tc.wantNextRetry.IsZero() {
+				assert.Equal(t, tc.wantNextRetry, msg.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func Test_Relay_RelayBatch_StoreError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errStore := errors.New("store unavailable")
+	store := mock_outbound.NewMockOutboxRelayStore(ctrl)
+	store.EXPECT().
+		ProcessOutbox(gomock.Any(), gomock.Any(), defaultBatchSize, gomock.Any()).
+		Return(0, errStore)
+
+	relay := NewRelay(
+		store,
+		mock_outbound.NewMockEventPublisher(ctrl),
+		RelayOptions{},
+		logger.NewZerologLogger(false),
+	)
+
+	_, err := relay.RelayBatch(context.Background())
+	assert.ErrorIs(t, err, errStore)
+}
+
+func Test_Relay_Run(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A full batch is followed immediately by the next, despite the long poll
+	// interval. The relay stops once its context is done.
+	store := mock_outbound.NewMockOutboxRelayStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			ProcessOutbox(gomock.Any(), gomock.Any(), 2, gomock.Any()).
+			Return(2, nil),
+		store.EXPECT().
+			ProcessOutbox(gomock.Any(), gomock.Any(), 2, gomock.Any()).
+			DoAndReturn(func(context.Context, time.Time, int, func(context.Context, []*domainOutbox.Message) error) (int, error) {
+				cancel()
+				return 0, nil
+			}),
+	)
+
+	relay := NewRelay(
+		store,
+		mock_outbound.NewMockEventPublisher(ctrl),
+		RelayOptions{BatchSize: 2, PollInterval: time.Hour},
+		logger.NewZerologLogger(false),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay did not stop")
+	}
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// CleanupJobName identifies the session cleanup job.
+const CleanupJobName = "session-cleanup"
+
+// CleanerOptions configures a [Cleaner]. Zero values select the defaults.
+type CleanerOptions struct {
+	// BatchSize is the most sessions deleted by one statement. Defaults to
+	// 500.
+	BatchSize int
+	// BlockedRetention is how long blocked sessions are kept after they were
+	// created. Sessions do not record when they were blocked. Defaults to a
+	// week.
+	BlockedRetention time.Duration
+}
+
+const (
+	defaultCleanupBatchSize        = 500
+	defaultCleanupBlockedRetention = 7 * 24 * time.Hour
+)
+
+// Cleaner deletes sessions that expired or have long been blocked.
+type Cleaner struct {
+	store outbound.SessionCleaner
+	opts  CleanerOptions
+	now   func() time.Time
+}
+
+func NewCleaner(store outbound.SessionCleaner, opts CleanerOptions) *Cleaner {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultCleanupBatchSize
+	}
+	if opts.BlockedRetention <= 0 {
+		opts.BlockedRetention = defaultCleanupBlockedRetention
+	}
+
+	return &Cleaner{store: store, opts: opts, now: time.Now}
+}
+
+// Run deletes stale sessions in batches until a batch comes back short, and
+// returns the number deleted. Batches keep each statement's locks brief.
+func (c *Cleaner) Run(ctx context.Context) (int64, error) {
+	now := c.now()
+	blockedBefore := now.Add(-c.opts.BlockedRetention)
+
+	var total int64
+	for {
+		deleted, err := c.store.DeleteStaleSessions(ctx, now, blockedBefore, c.opts.BatchSize)
+		total += deleted
+		if err != nil {
+			return total, fmt.Errorf("delete stale sessions: %w", err)
+		}
+		if deleted < int64(c.opts.BatchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
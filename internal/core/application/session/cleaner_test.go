@@ -0,0 +1,81 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+)
+
+func Test_Cleaner_Run(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	errStore := errors.New("store unavailable")
+
+	testCases := []struct {
+		name      string
+		batches   []int64
+		storeErr  error
+		wantTotal int64
+	}{
+		{
+			name:      "nothing to delete",
+			batches:   []int64{0},
+			wantTotal: 0,
+		},
+		{
+			name:      "deletes until a short batch",
+			batches:   []int64{2, 2, 1},
+			wantTotal: 5,
+		},
+		{
+			name:      "store fails",
+			batches:   []int64{2, 0},
+			storeErr:  errStore,
+			wantTotal: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_outbound.NewMockSessionCleaner(ctrl)
+			calls := make([]any, 0, len(tc.batches))
+			for i, deleted := range tc.batches {
+				var err error
+				if i == len(tc.batches)-1 {
+					err = tc.storeErr
+				}
+				calls = append(calls, store.EXPECT().
+					DeleteStaleSessions(gomock.Any(), now, now.Add(-time.Hour), 2).
+					Return(deleted, err))
+			}
+			gomock.InOrder(calls...)
+
+			cleaner := NewCleaner(store, CleanerOptions{BatchSize: 2, BlockedRetention: time.Hour})
+			cleaner.now = func() time.Time { return now }
+
+			total, err := cleaner.Run(context.Background())
+
+			if tc.storeErr != nil {
+				require.ErrorIs(t, err, tc.storeErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantTotal, total)
+		})
+	}
+}
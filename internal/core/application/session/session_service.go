@@ -3,29 +3,67 @@ package session
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
 )
 
+var tracer = otel.Tracer("github.com/teamkweku/code-odessey-hex-arch/internal/core/application/session")
+
 type SessionService struct {
-	sessionRepo outbound.SessionRepository
+	sessionRepo  outbound.SessionRepository
+	uow          outbound.UnitOfWork
+	metrics      outbound.Metrics
+	auditService *audit.AuditService
+	now          func() time.Time
 }
 
-func NewSessionService(sessionRepo outbound.SessionRepository) *SessionService {
-	return &SessionService{sessionRepo}
+func NewSessionService(
+	sessionRepo outbound.SessionRepository,
+	uow outbound.UnitOfWork,
+	metrics outbound.Metrics,
+	auditService *audit.AuditService,
+) *SessionService {
+	return &SessionService{sessionRepo, uow, metrics, auditService, time.Now}
 }
 
-func (s *SessionService) CreateSession(ctx context.Context, session *auth.Sessions) error {
-	if err := s.sessionRepo.CreateSession(ctx, session); err != nil {
+func (s *SessionService) CreateSession(ctx context.Context, session *auth.Sessions) (err error) {
+	ctx, span := tracer.Start(ctx, "SessionService.CreateSession")
+	defer tracing.End(span, &err)
+
+	err = s.uow.Do(ctx, func(repos outbound.Repositories) error {
+		if err := repos.Sessions().CreateSession(ctx, session); err != nil {
+			return err
+		}
+
+		created, err := event.NewSessionCreated(session, s.now())
+		if err != nil {
+			return err
+		}
+		return repos.Outbox().Append(ctx, outbox.NewMessage(created))
+	})
+	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
+	s.metrics.SessionCreated()
+
 	return nil
 }
 
-func (s *SessionService) GetSession(ctx context.Context, id uuid.UUID) (*auth.Sessions, error) {
+func (s *SessionService) GetSession(ctx context.Context, id uuid.UUID) (_ *auth.Sessions, err error) {
+	ctx, span := tracer.Start(ctx, "SessionService.GetSession")
+	defer tracing.End(span, &err)
+
 	session, err := s.sessionRepo.GetSession(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
@@ -34,7 +72,13 @@ func (s *SessionService) GetSession(ctx context.Context, id uuid.UUID) (*auth.Se
 	return session, nil
 }
 
-func (s *SessionService) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*auth.Sessions, error) {
+func (s *SessionService) GetSessionByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+) (_ *auth.Sessions, err error) {
+	ctx, span := tracer.Start(ctx, "SessionService.GetSessionByUserID")
+	defer tracing.End(span, &err)
+
 	session, err := s.sessionRepo.GetSessionByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
@@ -43,8 +87,17 @@ func (s *SessionService) GetSessionByUserID(ctx context.Context, userID uuid.UUI
 	return session, nil
 }
 
-func (s *SessionService) DeleteSession(ctx context.Context, id uuid.UUID) error {
-	if err := s.sessionRepo.DeleteSession(ctx, id); err != nil {
+func (s *SessionService) DeleteSession(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracer.Start(ctx, "SessionService.DeleteSession")
+	defer tracing.End(span, &err)
+
+	err = s.uow.Do(ctx, func(repos outbound.Repositories) error {
+		if err := repos.Sessions().DeleteSession(ctx, id); err != nil {
+			return err
+		}
+		return s.auditService.RecordIn(ctx, repos, domainAudit.ActionSessionRevoked, domainAudit.SessionTarget(id), nil)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
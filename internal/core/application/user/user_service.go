@@ -4,42 +4,102 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
 	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
 	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/tracing"
 )
 
-// service that implements and satisfies the inbound service interface
+var tracer = otel.Tracer("github.com/teamkweku/code-odessey-hex-arch/internal/core/application/user")
+
+// service that implements and satisfies the inbound service interface. Writes
+// go through a unit of work, which stores the resulting events in the outbox
+// together with the change. Registrations, logins and role changes are
+// recorded in the audit log.
 type UserService struct {
 	repo               outbound.UserRepository
+	uow                outbound.UnitOfWork
+	metrics            outbound.Metrics
+	auditService       *audit.AuditService
 	passwordComparator domainUser.PasswordComparator
+	now                func() time.Time
 }
 
-func NewUserService(repo outbound.UserRepository) *UserService {
+func NewUserService(
+	repo outbound.UserRepository,
+	uow outbound.UnitOfWork,
+	metrics outbound.Metrics,
+	auditService *audit.AuditService,
+) *UserService {
 	return &UserService{
 		repo:               repo,
+		uow:                uow,
+		metrics:            metrics,
+		auditService:       auditService,
 		passwordComparator: domainUser.BcryptCompare,
+		now:                time.Now,
 	}
 }
 
 func (us *UserService) Register(
 	ctx context.Context,
 	req *domainUser.RegistrationRequest,
-) (*domainUser.User, error) {
-	user, err := us.repo.CreateUser(ctx, req)
+) (_ *domainUser.User, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.Register")
+	defer tracing.End(span, &err)
+
+	var user *domainUser.User
+	err = us.uow.Do(ctx, func(repos outbound.Repositories) error {
+		created, err := repos.Users().CreateUser(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		registered, err := event.NewUserRegistered(created, us.now())
+		if err != nil {
+			return err
+		}
+		if err := repos.Outbox().Append(ctx, outbox.NewMessage(registered)); err != nil {
+			return err
+		}
+		if err := us.auditService.RecordIn(
+			domainAudit.WithActor(ctx, created.ID()),
+			repos,
+			domainAudit.ActionUserRegistered,
+			domainAudit.UserTarget(created.ID()),
+			nil,
+		); err != nil {
+			return err
+		}
+
+		user = created
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create user from %#v: %w", req, err)
+		// The request is not printed, since the error is recorded on the span.
+		return nil, fmt.Errorf("create user %q: %w", req.Username(), err)
 	}
 
+	us.metrics.UserRegistered()
+
 	return user, nil
 }
 
 func (us *UserService) GetUser(
 	ctx context.Context,
 	id uuid.UUID,
-) (*domainUser.User, error) {
+) (_ *domainUser.User, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUser")
+	defer tracing.End(span, &err)
+
 	user, err := us.repo.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("get user with ID %s: %w", id, err)
@@ -50,28 +110,95 @@ func (us *UserService) GetUser(
 func (us *UserService) Authenticate(
 	ctx context.Context,
 	req *domainUser.LoginRequest,
-) (*domainUser.User, error) {
-	user, err := us.repo.GetUserByEmail(ctx, req.Email())
+) (_ *domainUser.User, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.Authenticate")
+	defer tracing.End(span, &err)
+
+	// A login may closely follow the registration or a password change, which
+	// a lagging replica would not have seen yet.
+	user, err := us.repo.GetUserByEmail(outbound.WithReadYourWrites(ctx), req.Email())
 	if err != nil {
 		var notFoundErr *domainUser.NotFoundError
 		if errors.As(err, &notFoundErr) {
+			us.loginFailed(ctx, req, "unknown email")
 			return nil, &domainUser.AuthError{Cause: err}
 		}
 		return nil, fmt.Errorf("failed to authenticate user: %w", err)
 	}
 	if err := us.passwordComparator(user.PasswordHash(), req.PasswordCandidate()); err != nil {
+		us.loginFailed(ctx, req, "wrong password")
 		return nil, &domainUser.AuthError{Cause: err}
 	}
 
+	us.metrics.LoginSucceeded()
+	us.auditService.Record(
+		domainAudit.WithActor(ctx, user.ID()),
+		domainAudit.ActionLoginSucceeded,
+		domainAudit.UserTarget(user.ID()),
+		nil,
+	)
+
 	return user, nil
 }
 
+// loginFailed records a failed login attempt for the account `req` names.
+func (us *UserService) loginFailed(ctx context.Context, req *domainUser.LoginRequest, reason string) {
+	us.metrics.LoginFailed()
+	us.auditService.Record(
+		ctx,
+		domainAudit.ActionLoginFailed,
+		domainAudit.EmailTarget(req.Email().String()),
+		map[string]string{"reason": reason},
+	)
+}
+
 func (us *UserService) UpdateUser(
 	ctx context.Context,
 	req *domainUser.UpdateRequest,
-) (*domainUser.User, error) {
-	user, err := us.repo.UpdateUser(ctx, req)
+) (_ *domainUser.User, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateUser")
+	defer tracing.End(span, &err)
+
+	var user *domainUser.User
+	err = us.uow.Do(ctx, func(repos outbound.Repositories) error {
+		updated, err := repos.Users().UpdateUser(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		events, err := event.NewUpdateEvents(req, updated, us.now())
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			messages := make([]*outbox.Message, 0, len(events))
+			for _, e := range events {
+				messages = append(messages, outbox.NewMessage(e))
+			}
+			if err := repos.Outbox().Append(ctx, messages...); err != nil {
+				return err
+			}
+		}
+		if req.Role().IsSome() {
+			if err := us.auditService.RecordIn(
+				ctx,
+				repos,
+				domainAudit.ActionRoleChanged,
+				domainAudit.UserTarget(updated.ID()),
+				map[string]string{"role": updated.Role().String()},
+			); err != nil {
+				return err
+			}
+		}
+
+		user = updated
+		return nil
+	})
 	if err != nil {
+		var concurrentModificationErr *domainUser.ConcurrentModificationError
+		if errors.As(err, &concurrentModificationErr) {
+			us.metrics.ConcurrentModification()
+		}
 		return nil, fmt.Errorf(
 			"update user with ID %s: %w",
 			req.UserID().String(),
@@ -5,17 +5,99 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/application/audit"
+	domainAudit "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	domainOutbox "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
 	domainUser "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
 	mock_outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound/mock"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/logger"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
 )
 
 var anyError = errors.New("any error")
 
+// readYourWrites matches contexts requiring reads to observe every committed
+// write.
+var readYourWrites = gomock.Cond(func(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && outbound.ReadYourWrites(ctx)
+})
+
+// expectUnitOfWork returns a unit of work that runs its function once with
+// `users`, an outbox expecting `appended` messages and `auditLog`.
+func expectUnitOfWork(
+	ctrl *gomock.Controller,
+	users outbound.UserRepository,
+	appended int,
+	auditLog outbound.AuditRecorder,
+) *mock_outbound.MockUnitOfWork {
+	outbox := mock_outbound.NewMockOutboxRepository(ctrl)
+	if appended > 0 {
+		outbox.EXPECT().
+			Append(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, messages ...*domainOutbox.Message) error {
+				if len(messages) != appended {
+					return fmt.Errorf("appended %d messages, want %d", len(messages), appended)
+				}
+				return nil
+			})
+	}
+
+	repos := mock_outbound.NewMockRepositories(ctrl)
+	repos.EXPECT().Users().Return(users).AnyTimes()
+	repos.EXPECT().Outbox().Return(outbox).AnyTimes()
+	repos.EXPECT().AuditLog().Return(auditLog).AnyTimes()
+
+	uow := mock_outbound.NewMockUnitOfWork(ctrl)
+	uow.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(outbound.Repositories) error) error {
+			return fn(repos)
+		})
+	return uow
+}
+
+// expectAuditLog returns an audit log expecting `actions` to be recorded in
+// order.
+func expectAuditLog(ctrl *gomock.Controller, actions ...domainAudit.Action) *mock_outbound.MockAuditLogger {
+	auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+	calls := make([]any, 0, len(actions))
+	for _, action := range actions {
+		action := action
+		calls = append(calls, auditLog.EXPECT().
+			Record(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+				if entry.Action != action {
+					ctrl.T.Errorf("recorded %q, want %q", entry.Action, action)
+				}
+				return nil
+			}))
+	}
+	gomock.InOrder(calls...)
+	return auditLog
+}
+
+// newAuditService returns an audit service recording to `auditLog`.
+func newAuditService(ctrl *gomock.Controller, auditLog outbound.AuditLogger) *audit.AuditService {
+	return audit.NewAuditService(auditLog, mock_outbound.NewMockMetrics(ctrl), logger.NewZerologLogger(false))
+}
+
+// expectAudit returns an audit service expecting `actions` to be recorded in
+// order.
+func expectAudit(ctrl *gomock.Controller, actions ...domainAudit.Action) *audit.AuditService {
+	return newAuditService(ctrl, expectAuditLog(ctrl, actions...))
+}
+
 func Test_service_Register(t *testing.T) {
 	t.Parallel()
 
@@ -23,17 +105,26 @@ func Test_service_Register(t *testing.T) {
 
 	testCases := []struct {
 		name     string
-		wantUser *domainUser.User
+		repoUser *domainUser.User
+		repoErr  error
+		auditErr error
+		wantUser bool
 		wantErr  error
 	}{
 		{
 			name:     "repo call succeeds",
-			wantUser: &domainUser.User{},
-			wantErr:  nil,
+			repoUser: domainUser.RandomUser(t),
+			wantUser: true,
 		},
 		{
-			name:     "repo returns any error",
-			wantUser: nil,
+			name:    "repo returns any error",
+			repoErr: anyError,
+			wantErr: anyError,
+		},
+		{
+			name:     "audit log returns any error",
+			repoUser: domainUser.RandomUser(t),
+			auditErr: anyError,
 			wantErr:  anyError,
 		},
 	}
@@ -48,16 +139,47 @@ func Test_service_Register(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock_outbound.NewMockUserRepository(ctrl)
-			svc := NewUserService(mockRepo)
+			mockMetrics := mock_outbound.NewMockMetrics(ctrl)
+			auditLog := mock_outbound.NewMockAuditLogger(ctrl)
+			appended := 0
+			if tc.repoErr == nil {
+				appended = 1
+				auditLog.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *domainAudit.Entry) error {
+						assert.Equal(t, domainAudit.ActionUserRegistered, entry.Action)
+						assert.Equal(t, tc.repoUser.ID(), entry.ActorID)
+						return tc.auditErr
+					})
+			}
+			svc := NewUserService(
+				mockRepo,
+				expectUnitOfWork(ctrl, mockRepo, appended, auditLog),
+				mockMetrics,
+				newAuditService(ctrl, auditLog),
+			)
 
 			mockRepo.EXPECT().
 				CreateUser(gomock.Any(), req).
-				Return(tc.wantUser, tc.wantErr)
+				Return(tc.repoUser, tc.repoErr)
+			if tc.wantUser {
+				mockMetrics.EXPECT().UserRegistered()
+			}
 
 			gotUser, gotErr := svc.Register(context.Background(), req)
 
-			assert.Equal(t, tc.wantUser, gotUser)
+			if tc.wantUser {
+				assert.Equal(t, tc.repoUser, gotUser)
+			} else {
+				assert.Nil(t, gotUser)
+			}
 			assert.ErrorIs(t, gotErr, tc.wantErr)
+			if gotErr != nil {
+				// Errors are recorded on spans, so they must not carry the
+				// caller's email address or password hash.
+				assert.NotContains(t, gotErr.Error(), req.Email().String())
+				assert.NotContains(t, gotErr.Error(), string(req.PasswordHash().Bytes()))
+			}
 		})
 	}
 }
@@ -74,6 +196,8 @@ func Test_Service_Authenticate(t *testing.T) {
 		passwordComparator domainUser.PasswordComparator
 		wantUser           *domainUser.User
 		wantErr            error
+		wantMetrics        func(m *mock_outbound.MockMetrics)
+		wantActions        []domainAudit.Action
 	}{
 		{
 			name:     "success",
@@ -84,6 +208,10 @@ func Test_Service_Authenticate(t *testing.T) {
 			},
 			wantUser: &domainUser.User{},
 			wantErr:  nil,
+			wantMetrics: func(m *mock_outbound.MockMetrics) {
+				m.EXPECT().LoginSucceeded()
+			},
+			wantActions: []domainAudit.Action{domainAudit.ActionLoginSucceeded},
 		},
 		{
 			name:               "repo returns NotFoundError",
@@ -94,6 +222,10 @@ func Test_Service_Authenticate(t *testing.T) {
 			wantErr: &domainUser.AuthError{
 				Cause: &domainUser.NotFoundError{},
 			},
+			wantMetrics: func(m *mock_outbound.MockMetrics) {
+				m.EXPECT().LoginFailed()
+			},
+			wantActions: []domainAudit.Action{domainAudit.ActionLoginFailed},
 		},
 		{
 			name:               "repo returns any other error",
@@ -105,6 +237,7 @@ func Test_Service_Authenticate(t *testing.T) {
 				"failed to authenticate user: %w",
 				anyError,
 			),
+			wantMetrics: func(m *mock_outbound.MockMetrics) {},
 		},
 		{
 			name:     "passwordComparator returns an error",
@@ -117,6 +250,10 @@ func Test_Service_Authenticate(t *testing.T) {
 			wantErr: &domainUser.AuthError{
 				Cause: errors.New("invalid password"),
 			},
+			wantMetrics: func(m *mock_outbound.MockMetrics) {
+				m.EXPECT().LoginFailed()
+			},
+			wantActions: []domainAudit.Action{domainAudit.ActionLoginFailed},
 		},
 	}
 
@@ -130,13 +267,17 @@ func Test_Service_Authenticate(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock_outbound.NewMockUserRepository(ctrl)
+			mockMetrics := mock_outbound.NewMockMetrics(ctrl)
 			svc := &UserService{
 				repo:               mockRepo,
+				metrics:            mockMetrics,
+				auditService:       expectAudit(ctrl, tc.wantActions...),
 				passwordComparator: tc.passwordComparator,
 			}
+			tc.wantMetrics(mockMetrics)
 
 			mockRepo.EXPECT().
-				GetUserByEmail(gomock.Any(), req.Email()).
+				GetUserByEmail(readYourWrites, req.Email()).
 				Return(tc.repoUser, tc.repoErr)
 			gotUser, gotErr := svc.Authenticate(context.Background(), req)
 
@@ -176,7 +317,12 @@ func Test_Service_GetUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock_outbound.NewMockUserRepository(ctrl)
-			svc := NewUserService(mockRepo)
+			svc := NewUserService(
+				mockRepo,
+				mock_outbound.NewMockUnitOfWork(ctrl),
+				mock_outbound.NewMockMetrics(ctrl),
+				expectAudit(ctrl),
+			)
 			userID := uuid.New()
 
 			mockRepo.EXPECT().
@@ -195,6 +341,8 @@ func Test_service_UpdateUser(t *testing.T) {
 	t.Parallel()
 
 	req := domainUser.RandomUpdateRequest(t)
+	wantEvents, err := event.NewUpdateEvents(req, domainUser.RandomUser(t), time.Now())
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name     string
@@ -203,7 +351,7 @@ func Test_service_UpdateUser(t *testing.T) {
 	}{
 		{
 			name:     "repo call succeeds",
-			wantUser: &domainUser.User{},
+			wantUser: domainUser.RandomUser(t),
 			wantErr:  nil,
 		},
 		{
@@ -211,6 +359,14 @@ func Test_service_UpdateUser(t *testing.T) {
 			wantUser: nil,
 			wantErr:  anyError,
 		},
+		{
+			name:     "repo returns ConcurrentModificationError",
+			wantUser: nil,
+			wantErr: &domainUser.ConcurrentModificationError{
+				ID:   req.UserID(),
+				ETag: req.ETag(),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -223,12 +379,32 @@ func Test_service_UpdateUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock_outbound.NewMockUserRepository(ctrl)
-			svc := NewUserService(mockRepo)
+			mockMetrics := mock_outbound.NewMockMetrics(ctrl)
+			appended := 0
+			var actions []domainAudit.Action
+			if tc.wantErr == nil {
+				appended = len(wantEvents)
+				if req.Role().IsSome() {
+					actions = append(actions, domainAudit.ActionRoleChanged)
+				}
+			}
+			auditLog := expectAuditLog(ctrl, actions...)
+			svc := NewUserService(
+				mockRepo,
+				expectUnitOfWork(ctrl, mockRepo, appended, auditLog),
+				mockMetrics,
+				newAuditService(ctrl, auditLog),
+			)
 
 			mockRepo.EXPECT().
 				UpdateUser(gomock.Any(), req).
 				Return(tc.wantUser, tc.wantErr)
 
+			var concurrentModificationErr *domainUser.ConcurrentModificationError
+			if errors.As(tc.wantErr, &concurrentModificationErr) {
+				mockMetrics.EXPECT().ConcurrentModification()
+			}
+
 			gotUser, gotErr := svc.UpdateUser(context.Background(), req)
 
 			assert.Equal(t, tc.wantUser, gotUser)
@@ -247,3 +423,37 @@ func Test_service_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_UpdateUser_NoEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := domainUser.NewUpdateRequest(
+		uuid.New(),
+		etag.Random(),
+		option.None[domainUser.EmailAddress](),
+		option.None[domainUser.PasswordHash](),
+		option.None[domainUser.Username](),
+		option.None[domainUser.Role](),
+	)
+	wantUser := domainUser.RandomUser(t)
+
+	mockRepo := mock_outbound.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().
+		UpdateUser(gomock.Any(), req).
+		Return(wantUser, nil)
+
+	// The outbox expects no messages, so any call to Append fails the test.
+	svc := NewUserService(
+		mockRepo,
+		expectUnitOfWork(ctrl, mockRepo, 0, expectAuditLog(ctrl)),
+		mock_outbound.NewMockMetrics(ctrl),
+		expectAudit(ctrl),
+	)
+
+	gotUser, err := svc.UpdateUser(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, wantUser, gotUser)
+}
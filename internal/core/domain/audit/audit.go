@@ -0,0 +1,238 @@
+// Package audit models the tamper-evident record of security-relevant
+// actions. Entries form a hash chain: each entry's hash covers its contents
+// and the hash of the entry before it, so that modifying or removing an
+// entry breaks the chain from that point on.
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+// Action names a kind of audited action. Actions are stored, so existing
+// actions must never be renamed.
+type Action string
+
+const (
+	ActionUserRegistered Action = "user.registered"
+	ActionLoginSucceeded Action = "auth.login_succeeded"
+	ActionLoginFailed    Action = "auth.login_failed"
+	ActionTokenRefreshed Action = "auth.token_refreshed"
+	ActionSessionRevoked Action = "session.revoked"
+	ActionRoleChanged    Action = "user.role_changed"
+	// ActionAuditQueried is the admin action of reading the audit log.
+	ActionAuditQueried Action = "admin.audit_queried"
+)
+
+// Entry records an action. Entries are created with [NewEntry] and sealed
+// with [Entry.Seal] when they are appended to the chain.
+type Entry struct {
+	// Sequence is the entry's position in the chain, starting at 1.
+	Sequence int64
+	ID       uuid.UUID
+	Action   Action
+	// ActorID is the user who performed the action, or [uuid.Nil] if the
+	// action was performed anonymously.
+	ActorID uuid.UUID
+	// Target is what the action was performed on, such as "user:<id>".
+	Target    string
+	ClientIP  string
+	UserAgent string
+	RequestID string
+	// Details holds action-specific values, such as the new role of a user.
+	Details    map[string]string
+	OccurredAt time.Time
+	// PrevHash is the hash of the previous entry, and is empty for the first.
+	PrevHash []byte
+	Hash     []byte
+}
+
+// NewEntry returns an unsealed entry recording `action` on `target` by the
+// actor described by `source`.
+func NewEntry(
+	action Action,
+	source Source,
+	target string,
+	details map[string]string,
+	now time.Time,
+) *Entry {
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	return &Entry{
+		ID:         uuid.New(),
+		Action:     action,
+		ActorID:    source.ActorID,
+		Target:     target,
+		ClientIP:   source.ClientIP,
+		UserAgent:  source.UserAgent,
+		RequestID:  source.RequestID,
+		Details:    details,
+		OccurredAt: now.UTC().Truncate(time.Microsecond),
+	}
+}
+
+// Seal appends the entry to a chain whose last entry has sequence
+// `prevSequence` and hash `prevHash`. Both are zero for an empty chain.
+func (e *Entry) Seal(prevSequence int64, prevHash []byte) {
+	e.Sequence = prevSequence + 1
+	e.PrevHash = prevHash
+	if e.PrevHash == nil {
+		e.PrevHash = []byte{}
+	}
+	e.Hash = e.ComputeHash()
+}
+
+// hashedEntry is the encoding of an entry that its hash covers. Maps are
+// encoded with sorted keys, so the encoding is deterministic.
+type hashedEntry struct {
+	Sequence   int64             `json:"sequence"`
+	ID         uuid.UUID         `json:"id"`
+	Action     Action            `json:"action"`
+	ActorID    uuid.UUID         `json:"actor_id"`
+	Target     string            `json:"target"`
+	ClientIP   string            `json:"client_ip"`
+	UserAgent  string            `json:"user_agent"`
+	RequestID  string            `json:"request_id"`
+	Details    map[string]string `json:"details"`
+	OccurredAt string            `json:"occurred_at"`
+	PrevHash   string            `json:"prev_hash"`
+}
+
+// ComputeHash returns the SHA-256 hash of the entry's contents and previous
+// hash.
+func (e *Entry) ComputeHash() []byte {
+	details := e.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	// Encoding a struct of strings and a map of strings never fails.
+	encoded, _ := json.Marshal(hashedEntry{
+		Sequence:   e.Sequence,
+		ID:         e.ID,
+		Action:     e.Action,
+		ActorID:    e.ActorID,
+		Target:     e.Target,
+		ClientIP:   e.ClientIP,
+		UserAgent:  e.UserAgent,
+		RequestID:  e.RequestID,
+		Details:    details,
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:   hex.EncodeToString(e.PrevHash),
+	})
+
+	sum := sha256.Sum256(encoded)
+	return sum[:]
+}
+
+// ChainError reports the first entry at which a chain is broken.
+type ChainError struct {
+	Sequence int64
+	Reason   string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit chain broken at entry %d: %s", e.Sequence, e.Reason)
+}
+
+// VerifyChain checks the hash of each entry, and that each entry links to the
+// one before it when their sequences are consecutive. `entries` must be in
+// sequence order; entries filtered out of a query leave gaps, across which
+// links cannot be checked.
+func VerifyChain(entries []*Entry) error {
+	for i, e := range entries {
+		if !bytes.Equal(e.Hash, e.ComputeHash()) {
+			return &ChainError{Sequence: e.Sequence, Reason: "hash does not match contents"}
+		}
+
+		if i == 0 {
+			if e.Sequence == 1 && len(e.PrevHash) != 0 {
+				return &ChainError{Sequence: e.Sequence, Reason: "first entry has a previous hash"}
+			}
+			continue
+		}
+
+		prev := entries[i-1]
+		if e.Sequence <= prev.Sequence {
+			return &ChainError{Sequence: e.Sequence, Reason: "entries out of order"}
+		}
+		if e.Sequence == prev.Sequence+1 && !bytes.Equal(e.PrevHash, prev.Hash) {
+			return &ChainError{Sequence: e.Sequence, Reason: "previous hash does not match"}
+		}
+	}
+
+	return nil
+}
+
+// Filter selects entries of the audit log. Unset fields match every entry.
+type Filter struct {
+	ActorID option.Option[uuid.UUID]
+	Target  option.Option[string]
+	// From and To bound the time of the action, From inclusive and To
+	// exclusive. Zero times leave the range open.
+	From time.Time
+	To   time.Time
+	// AfterSequence and BeforeSequence bound the sequence of the entries, both
+	// exclusive, to page through the log. Zero leaves the range open.
+	AfterSequence  int64
+	BeforeSequence int64
+	// NewestFirst orders the entries by descending rather than ascending
+	// sequence.
+	NewestFirst bool
+	// Limit is the most entries returned, and must be positive.
+	Limit int
+}
+
+// Page holds the entries matching a [Filter], in the order it asks for.
+type Page struct {
+	Entries []*Entry
+	// NextCursor is the sequence to pass as the AfterSequence of the filter,
+	// or its BeforeSequence if it asks for the newest entries first, to get
+	// the entries that follow Entries. It is zero if no entries follow.
+	NextCursor int64
+}
+
+// NewPage returns the page of at most `filter.Limit` of `entries`, which are
+// the entries matching `filter` up to one more than the limit, so that the
+// page knows whether entries follow it.
+func NewPage(filter Filter, entries []*Entry) *Page {
+	if len(entries) <= filter.Limit {
+		return &Page{Entries: entries}
+	}
+
+	entries = entries[:filter.Limit]
+	page := &Page{Entries: entries}
+	if len(entries) > 0 {
+		page.NextCursor = entries[len(entries)-1].Sequence
+	}
+	return page
+}
+
+// UserTarget names a user as the target of an action.
+func UserTarget(id uuid.UUID) string {
+	return "user:" + id.String()
+}
+
+// SessionTarget names a session as the target of an action.
+func SessionTarget(id uuid.UUID) string {
+	return "session:" + id.String()
+}
+
+// EmailTarget names the account an unauthenticated request identifies by
+// email, such as a failed login.
+func EmailTarget(email string) string {
+	return "email:" + email
+}
+
+// AuditLogTarget is the target of admin actions on the audit log itself.
+const AuditLogTarget = "audit_log"
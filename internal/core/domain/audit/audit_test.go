@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sealedChain(t *testing.T, n int) []*Entry {
+	t.Helper()
+
+	source := Source{ActorID: uuid.New(), ClientIP: "10.0.0.1", UserAgent: "test", RequestID: "req"}
+	entries := make([]*Entry, n)
+	var (
+		prevSequence int64
+		prevHash     []byte
+	)
+	for i := range entries {
+		e := NewEntry(ActionRoleChanged, source, UserTarget(uuid.New()), map[string]string{"role": "Admin"}, time.Now())
+		e.Seal(prevSequence, prevHash)
+		prevSequence, prevHash = e.Sequence, e.Hash
+		entries[i] = e
+	}
+	return entries
+}
+
+func TestEntry_Seal(t *testing.T) {
+	t.Parallel()
+
+	entries := sealedChain(t, 2)
+
+	assert.Equal(t, int64(1), entries[0].Sequence)
+	assert.Empty(t, entries[0].PrevHash)
+	assert.Len(t, entries[0].Hash, 32)
+	assert.Equal(t, int64(2), entries[1].Sequence)
+	assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+	assert.Equal(t, entries[1].Hash, entries[1].ComputeHash(), "hash is deterministic")
+}
+
+func TestVerifyChain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		tamper       func(entries []*Entry) []*Entry
+		wantSequence int64
+	}{
+		{
+			name:   "intact",
+			tamper: func(entries []*Entry) []*Entry { return entries },
+		},
+		{
+			name: "gap left by a filter",
+			tamper: func(entries []*Entry) []*Entry {
+				return []*Entry{entries[0], entries[2]}
+			},
+		},
+		{
+			name: "modified details",
+			tamper: func(entries []*Entry) []*Entry {
+				entries[1].Details["role"] = "Reader"
+				return entries
+			},
+			wantSequence: 2,
+		},
+		{
+			name: "modified actor",
+			tamper: func(entries []*Entry) []*Entry {
+				entries[2].ActorID = uuid.New()
+				return entries
+			},
+			wantSequence: 3,
+		},
+		{
+			name: "rehashed after modification",
+			tamper: func(entries []*Entry) []*Entry {
+				entries[1].Target = "user:someone-else"
+				entries[1].Hash = entries[1].ComputeHash()
+				return entries
+			},
+			wantSequence: 3,
+		},
+		{
+			name: "out of order",
+			tamper: func(entries []*Entry) []*Entry {
+				return []*Entry{entries[1], entries[0]}
+			},
+			wantSequence: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyChain(tt.tamper(sealedChain(t, 3)))
+			if tt.wantSequence == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var chainErr *ChainError
+			require.ErrorAs(t, err, &chainErr)
+			assert.Equal(t, tt.wantSequence, chainErr.Sequence)
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	t.Parallel()
+
+	entries := sealedChain(t, 3)
+
+	tests := []struct {
+		name        string
+		entries     []*Entry
+		wantEntries []*Entry
+		wantCursor  int64
+	}{
+		{name: "no entries", entries: nil, wantEntries: nil},
+		{name: "fewer entries than the limit", entries: entries[:1], wantEntries: entries[:1]},
+		{name: "as many entries as the limit", entries: entries[:2], wantEntries: entries[:2]},
+		{name: "more entries than the limit", entries: entries, wantEntries: entries[:2], wantCursor: entries[1].Sequence},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			page := NewPage(Filter{Limit: 2}, tt.entries)
+			assert.Equal(t, tt.wantEntries, page.Entries)
+			assert.Equal(t, tt.wantCursor, page.NextCursor)
+		})
+	}
+}
+
+func TestWithActor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Source{}, SourceFromContext(context.Background()))
+
+	actorID := uuid.New()
+	ctx := WithSource(context.Background(), Source{ClientIP: "10.0.0.1", RequestID: "req"})
+	ctx = WithActor(ctx, actorID)
+
+	assert.Equal(t, Source{ActorID: actorID, ClientIP: "10.0.0.1", RequestID: "req"}, SourceFromContext(ctx))
+}
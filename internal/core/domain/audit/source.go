@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Source describes who performed an action, and the request they performed
+// it with.
+type Source struct {
+	// ActorID is [uuid.Nil] for anonymous requests.
+	ActorID   uuid.UUID
+	ClientIP  string
+	UserAgent string
+	RequestID string
+}
+
+type sourceKey struct{}
+
+// WithSource returns a copy of `ctx` carrying `source`.
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// SourceFromContext returns the source stored in `ctx`, or an anonymous
+// source without request details.
+func SourceFromContext(ctx context.Context) Source {
+	source, _ := ctx.Value(sourceKey{}).(Source)
+	return source
+}
+
+// WithActor returns a copy of `ctx` whose source is performed by `actorID`,
+// for actions that identify their actor, such as logging in.
+func WithActor(ctx context.Context, actorID uuid.UUID) context.Context {
+	source := SourceFromContext(ctx)
+	source.ActorID = actorID
+	return WithSource(ctx, source)
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// ClientIP is the normalized address of the client that opened a session.
+// IPv4-mapped IPv6 addresses are unmapped, and ports and IPv6 zones are
+// dropped, so that the same client always produces the same value. The zero
+// value represents an unknown client.
+type ClientIP struct {
+	addr netip.Addr
+}
+
+// NewClientIP returns the [ClientIP] for `addr`. An invalid `addr` yields the
+// unknown client.
+func NewClientIP(addr netip.Addr) ClientIP {
+	if !addr.IsValid() {
+		return ClientIP{}
+	}
+	return ClientIP{addr: addr.Unmap().WithZone("")}
+}
+
+// ParseClientIP parses an IPv4 or IPv6 address, optionally with a port as
+// stored by earlier versions of the service. An empty candidate yields the
+// unknown client.
+func ParseClientIP(candidate string) (ClientIP, error) {
+	candidate = strings.TrimSpace(candidate)
+	if candidate == "" {
+		return ClientIP{}, nil
+	}
+
+	if addr, err := netip.ParseAddr(candidate); err == nil {
+		return NewClientIP(addr), nil
+	}
+	if addrPort, err := netip.ParseAddrPort(candidate); err == nil {
+		return NewClientIP(addrPort.Addr()), nil
+	}
+
+	return ClientIP{}, NewInvalidClientIPError(candidate)
+}
+
+// Addr returns the underlying address, which is invalid for an unknown client.
+func (c ClientIP) Addr() netip.Addr {
+	return c.addr
+}
+
+// IsUnknown reports whether the client's address is unknown.
+func (c ClientIP) IsUnknown() bool {
+	return !c.addr.IsValid()
+}
+
+// String returns the address in its canonical form, or the empty string for an
+// unknown client.
+func (c ClientIP) String() string {
+	if c.IsUnknown() {
+		return ""
+	}
+	return c.addr.String()
+}
+
+func (c ClientIP) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *ClientIP) UnmarshalText(text []byte) error {
+	parsed, err := ParseClientIP(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
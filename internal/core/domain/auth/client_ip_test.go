@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientIP(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		candidate string
+		expected  string
+		wantErr   error
+	}{
+		{"IPv4", "192.0.2.1", "192.0.2.1", nil},
+		{"IPv4 with port", "192.0.2.1:8080", "192.0.2.1", nil},
+		{"IPv6", "2001:DB8::1", "2001:db8::1", nil},
+		{"IPv6 with port", "[2001:db8::1]:443", "2001:db8::1", nil},
+		{"IPv6 with zone", "fe80::1%eth0", "fe80::1", nil},
+		{"IPv4-mapped IPv6", "::ffff:192.0.2.1", "192.0.2.1", nil},
+		{"Empty", "", "", nil},
+		{"Hostname", "localhost", "", NewInvalidClientIPError("localhost")},
+		{"Garbage", "192.0.2", "", NewInvalidClientIPError("192.0.2")},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientIP, err := ParseClientIP(tc.candidate)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, clientIP.String())
+		})
+	}
+}
+
+func TestNewClientIP(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, NewClientIP(netip.Addr{}).IsUnknown())
+
+	clientIP := NewClientIP(netip.MustParseAddr("::ffff:10.0.0.1"))
+	assert.False(t, clientIP.IsUnknown())
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), clientIP.Addr())
+}
+
+func TestClientIP_JSON(t *testing.T) {
+	t.Parallel()
+
+	clientIP, err := ParseClientIP("2001:db8::1")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(clientIP)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"2001:db8::1"`, string(data))
+
+	var decoded ClientIP
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, clientIP, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`"not-an-ip"`), &decoded))
+}
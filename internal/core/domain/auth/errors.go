@@ -6,6 +6,10 @@ import (
 	"time"
 )
 
+// ErrSessionNotFound should be returned by a session repository when the
+// specified session does not exist.
+var ErrSessionNotFound = errors.New("session not found")
+
 type FieldType int
 
 const (
@@ -16,9 +20,11 @@ const (
 	ExpiredTokenFieldtype
 	SecretKeyFieldType
 	TokenCreationFieldType
+	ClientIPFieldType
+	SessionFieldType
 )
 
-var fieldNames = [7]string{
+var fieldNames = [9]string{
 	"uuid",
 	"token",
 	"time range",
@@ -26,6 +32,8 @@ var fieldNames = [7]string{
 	"expired token",
 	"secret key",
 	"token creation",
+	"client ip",
+	"session",
 }
 
 func (f FieldType) String() string {
@@ -120,3 +128,17 @@ func NewTokenCreationError(reason string) error {
 		Message: fmt.Sprintf("failed to create token: %s", reason),
 	}
 }
+
+func NewInvalidClientIPError(candidate string) error {
+	return &ValidationError{
+		Field:   ClientIPFieldType,
+		Message: fmt.Sprintf("%q is not a valid IP address", candidate),
+	}
+}
+
+func NewInvalidSessionError(reason string) error {
+	return &ValidationError{
+		Field:   SessionFieldType,
+		Message: fmt.Sprintf("invalid session: %s", reason),
+	}
+}
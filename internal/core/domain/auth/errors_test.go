@@ -22,6 +22,8 @@ func TestFieldType_String(t *testing.T) {
 		{"ExpiredTokenFieldtype", ExpiredTokenFieldtype, "expired token"},
 		{"SecretKeyFieldType", SecretKeyFieldType, "secret key"},
 		{"TokenCreationFieldType", TokenCreationFieldType, "token creation"},
+		{"ClientIPFieldType", ClientIPFieldType, "client ip"},
+		{"SessionFieldType", SessionFieldType, "session"},
 		{"Unknown FieldType", FieldType(100), "unknown"},
 	}
 
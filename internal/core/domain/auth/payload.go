@@ -17,15 +17,14 @@ type Payload struct {
 }
 
 // New Payload creates a new token payload with specific username and duration
-func NewPayload(user *user.User, durationStr string) (*Payload, error) {
+func NewPayload(user *user.User, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, NewInvalidUUIDError("invalid UUID creation error")
 	}
 
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		return nil, NewInvalidDurationError(duration, "invalid token duration")
+	if duration <= 0 {
+		return nil, NewInvalidDurationError(duration, "token duration must be positive")
 	}
 
 	payload := &Payload{
@@ -14,9 +14,7 @@ func TestNewPayload(t *testing.T) {
 	t.Parallel()
 
 	testUser := user.RandomUser(t)
-	durationStr := "1h"
-
-	payload, err := NewPayload(testUser, durationStr)
+	payload, err := NewPayload(testUser, time.Hour)
 	require.NoError(t, err)
 
 	assert.NotNil(t, payload)
@@ -74,9 +72,7 @@ func TestNewPayload_InvalidDuration(t *testing.T) {
 	t.Parallel()
 
 	testUser := user.RandomUser(t)
-	durationStr := "invalid"
-
-	payload, err := NewPayload(testUser, durationStr)
+	payload, err := NewPayload(testUser, 0)
 	assert.Error(t, err)
 	assert.Nil(t, payload)
 	assert.IsType(t, &ValidationError{}, err)
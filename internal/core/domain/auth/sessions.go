@@ -11,22 +11,23 @@ type Sessions struct {
 	UserID       uuid.UUID `json:"user_id,omitempty"`
 	RefreshToken string    `json:"refresh_token,omitempty"`
 	UserAgent    string    `json:"user_agent,omitempty"`
-	ClientIP     string    `json:"client_ip,omitempty"`
+	ClientIP     ClientIP  `json:"client_ip"`
 	IsBlocked    bool      `json:"is_blocked,omitempty"`
 	ExpiresAt    time.Time `json:"expires_at,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 }
 
-// create a new session
+// NewSessions creates the session of `refreshPayload`'s refresh token. The
+// session takes the payload's ID, so that the token identifies its session.
 func NewSessions(
 	userID uuid.UUID,
 	refreshToken string,
 	refreshPayload *Payload,
 	userAgent string,
-	clientIP string,
+	clientIP ClientIP,
 ) (*Sessions, error) {
 	return &Sessions{
-		ID:           uuid.New(),
+		ID:           refreshPayload.ID,
 		UserID:       userID,
 		RefreshToken: refreshToken,
 		UserAgent:    userAgent,
@@ -36,3 +37,18 @@ func NewSessions(
 		CreatedAt:    time.Now().UTC(),
 	}, nil
 }
+
+// CheckRefresh reports whether `refreshToken` may renew the session's access
+// at `now`: the session must be unblocked, unexpired and issued for that
+// token.
+func (s *Sessions) CheckRefresh(refreshToken string, now time.Time) error {
+	switch {
+	case s.IsBlocked:
+		return NewInvalidSessionError("session is blocked")
+	case s.RefreshToken != refreshToken:
+		return NewInvalidSessionError("refresh token does not match the session")
+	case !now.Before(s.ExpiresAt):
+		return NewInvalidSessionError("session has expired")
+	}
+	return nil
+}
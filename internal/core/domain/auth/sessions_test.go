@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"net/netip"
 	"testing"
 	"time"
 
@@ -15,10 +16,11 @@ func TestNewSessions(t *testing.T) {
 	userID := uuid.New()
 	refreshToken := "refresh_token"
 	userAgent := "test_user_agent"
-	clientIP := "127.0.0.1"
+	clientIP := NewClientIP(netip.MustParseAddr("127.0.0.1"))
 	expiresAt := time.Now().Add(time.Hour)
 
 	refreshPayload := &Payload{
+		ID:        uuid.New(),
 		ExpiredAt: expiresAt,
 	}
 
@@ -27,7 +29,7 @@ func TestNewSessions(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, session)
 
-	assert.NotEqual(t, uuid.Nil, session.ID)
+	assert.Equal(t, refreshPayload.ID, session.ID)
 	assert.Equal(t, userID, session.UserID)
 	assert.Equal(t, refreshToken, session.RefreshToken)
 	assert.Equal(t, userAgent, session.UserAgent)
@@ -36,3 +38,42 @@ func TestNewSessions(t *testing.T) {
 	assert.Equal(t, expiresAt, session.ExpiresAt)
 	assert.WithinDuration(t, time.Now(), session.CreatedAt, time.Second)
 }
+
+func TestSessions_CheckRefresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	valid := Sessions{RefreshToken: "refresh_token", ExpiresAt: now.Add(time.Hour)}
+
+	testCases := []struct {
+		name    string
+		modify  func(s *Sessions)
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*Sessions) {}, token: "refresh_token"},
+		{name: "blocked", modify: func(s *Sessions) { s.IsBlocked = true }, token: "refresh_token", wantErr: true},
+		{name: "other token", modify: func(*Sessions) {}, token: "other_token", wantErr: true},
+		{name: "expired", modify: func(s *Sessions) { s.ExpiresAt = now }, token: "refresh_token", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := valid
+			tc.modify(&session)
+
+			err := session.CheckRefresh(tc.token, now)
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, SessionFieldType, validationErr.Field)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package event models the domain events published when users and their
+// sessions change.
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+)
+
+// Type names a kind of event. Types are stable identifiers shared with
+// consumers outside the service, so existing types must never be renamed.
+type Type string
+
+const (
+	TypeUserRegistered  Type = "user.registered"
+	TypeUserUpdated     Type = "user.updated"
+	TypePasswordChanged Type = "user.password_changed"
+	TypeSessionCreated  Type = "session.created"
+)
+
+// Event is a fact about a user, encoded for publication.
+type Event struct {
+	ID   uuid.UUID
+	Type Type
+	// UserID is the user the event concerns.
+	UserID     uuid.UUID
+	OccurredAt time.Time
+	// Payload is the JSON encoding of the event's payload type, such as
+	// [UserRegistered] for [TypeUserRegistered].
+	Payload []byte
+}
+
+// UserRegistered is the payload of [TypeUserRegistered].
+type UserRegistered struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+// UserUpdated is the payload of [TypeUserUpdated].
+type UserUpdated struct {
+	// Fields are the names of the changed fields, excluding the password,
+	// whose changes are reported by [TypePasswordChanged].
+	Fields []string `json:"fields"`
+	Role   string   `json:"role"`
+}
+
+// PasswordChanged is the payload of [TypePasswordChanged].
+type PasswordChanged struct {
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// SessionCreated is the payload of [TypeSessionCreated].
+type SessionCreated struct {
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewUserRegistered returns the event recording the registration of `usr`.
+func NewUserRegistered(usr *user.User, now time.Time) (Event, error) {
+	return newEvent(TypeUserRegistered, usr.ID(), now, UserRegistered{
+		Username: usr.Username().String(),
+		Email:    usr.Email().String(),
+		Role:     usr.Role().String(),
+	})
+}
+
+// NewUpdateEvents returns the events recording the update of `updated` by
+// `req`: [TypeUserUpdated] if any field other than the password was changed,
+// and [TypePasswordChanged] if the password was.
+func NewUpdateEvents(
+	req *user.UpdateRequest,
+	updated *user.User,
+	now time.Time,
+) ([]Event, error) {
+	var fields []string
+	if req.Username().IsSome() {
+		fields = append(fields, "username")
+	}
+	if req.Email().IsSome() {
+		fields = append(fields, "email")
+	}
+	if req.Role().IsSome() {
+		fields = append(fields, "role")
+	}
+
+	var events []Event
+	if len(fields) > 0 {
+		e, err := newEvent(TypeUserUpdated, updated.ID(), now, UserUpdated{
+			Fields: fields,
+			Role:   updated.Role().String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	if req.PasswordHash().IsSome() {
+		e, err := newEvent(TypePasswordChanged, updated.ID(), now, PasswordChanged{
+			ChangedAt: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// NewSessionCreated returns the event recording the creation of `session`.
+// The refresh token is never included.
+func NewSessionCreated(session *auth.Sessions, now time.Time) (Event, error) {
+	return newEvent(TypeSessionCreated, session.UserID, now, SessionCreated{
+		UserAgent: session.UserAgent,
+		ClientIP:  session.ClientIP.String(),
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+func newEvent(typ Type, userID uuid.UUID, now time.Time, payload any) (Event, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("encode %s payload: %w", typ, err)
+	}
+
+	return Event{
+		ID:         uuid.New(),
+		Type:       typ,
+		UserID:     userID,
+		OccurredAt: now.UTC().Truncate(time.Microsecond),
+		Payload:    encoded,
+	}, nil
+}
+
+// DecodePayload decodes the payload of `e` into a value of type T, which must
+// be the payload type of the event's [Type].
+func DecodePayload[T any](e Event) (T, error) {
+	var payload T
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return payload, fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+	return payload, nil
+}
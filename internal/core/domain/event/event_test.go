@@ -0,0 +1,157 @@
+package event
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+func randomUser(t *testing.T) *user.User {
+	t.Helper()
+
+	id := uuid.New()
+	now := time.Now()
+	return user.NewUser(
+		id,
+		etag.New(id, now),
+		user.RandomUsername(t),
+		user.RandomEmailAddress(t),
+		user.NewPasswordHashFromTrustedSource([]byte("hash")),
+		user.RoleReader,
+		now,
+		now,
+		now,
+	)
+}
+
+func TestNewUserRegistered(t *testing.T) {
+	t.Parallel()
+
+	usr := randomUser(t)
+	now := time.Now()
+
+	e, err := NewUserRegistered(usr, now)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, uuid.Nil, e.ID)
+	assert.Equal(t, TypeUserRegistered, e.Type)
+	assert.Equal(t, usr.ID(), e.UserID)
+	assert.Equal(t, now.UTC().Truncate(time.Microsecond), e.OccurredAt)
+
+	payload, err := DecodePayload[UserRegistered](e)
+	require.NoError(t, err)
+	assert.Equal(t, UserRegistered{
+		Username: usr.Username().String(),
+		Email:    usr.Email().String(),
+		Role:     usr.Role().String(),
+	}, payload)
+	assert.NotContains(t, string(e.Payload), "hash")
+}
+
+func TestNewUpdateEvents(t *testing.T) {
+	t.Parallel()
+
+	usr := randomUser(t)
+	now := time.Now()
+
+	testCases := []struct {
+		name       string
+		username   option.Option[user.Username]
+		password   option.Option[user.PasswordHash]
+		role       option.Option[user.Role]
+		wantTypes  []Type
+		wantFields []string
+	}{
+		{
+			name:       "profile fields",
+			username:   option.Some(user.RandomUsername(t)),
+			role:       option.Some(user.RoleAdmin),
+			wantTypes:  []Type{TypeUserUpdated},
+			wantFields: []string{"username", "role"},
+		},
+		{
+			name:      "password only",
+			password:  option.Some(user.NewPasswordHashFromTrustedSource([]byte("new"))),
+			wantTypes: []Type{TypePasswordChanged},
+		},
+		{
+			name:       "profile and password",
+			username:   option.Some(user.RandomUsername(t)),
+			password:   option.Some(user.NewPasswordHashFromTrustedSource([]byte("new"))),
+			wantTypes:  []Type{TypeUserUpdated, TypePasswordChanged},
+			wantFields: []string{"username"},
+		},
+		{
+			name: "nothing changed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := user.NewUpdateRequest(
+				usr.ID(),
+				usr.ETag(),
+				option.None[user.EmailAddress](),
+				tc.password,
+				tc.username,
+				tc.role,
+			)
+
+			events, err := NewUpdateEvents(req, usr, now)
+			require.NoError(t, err)
+
+			var types []Type
+			for _, e := range events {
+				types = append(types, e.Type)
+				assert.Equal(t, usr.ID(), e.UserID)
+			}
+			assert.Equal(t, tc.wantTypes, types)
+
+			if len(tc.wantFields) > 0 {
+				payload, err := DecodePayload[UserUpdated](events[0])
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantFields, payload.Fields)
+			}
+		})
+	}
+}
+
+func TestNewSessionCreated(t *testing.T) {
+	t.Parallel()
+
+	session := &auth.Sessions{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		RefreshToken: "secret-refresh-token",
+		UserAgent:    "curl/8.0",
+		ClientIP:     auth.NewClientIP(netip.MustParseAddr("203.0.113.7")),
+		ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond),
+	}
+
+	e, err := NewSessionCreated(session, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, TypeSessionCreated, e.Type)
+	assert.Equal(t, session.UserID, e.UserID)
+	assert.NotContains(t, string(e.Payload), session.RefreshToken)
+
+	payload, err := DecodePayload[SessionCreated](e)
+	require.NoError(t, err)
+	assert.Equal(t, SessionCreated{
+		UserAgent: "curl/8.0",
+		ClientIP:  "203.0.113.7",
+		ExpiresAt: session.ExpiresAt,
+	}, payload)
+}
@@ -0,0 +1,47 @@
+// Package idempotency models the stored outcomes of requests that clients may
+// safely retry.
+package idempotency
+
+import "time"
+
+// Key identifies a request by the principal that made it, the operation it
+// invoked and the client-chosen idempotency key.
+type Key struct {
+	Principal string
+	Method    string
+	Value     string
+}
+
+// Record is the stored outcome of the first request made with a [Key].
+type Record struct {
+	Key Key
+	// Fingerprint is a digest of the request payload. A retry whose payload
+	// has a different fingerprint is a misuse of the key.
+	Fingerprint string
+	// Response is the encoded outcome of the request, or nil while the
+	// request is still being processed.
+	Response []byte
+	// LockedUntil is when the lease on an in-progress request runs out. A
+	// request still in progress after then is presumed to have crashed, and
+	// the key may be reserved again.
+	LockedUntil time.Time
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// IsComplete reports whether the original request has finished and its
+// response was recorded.
+func (r *Record) IsComplete() bool {
+	return r.Response != nil
+}
+
+// IsExpired reports whether the record no longer applies at `now`.
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// IsAbandoned reports whether the original request is still in progress at
+// `now` although its lease has run out.
+func (r *Record) IsAbandoned(now time.Time) bool {
+	return !r.IsComplete() && !now.Before(r.LockedUntil)
+}
@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord_IsComplete(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&Record{}).IsComplete())
+	assert.True(t, (&Record{Response: []byte{}}).IsComplete())
+}
+
+func TestRecord_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	record := &Record{ExpiresAt: now}
+
+	assert.False(t, record.IsExpired(now.Add(-time.Second)))
+	assert.True(t, record.IsExpired(now))
+	assert.True(t, record.IsExpired(now.Add(time.Second)))
+}
+
+func TestRecord_IsAbandoned(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	record := &Record{LockedUntil: now}
+	assert.False(t, record.IsAbandoned(now.Add(-time.Second)))
+	assert.True(t, record.IsAbandoned(now))
+
+	record.Response = []byte{}
+	assert.False(t, record.IsAbandoned(now), "completed records are never abandoned")
+}
@@ -0,0 +1,98 @@
+// Package outbox models events awaiting publication, which are stored in the
+// same transaction as the change they describe and published afterwards.
+package outbox
+
+import (
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+)
+
+// Status is the delivery state of a [Message].
+type Status string
+
+const (
+	// StatusPending messages are published once their next attempt is due.
+	StatusPending Status = "pending"
+	// StatusSent messages were published.
+	StatusSent Status = "sent"
+	// StatusDead messages failed too many times and are no longer retried.
+	// They remain stored for inspection until the outbox cleanup deletes them.
+	StatusDead Status = "dead"
+)
+
+// Message is an event and the state of its publication.
+type Message struct {
+	Event         event.Event
+	Status        Status
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	// SentAt is the zero time until the message is sent.
+	SentAt time.Time
+}
+
+// NewMessage returns a pending message for `e`, due immediately.
+func NewMessage(e event.Event) *Message {
+	return &Message{
+		Event:         e,
+		Status:        StatusPending,
+		NextAttemptAt: e.OccurredAt,
+	}
+}
+
+// IsDue reports whether the message should be published at `now`.
+func (m *Message) IsDue(now time.Time) bool {
+	return m.Status == StatusPending && !m.NextAttemptAt.After(now)
+}
+
+// MarkSent records the publication of the message at `now`.
+func (m *Message) MarkSent(now time.Time) {
+	m.Status = StatusSent
+	m.Attempts++
+	m.LastError = ""
+	m.SentAt = now
+}
+
+// MarkFailed records a failed attempt to publish the message at `now`. The
+// message is scheduled for another attempt as set by `policy`, or is dead if
+// it has exhausted its attempts.
+func (m *Message) MarkFailed(now time.Time, err error, policy RetryPolicy) {
+	m.Attempts++
+	m.LastError = err.Error()
+
+	if m.Attempts >= policy.MaxAttempts {
+		m.Status = StatusDead
+		return
+	}
+	m.NextAttemptAt = now.Add(policy.Backoff(m.Attempts))
+}
+
+// RetryPolicy bounds the attempts to publish a message. The wait before
+// each retry doubles from BaseDelay, but never exceeds MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries for about half an hour before giving up.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 12,
+	BaseDelay:   time.Second,
+	MaxDelay:    15 * time.Minute,
+}
+
+// Backoff returns the wait after failed attempt number `attempts`, starting
+// at 1.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := p.BaseDelay << (attempts - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
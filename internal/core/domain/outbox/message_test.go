@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+)
+
+func TestMessage_IsDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	msg := NewMessage(event.Event{ID: uuid.New(), OccurredAt: now})
+
+	assert.False(t, msg.IsDue(now.Add(-time.Second)))
+	assert.True(t, msg.IsDue(now))
+
+	msg.MarkSent(now)
+	assert.False(t, msg.IsDue(now.Add(time.Hour)), "sent messages are never due")
+}
+
+func TestMessage_MarkFailed(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+	now := time.Now()
+	errPublish := errors.New("broker unavailable")
+	msg := NewMessage(event.Event{ID: uuid.New(), OccurredAt: now})
+
+	msg.MarkFailed(now, errPublish, policy)
+	assert.Equal(t, StatusPending, msg.Status)
+	assert.Equal(t, 1, msg.Attempts)
+	assert.Equal(t, errPublish.Error(), msg.LastError)
+	assert.Equal(t, now.Add(time.Second), msg.NextAttemptAt)
+
+	msg.MarkFailed(now, errPublish, policy)
+	assert.Equal(t, StatusPending, msg.Status)
+	assert.Equal(t, now.Add(2*time.Second), msg.NextAttemptAt)
+
+	msg.MarkFailed(now, errPublish, policy)
+	assert.Equal(t, StatusDead, msg.Status)
+	assert.Equal(t, 3, msg.Attempts)
+	assert.False(t, msg.IsDue(now.Add(time.Hour)), "dead messages are never due")
+}
+
+func TestMessage_MarkSent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	msg := NewMessage(event.Event{ID: uuid.New(), OccurredAt: now})
+	msg.MarkFailed(now, errors.New("timeout"), DefaultRetryPolicy)
+
+	msg.MarkSent(now)
+
+	assert.Equal(t, StatusSent, msg.Status)
+	assert.Equal(t, 2, msg.Attempts)
+	assert.Empty(t, msg.LastError)
+	assert.Equal(t, now, msg.SentAt)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	testCases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{100, 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, policy.Backoff(tc.attempts), "attempts %d", tc.attempts)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,12 +23,15 @@ type EmailAddress struct {
 // ParseEmailAddress returns a new email address from `candidate`, validating
 // that the email address conforms to RFC5332 standards (with the minor
 // divergences introduce by the Go standard library, documented in [net/mail]).
+//
+// Addresses are normalized by trimming surrounding whitespace and lowercasing,
+// so that addresses differing only in case identify the same user.
 func ParseEmailAddress(candidate string) (EmailAddress, error) {
 	if _, err := mail.ParseAddress(candidate); err != nil {
 		return EmailAddress{}, NewEmailAddressFormatError(candidate)
 	}
 
-	return EmailAddress{raw: candidate}, nil
+	return EmailAddress{raw: strings.ToLower(strings.TrimSpace(candidate))}, nil
 }
 
 // String returns the raw email address.
@@ -51,6 +55,11 @@ var (
 	usernameRegex   = regexp.MustCompile(usernamePattern)
 )
 
+// UsernamePattern returns the regular expression usernames must match.
+func UsernamePattern() string {
+	return usernamePattern
+}
+
 // Username represents a valid Username.
 type Username struct {
 	raw string
@@ -32,6 +32,12 @@ func Test_ParseEmailAddress(t *testing.T) {
 			wantEmailAddress: EmailAddress{raw: validEmailCandidate},
 			wantErr:          nil,
 		},
+		{
+			name:             "normalizes case and surrounding whitespace",
+			candidate:        "  Bob.Smith@Example.COM ",
+			wantEmailAddress: EmailAddress{raw: "bob.smith@example.com"},
+			wantErr:          nil,
+		},
 		{
 			name:             "invalid email address",
 			candidate:        "test",
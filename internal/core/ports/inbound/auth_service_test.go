@@ -1,6 +1,7 @@
 package inbound
 
 import (
+	"net/netip"
 	"testing"
 	"time"
 
@@ -20,10 +21,11 @@ func TestNewSessions(t *testing.T) {
 	userID := uuid.New()
 	refreshToken := "refresh_token"
 	userAgent := "test_user_agent"
-	clientIP := "127.0.0.1"
+	clientIP := auth.NewClientIP(netip.MustParseAddr("127.0.0.1"))
 	expiresAt := time.Now().Add(time.Hour)
 
 	refreshPayload := &auth.Payload{
+		ID:        uuid.New(),
 		ExpiredAt: expiresAt,
 	}
 
@@ -32,7 +34,7 @@ func TestNewSessions(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, session)
 
-	assert.NotEqual(t, uuid.Nil, session.ID)
+	assert.Equal(t, refreshPayload.ID, session.ID)
 	assert.Equal(t, userID, session.UserID)
 	assert.Equal(t, refreshToken, session.RefreshToken)
 	assert.Equal(t, userAgent, session.UserAgent)
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/ports/inbound/auth_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/ports/inbound/auth_service.go -destination=internal/core/ports/inbound/mock/mock_auth_service.go -package=mock_repo
+//
+
+// Package mock_repo is a generated GoMock package.
+package mock_repo
+
+import (
+	reflect "reflect"
+	time "time"
+
+	auth "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	user "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenService is a mock of TokenService interface.
+type MockTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenServiceMockRecorder
+}
+
+// MockTokenServiceMockRecorder is the mock recorder for MockTokenService.
+type MockTokenServiceMockRecorder struct {
+	mock *MockTokenService
+}
+
+// NewMockTokenService creates a new mock instance.
+func NewMockTokenService(ctrl *gomock.Controller) *MockTokenService {
+	mock := &MockTokenService{ctrl: ctrl}
+	mock.recorder = &MockTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenService) EXPECT() *MockTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockTokenService) CreateToken(user *user.User, duration time.Duration) (string, *auth.Payload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", user, duration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*auth.Payload)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockTokenServiceMockRecorder) CreateToken(user, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockTokenService)(nil).CreateToken), user, duration)
+}
+
+// VerifyToken mocks base method.
+func (m *MockTokenService) VerifyToken(token string) (*auth.Payload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyToken", token)
+	ret0, _ := ret[0].(*auth.Payload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyToken indicates an expected call of VerifyToken.
+func (mr *MockTokenServiceMockRecorder) VerifyToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockTokenService)(nil).VerifyToken), token)
+}
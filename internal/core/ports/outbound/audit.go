@@ -0,0 +1,23 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+)
+
+// AuditRecorder appends entries to the audit log.
+type AuditRecorder interface {
+	// Record seals `entry` onto the end of the chain and stores it. Concurrent
+	// calls, including those of other replicas, are serialized so that every
+	// entry links to the one stored before it.
+	Record(ctx context.Context, entry *audit.Entry) error
+}
+
+// AuditLogger stores the audit log as a hash chain.
+type AuditLogger interface {
+	AuditRecorder
+	// Query returns the entries matching `filter` in ascending sequence order,
+	// or descending if it asks for the newest entries first.
+	Query(ctx context.Context, filter audit.Filter) ([]*audit.Entry, error)
+}
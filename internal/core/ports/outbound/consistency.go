@@ -0,0 +1,20 @@
+package outbound
+
+import "context"
+
+type readYourWritesKey struct{}
+
+// WithReadYourWrites returns a context whose repository reads observe every
+// write committed before them. Use it for requests that read data they have
+// just written: stores that replicate asynchronously serve such reads from
+// the primary rather than a replica that may lag behind.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// ReadYourWrites reports whether reads made with `ctx` must observe all
+// committed writes.
+func ReadYourWrites(ctx context.Context) bool {
+	required, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return required
+}
@@ -0,0 +1,25 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/idempotency"
+)
+
+// IdempotencyStore persists the outcomes of idempotent requests.
+type IdempotencyStore interface {
+	// Reserve claims `record.Key` for a request that is about to be processed.
+	// If the key is already held by an unexpired record, that record is
+	// returned and nothing is stored. Expired and abandoned records are
+	// replaced.
+	Reserve(ctx context.Context, record *idempotency.Record) (existing *idempotency.Record, err error)
+	// Complete records the response of the request holding `key`.
+	Complete(ctx context.Context, key idempotency.Key, response []byte) error
+	// Release discards the reservation of `key`, allowing the request to be
+	// retried.
+	Release(ctx context.Context, key idempotency.Key) error
+	// DeleteExpired removes records that expired before `now`, returning the
+	// number removed.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,11 @@
+package outbound
+
+import "context"
+
+// JobLocker ensures that a background job runs on one replica at a time.
+type JobLocker interface {
+	// RunExclusive calls `fn` if no other caller, in this or another replica,
+	// holds the lock `name`, and holds the lock until `fn` returns. It reports
+	// whether `fn` was called, and returns the error of `fn`.
+	RunExclusive(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
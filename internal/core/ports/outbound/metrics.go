@@ -0,0 +1,46 @@
+package outbound
+
+import "time"
+
+// Metrics records domain-level counters that are independent of the transport
+// or storage in use.
+type Metrics interface {
+	// UserRegistered records a successful user registration.
+	UserRegistered()
+
+	// LoginSucceeded records a successful authentication attempt.
+	LoginSucceeded()
+
+	// LoginFailed records an authentication attempt rejected with an
+	// [user.AuthError].
+	LoginFailed()
+
+	// SessionCreated records the persistence of a new session.
+	SessionCreated()
+
+	// ConcurrentModification records an update rejected with a
+	// [user.ConcurrentModificationError].
+	ConcurrentModification()
+
+	// AuditRecordFailed records an action that happened but could not be
+	// recorded in the audit log.
+	AuditRecordFailed()
+}
+
+// JobResult is the outcome of a run of a background job.
+type JobResult string
+
+const (
+	JobSucceeded JobResult = "success"
+	JobFailed    JobResult = "failure"
+	// JobSkipped is the result of a run that found the job already running on
+	// another replica.
+	JobSkipped JobResult = "skipped"
+)
+
+// JobMetrics records the runs of background jobs.
+type JobMetrics interface {
+	// JobRun records a run of `job` that took `duration` and processed
+	// `processed` items.
+	JobRun(job string, result JobResult, duration time.Duration, processed int64)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/ports/outbound/job_lock.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/ports/outbound/job_lock.go -destination=internal/core/ports/outbound/mock/mock_job_lock.go -package=mock_repo
+//
+
+// Package mock_repo is a generated GoMock package.
+package mock_repo
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobLocker is a mock of JobLocker interface.
+type MockJobLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobLockerMockRecorder
+}
+
+// MockJobLockerMockRecorder is the mock recorder for MockJobLocker.
+type MockJobLockerMockRecorder struct {
+	mock *MockJobLocker
+}
+
+// NewMockJobLocker creates a new mock instance.
+func NewMockJobLocker(ctrl *gomock.Controller) *MockJobLocker {
+	mock := &MockJobLocker{ctrl: ctrl}
+	mock.recorder = &MockJobLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobLocker) EXPECT() *MockJobLockerMockRecorder {
+	return m.recorder
+}
+
+// RunExclusive mocks base method.
+func (m *MockJobLocker) RunExclusive(ctx context.Context, name string, fn func(context.Context) error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunExclusive", ctx, name, fn)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunExclusive indicates an expected call of RunExclusive.
+func (mr *MockJobLockerMockRecorder) RunExclusive(ctx, name, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunExclusive", reflect.TypeOf((*MockJobLocker)(nil).RunExclusive), ctx, name, fn)
+}
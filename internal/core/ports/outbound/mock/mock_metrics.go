@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/ports/outbound/metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/ports/outbound/metrics.go -destination=internal/core/ports/outbound/mock/mock_metrics.go -package=mock_repo
+//
+
+// Package mock_repo is a generated GoMock package.
+package mock_repo
+
+import (
+	reflect "reflect"
+	time "time"
+
+	outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// AuditRecordFailed mocks base method.
+func (m *MockMetrics) AuditRecordFailed() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AuditRecordFailed")
+}
+
+// AuditRecordFailed indicates an expected call of AuditRecordFailed.
+func (mr *MockMetricsMockRecorder) AuditRecordFailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditRecordFailed", reflect.TypeOf((*MockMetrics)(nil).AuditRecordFailed))
+}
+
+// ConcurrentModification mocks base method.
+func (m *MockMetrics) ConcurrentModification() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ConcurrentModification")
+}
+
+// ConcurrentModification indicates an expected call of ConcurrentModification.
+func (mr *MockMetricsMockRecorder) ConcurrentModification() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConcurrentModification", reflect.TypeOf((*MockMetrics)(nil).ConcurrentModification))
+}
+
+// LoginFailed mocks base method.
+func (m *MockMetrics) LoginFailed() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LoginFailed")
+}
+
+// LoginFailed indicates an expected call of LoginFailed.
+func (mr *MockMetricsMockRecorder) LoginFailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginFailed", reflect.TypeOf((*MockMetrics)(nil).LoginFailed))
+}
+
+// LoginSucceeded mocks base method.
+func (m *MockMetrics) LoginSucceeded() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LoginSucceeded")
+}
+
+// LoginSucceeded indicates an expected call of LoginSucceeded.
+func (mr *MockMetricsMockRecorder) LoginSucceeded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginSucceeded", reflect.TypeOf((*MockMetrics)(nil).LoginSucceeded))
+}
+
+// SessionCreated mocks base method.
+func (m *MockMetrics) SessionCreated() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SessionCreated")
+}
+
+// SessionCreated indicates an expected call of SessionCreated.
+func (mr *MockMetricsMockRecorder) SessionCreated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionCreated", reflect.TypeOf((*MockMetrics)(nil).SessionCreated))
+}
+
+// UserRegistered mocks base method.
+func (m *MockMetrics) UserRegistered() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UserRegistered")
+}
+
+// UserRegistered indicates an expected call of UserRegistered.
+func (mr *MockMetricsMockRecorder) UserRegistered() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRegistered", reflect.TypeOf((*MockMetrics)(nil).UserRegistered))
+}
+
+// MockJobMetrics is a mock of JobMetrics interface.
+type MockJobMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobMetricsMockRecorder
+}
+
+// MockJobMetricsMockRecorder is the mock recorder for MockJobMetrics.
+type MockJobMetricsMockRecorder struct {
+	mock *MockJobMetrics
+}
+
+// NewMockJobMetrics creates a new mock instance.
+func NewMockJobMetrics(ctrl *gomock.Controller) *MockJobMetrics {
+	mock := &MockJobMetrics{ctrl: ctrl}
+	mock.recorder = &MockJobMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobMetrics) EXPECT() *MockJobMetricsMockRecorder {
+	return m.recorder
+}
+
+// JobRun mocks base method.
+func (m *MockJobMetrics) JobRun(job string, result outbound.JobResult, duration time.Duration, processed int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "JobRun", job, result, duration, processed)
+}
+
+// JobRun indicates an expected call of JobRun.
+func (mr *MockJobMetricsMockRecorder) JobRun(job, result, duration, processed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobRun", reflect.TypeOf((*MockJobMetrics)(nil).JobRun), job, result, duration, processed)
+}
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/ports/outbound/outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/ports/outbound/outbox.go -destination=internal/core/ports/outbound/mock/mock_outbox.go -package=mock_repo
+//
+
+// Package mock_repo is a generated GoMock package.
+package mock_repo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	event "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	outbox "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOutboxRepository) Append(ctx context.Context, messages ...*outbox.Message) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range messages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOutboxRepositoryMockRecorder) Append(ctx any, messages ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, messages...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOutboxRepository)(nil).Append), varargs...)
+}
+
+// MockOutboxRelayStore is a mock of OutboxRelayStore interface.
+type MockOutboxRelayStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRelayStoreMockRecorder
+}
+
+// MockOutboxRelayStoreMockRecorder is the mock recorder for MockOutboxRelayStore.
+type MockOutboxRelayStoreMockRecorder struct {
+	mock *MockOutboxRelayStore
+}
+
+// NewMockOutboxRelayStore creates a new mock instance.
+func NewMockOutboxRelayStore(ctrl *gomock.Controller) *MockOutboxRelayStore {
+	mock := &MockOutboxRelayStore{ctrl: ctrl}
+	mock.recorder = &MockOutboxRelayStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRelayStore) EXPECT() *MockOutboxRelayStoreMockRecorder {
+	return m.recorder
+}
+
+// ProcessOutbox mocks base method.
+func (m *MockOutboxRelayStore) ProcessOutbox(ctx context.Context, now time.Time, limit int, process func(context.Context, []*outbox.Message) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessOutbox", ctx, now, limit, process)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessOutbox indicates an expected call of ProcessOutbox.
+func (mr *MockOutboxRelayStoreMockRecorder) ProcessOutbox(ctx, now, limit, process any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessOutbox", reflect.TypeOf((*MockOutboxRelayStore)(nil).ProcessOutbox), ctx, now, limit, process)
+}
+
+// MockOutboxCleaner is a mock of OutboxCleaner interface.
+type MockOutboxCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxCleanerMockRecorder
+}
+
+// MockOutboxCleanerMockRecorder is the mock recorder for MockOutboxCleaner.
+type MockOutboxCleanerMockRecorder struct {
+	mock *MockOutboxCleaner
+}
+
+// NewMockOutboxCleaner creates a new mock instance.
+func NewMockOutboxCleaner(ctrl *gomock.Controller) *MockOutboxCleaner {
+	mock := &MockOutboxCleaner{ctrl: ctrl}
+	mock.recorder = &MockOutboxCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxCleaner) EXPECT() *MockOutboxCleanerMockRecorder {
+	return m.recorder
+}
+
+// DeleteFinishedOutboxMessages mocks base method.
+func (m *MockOutboxCleaner) DeleteFinishedOutboxMessages(ctx context.Context, sentBefore, deadBefore time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedOutboxMessages", ctx, sentBefore, deadBefore, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedOutboxMessages indicates an expected call of DeleteFinishedOutboxMessages.
+func (mr *MockOutboxCleanerMockRecorder) DeleteFinishedOutboxMessages(ctx, sentBefore, deadBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedOutboxMessages", reflect.TypeOf((*MockOutboxCleaner)(nil).DeleteFinishedOutboxMessages), ctx, sentBefore, deadBefore, limit)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), ctx, e)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/ports/outbound/session_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/ports/outbound/session_repository.go -destination=internal/core/ports/outbound/mock/mock_session_repository.go -package=mock_repo
+//
+
+// Package mock_repo is a generated GoMock package.
+package mock_repo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	auth "github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionRepository) CreateSession(ctx context.Context, session *auth.Sessions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionRepositoryMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionRepository)(nil).CreateSession), ctx, session)
+}
+
+// DeleteSession mocks base method.
+func (m *MockSessionRepository) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockSessionRepositoryMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSession), ctx, id)
+}
+
+// GetSession mocks base method.
+func (m *MockSessionRepository) GetSession(ctx context.Context, id uuid.UUID) (*auth.Sessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(*auth.Sessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockSessionRepositoryMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockSessionRepository)(nil).GetSession), ctx, id)
+}
+
+// GetSessionByUserID mocks base method.
+func (m *MockSessionRepository) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*auth.Sessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByUserID", ctx, userID)
+	ret0, _ := ret[0].(*auth.Sessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByUserID indicates an expected call of GetSessionByUserID.
+func (mr *MockSessionRepositoryMockRecorder) GetSessionByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByUserID", reflect.TypeOf((*MockSessionRepository)(nil).GetSessionByUserID), ctx, userID)
+}
+
+// MockSessionCleaner is a mock of SessionCleaner interface.
+type MockSessionCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionCleanerMockRecorder
+}
+
+// MockSessionCleanerMockRecorder is the mock recorder for MockSessionCleaner.
+type MockSessionCleanerMockRecorder struct {
+	mock *MockSessionCleaner
+}
+
+// NewMockSessionCleaner creates a new mock instance.
+func NewMockSessionCleaner(ctrl *gomock.Controller) *MockSessionCleaner {
+	mock := &MockSessionCleaner{ctrl: ctrl}
+	mock.recorder = &MockSessionCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionCleaner) EXPECT() *MockSessionCleanerMockRecorder {
+	return m.recorder
+}
+
+// DeleteStaleSessions mocks base method.
+func (m *MockSessionCleaner) DeleteStaleSessions(ctx context.Context, expiredBefore, blockedBefore time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleSessions", ctx, expiredBefore, blockedBefore, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleSessions indicates an expected call of DeleteStaleSessions.
+func (mr *MockSessionCleanerMockRecorder) DeleteStaleSessions(ctx, expiredBefore, blockedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleSessions", reflect.TypeOf((*MockSessionCleaner)(nil).DeleteStaleSessions), ctx, expiredBefore, blockedBefore, limit)
+}
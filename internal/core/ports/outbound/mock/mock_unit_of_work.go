@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/ports/outbound/unit_of_work.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/ports/outbound/unit_of_work.go -destination=internal/core/ports/outbound/mock/mock_unit_of_work.go -package=mock_repo
+//
+
+// Package mock_repo is a generated GoMock package.
+package mock_repo
+
+import (
+	context "context"
+	reflect "reflect"
+
+	outbound "github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepositories is a mock of Repositories interface.
+type MockRepositories struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoriesMockRecorder
+}
+
+// MockRepositoriesMockRecorder is the mock recorder for MockRepositories.
+type MockRepositoriesMockRecorder struct {
+	mock *MockRepositories
+}
+
+// NewMockRepositories creates a new mock instance.
+func NewMockRepositories(ctrl *gomock.Controller) *MockRepositories {
+	mock := &MockRepositories{ctrl: ctrl}
+	mock.recorder = &MockRepositoriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositories) EXPECT() *MockRepositoriesMockRecorder {
+	return m.recorder
+}
+
+// AuditLog mocks base method.
+func (m *MockRepositories) AuditLog() outbound.AuditRecorder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog")
+	ret0, _ := ret[0].(outbound.AuditRecorder)
+	return ret0
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockRepositoriesMockRecorder) AuditLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockRepositories)(nil).AuditLog))
+}
+
+// Outbox mocks base method.
+func (m *MockRepositories) Outbox() outbound.OutboxRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Outbox")
+	ret0, _ := ret[0].(outbound.OutboxRepository)
+	return ret0
+}
+
+// Outbox indicates an expected call of Outbox.
+func (mr *MockRepositoriesMockRecorder) Outbox() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Outbox", reflect.TypeOf((*MockRepositories)(nil).Outbox))
+}
+
+// Sessions mocks base method.
+func (m *MockRepositories) Sessions() outbound.SessionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].(outbound.SessionRepository)
+	return ret0
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockRepositoriesMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockRepositories)(nil).Sessions))
+}
+
+// Users mocks base method.
+func (m *MockRepositories) Users() outbound.UserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users")
+	ret0, _ := ret[0].(outbound.UserRepository)
+	return ret0
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockRepositoriesMockRecorder) Users() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockRepositories)(nil).Users))
+}
+
+// MockUnitOfWork is a mock of UnitOfWork interface.
+type MockUnitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnitOfWorkMockRecorder
+}
+
+// MockUnitOfWorkMockRecorder is the mock recorder for MockUnitOfWork.
+type MockUnitOfWorkMockRecorder struct {
+	mock *MockUnitOfWork
+}
+
+// NewMockUnitOfWork creates a new mock instance.
+func NewMockUnitOfWork(ctrl *gomock.Controller) *MockUnitOfWork {
+	mock := &MockUnitOfWork{ctrl: ctrl}
+	mock.recorder = &MockUnitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnitOfWork) EXPECT() *MockUnitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(outbound.Repositories) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockUnitOfWorkMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockUnitOfWork)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,276 @@
+package outboundtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/audit"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+// AuditStore is an adapter under test that records audit entries both on
+// their own and in units of work.
+type AuditStore interface {
+	outbound.UnitOfWork
+	outbound.AuditLogger
+}
+
+// NewAuditStore returns the adapter under test, as [NewRepositories] does.
+// Tests only query the entries they record, so the adapter may be shared.
+type NewAuditStore func(t *testing.T) AuditStore
+
+// RunAuditLogger runs the [outbound.AuditLogger] contract tests, and those of
+// [outbound.Repositories.AuditLog].
+func RunAuditLogger(t *testing.T, newStore NewAuditStore) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, store AuditStore)
+	}{
+		{"Record seals entries onto the chain", testAuditRecordSeals},
+		{"Query filters entries", testAuditQueryFilters},
+		{"Record serializes concurrent entries", testAuditRecordParallel},
+		{"Do commits recorded entries", testAuditUnitOfWorkCommits},
+		{"Do rolls back recorded entries", testAuditUnitOfWorkRollsBack},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func testAuditRecordSeals(t *testing.T, logger AuditStore) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	entries := recordEntries(t, logger, actorID, timestamp(), 3)
+
+	for i, entry := range entries {
+		assert.Equal(t, entry.ComputeHash(), entry.Hash)
+		if i > 0 {
+			assert.Greater(t, entry.Sequence, entries[i-1].Sequence)
+		}
+	}
+
+	got, err := logger.Query(ctx, audit.Filter{ActorID: option.Some(actorID), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, got, len(entries))
+	for i, entry := range entries {
+		assertSameEntry(t, entry, got[i])
+	}
+	assert.NoError(t, audit.VerifyChain(got))
+}
+
+func testAuditQueryFilters(t *testing.T, logger AuditStore) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	start := timestamp()
+	entries := recordEntries(t, logger, actorID, start, 3)
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+		want   []*audit.Entry
+	}{
+		{
+			name:   "target",
+			filter: audit.Filter{Target: option.Some(entries[1].Target), Limit: 10},
+			want:   entries[1:2],
+		},
+		{
+			name: "time range",
+			filter: audit.Filter{
+				ActorID: option.Some(actorID),
+				From:    start.Add(time.Second),
+				To:      start.Add(2 * time.Second),
+				Limit:   10,
+			},
+			want: entries[1:2],
+		},
+		{
+			name:   "open-ended time range",
+			filter: audit.Filter{ActorID: option.Some(actorID), From: start.Add(time.Second), Limit: 10},
+			want:   entries[1:],
+		},
+		{
+			name:   "limit",
+			filter: audit.Filter{ActorID: option.Some(actorID), Limit: 2},
+			want:   entries[:2],
+		},
+		{
+			name:   "after sequence",
+			filter: audit.Filter{ActorID: option.Some(actorID), AfterSequence: entries[0].Sequence, Limit: 10},
+			want:   entries[1:],
+		},
+		{
+			name:   "before sequence",
+			filter: audit.Filter{ActorID: option.Some(actorID), BeforeSequence: entries[2].Sequence, Limit: 10},
+			want:   entries[:2],
+		},
+		{
+			name:   "newest first",
+			filter: audit.Filter{ActorID: option.Some(actorID), NewestFirst: true, Limit: 2},
+			want:   []*audit.Entry{entries[2], entries[1]},
+		},
+		{
+			name: "newest first before sequence",
+			filter: audit.Filter{
+				ActorID:        option.Some(actorID),
+				BeforeSequence: entries[1].Sequence,
+				NewestFirst:    true,
+				Limit:          10,
+			},
+			want: entries[:1],
+		},
+		{
+			name:   "no match",
+			filter: audit.Filter{ActorID: option.Some(uuid.New()), Limit: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := logger.Query(ctx, tt.filter)
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i, entry := range tt.want {
+				assertSameEntry(t, entry, got[i])
+			}
+		})
+	}
+}
+
+// testAuditRecordParallel records entries concurrently, which must each link
+// to a different predecessor: two entries with the same predecessor fork the
+// chain.
+func testAuditRecordParallel(t *testing.T, logger AuditStore) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	now := timestamp()
+
+	errs := runParallel(func(int) error {
+		entry := audit.NewEntry(audit.ActionLoginSucceeded, audit.Source{ActorID: actorID}, audit.UserTarget(actorID), nil, now)
+		return logger.Record(ctx, entry)
+	})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	got, err := logger.Query(ctx, audit.Filter{ActorID: option.Some(actorID), Limit: 2 * parallelWriters})
+	require.NoError(t, err)
+	require.Len(t, got, parallelWriters)
+	require.NoError(t, audit.VerifyChain(got))
+
+	prevHashes := make(map[string]int64, len(got))
+	for _, entry := range got {
+		if seq, ok := prevHashes[string(entry.PrevHash)]; ok {
+			t.Fatalf("entries %d and %d have the same predecessor", seq, entry.Sequence)
+		}
+		prevHashes[string(entry.PrevHash)] = entry.Sequence
+	}
+}
+
+// testAuditUnitOfWorkCommits records an entry in a unit of work, which must be
+// sealed onto the chain after the entries recorded before it.
+func testAuditUnitOfWorkCommits(t *testing.T, store AuditStore) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	before := recordEntries(t, store, actorID, timestamp(), 1)
+
+	entry := audit.NewEntry(audit.ActionSessionRevoked, audit.Source{ActorID: actorID}, audit.SessionTarget(uuid.New()), nil, timestamp())
+	err := store.Do(ctx, func(tx outbound.Repositories) error {
+		return tx.AuditLog().Record(ctx, entry)
+	})
+	require.NoError(t, err)
+
+	got, err := store.Query(ctx, audit.Filter{ActorID: option.Some(actorID), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assertSameEntry(t, before[0], got[0])
+	assertSameEntry(t, entry, got[1])
+	assert.Greater(t, got[1].Sequence, got[0].Sequence)
+	assert.Equal(t, entry.ComputeHash(), got[1].Hash)
+}
+
+// testAuditUnitOfWorkRollsBack records an entry in a unit of work that then
+// fails. The entry may not be stored.
+func testAuditUnitOfWorkRollsBack(t *testing.T, store AuditStore) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	errAbort := errors.New("abort")
+
+	err := store.Do(ctx, func(tx outbound.Repositories) error {
+		entry := audit.NewEntry(audit.ActionSessionRevoked, audit.Source{ActorID: actorID}, audit.SessionTarget(uuid.New()), nil, timestamp())
+		if err := tx.AuditLog().Record(ctx, entry); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	got, err := store.Query(ctx, audit.Filter{ActorID: option.Some(actorID), Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+// recordEntries records `n` entries by `actorID` on distinct targets, a second
+// apart starting at `start`.
+func recordEntries(
+	t *testing.T,
+	logger outbound.AuditLogger,
+	actorID uuid.UUID,
+	start time.Time,
+	n int,
+) []*audit.Entry {
+	t.Helper()
+
+	source := audit.Source{
+		ActorID:   actorID,
+		ClientIP:  "192.0.2.1",
+		UserAgent: "outboundtest",
+		RequestID: uuid.NewString(),
+	}
+
+	entries := make([]*audit.Entry, n)
+	for i := range entries {
+		entries[i] = audit.NewEntry(
+			audit.ActionRoleChanged,
+			source,
+			audit.UserTarget(uuid.New()),
+			map[string]string{"role": "Admin"},
+			start.Add(time.Duration(i)*time.Second),
+		)
+		require.NoError(t, logger.Record(context.Background(), entries[i]))
+	}
+
+	return entries
+}
+
+func assertSameEntry(t *testing.T, want, got *audit.Entry) {
+	t.Helper()
+
+	assert.Equal(t, want.Sequence, got.Sequence)
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.Action, got.Action)
+	assert.Equal(t, want.ActorID, got.ActorID)
+	assert.Equal(t, want.Target, got.Target)
+	assert.Equal(t, want.ClientIP, got.ClientIP)
+	assert.Equal(t, want.UserAgent, got.UserAgent)
+	assert.Equal(t, want.RequestID, got.RequestID)
+	assert.Equal(t, want.Details, got.Details)
+	assert.True(t, want.OccurredAt.Equal(got.OccurredAt))
+	assert.Equal(t, want.PrevHash, got.PrevHash)
+	assert.Equal(t, want.Hash, got.Hash)
+}
@@ -0,0 +1,48 @@
+// Package outboundtest provides contract tests for the outbound repository
+// ports. Each adapter runs the same suite, so that every implementation
+// reports the errors documented on the ports and resolves conflicting writes
+// the same way.
+//
+// An adapter's tests call [Run] with a function returning a ready-to-use
+// instance:
+//
+//	func TestContract(t *testing.T) {
+//		t.Parallel()
+//		outboundtest.Run(t, func(t *testing.T) outboundtest.Repositories {
+//			return memory.New()
+//		})
+//	}
+package outboundtest
+
+import (
+	"testing"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// Repositories is an adapter under test. Sessions reference users, so both
+// ports must be served by the same store.
+type Repositories interface {
+	outbound.UserRepository
+	outbound.SessionRepository
+}
+
+// NewRepositories returns the adapter under test. It is called once per test
+// case and may return a shared instance: the suite only relies on the records
+// it creates itself, which use random usernames and emails.
+type NewRepositories func(t *testing.T) Repositories
+
+// Run runs the [outbound.UserRepository] and [outbound.SessionRepository]
+// contract tests against the adapter returned by `newRepos`.
+func Run(t *testing.T, newRepos NewRepositories) {
+	t.Helper()
+
+	t.Run("UserRepository", func(t *testing.T) {
+		t.Parallel()
+		RunUserRepository(t, newRepos)
+	})
+	t.Run("SessionRepository", func(t *testing.T) {
+		t.Parallel()
+		RunSessionRepository(t, newRepos)
+	})
+}
@@ -0,0 +1,73 @@
+package outboundtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// NewJobLocker returns the adapter under test, as [NewRepositories] does.
+// Tests use random lock names, so the adapter may be shared.
+type NewJobLocker func(t *testing.T) outbound.JobLocker
+
+// RunJobLocker runs the [outbound.JobLocker] contract tests.
+func RunJobLocker(t *testing.T, newLocker NewJobLocker) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, locker outbound.JobLocker)
+	}{
+		{"RunExclusive excludes other callers", testRunExclusive},
+		{"RunExclusive releases the lock when fn fails", testRunExclusiveError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, newLocker(t))
+		})
+	}
+}
+
+func testRunExclusive(t *testing.T, locker outbound.JobLocker) {
+	ctx := context.Background()
+	name := uuid.NewString()
+
+	ran, err := locker.RunExclusive(ctx, name, func(ctx context.Context) error {
+		ran, err := locker.RunExclusive(ctx, name, func(context.Context) error {
+			t.Error("fn called while the lock is held")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, ran, "same lock")
+
+		ran, err = locker.RunExclusive(ctx, uuid.NewString(), func(context.Context) error { return nil })
+		require.NoError(t, err)
+		assert.True(t, ran, "other lock")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func testRunExclusiveError(t *testing.T, locker outbound.JobLocker) {
+	ctx := context.Background()
+	name := uuid.NewString()
+	errJob := errors.New("job failed")
+
+	ran, err := locker.RunExclusive(ctx, name, func(context.Context) error { return errJob })
+	require.ErrorIs(t, err, errJob)
+	assert.True(t, ran)
+
+	ran, err = locker.RunExclusive(ctx, name, func(context.Context) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, ran, "lock released")
+}
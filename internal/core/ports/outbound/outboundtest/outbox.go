@@ -0,0 +1,293 @@
+package outboundtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// OutboxStore is an adapter under test that stores outbox messages in units
+// of work and hands them to a relay.
+type OutboxStore interface {
+	outbound.UnitOfWork
+	outbound.OutboxRelayStore
+}
+
+// NewOutboxStore returns the adapter under test, as [NewRepositories] does.
+type NewOutboxStore func(t *testing.T) OutboxStore
+
+// outboxLimit is the number of messages claimed by the tests. Messages left
+// in a shared store by other tests are claimed too, but never modified.
+const outboxLimit = 100
+
+var errPublish = errors.New("publish failed")
+
+// RunOutbox runs the [outbound.OutboxRepository] and
+// [outbound.OutboxRelayStore] contract tests. The tests run one after the
+// other, since every relay call claims the messages of concurrent tests.
+func RunOutbox(t *testing.T, newStore NewOutboxStore) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, store OutboxStore)
+	}{
+		{"Append is committed with the unit of work", testOutboxAppendCommits},
+		{"Append is rolled back with the unit of work", testOutboxAppendRollsBack},
+		{"ProcessOutbox saves sent messages", testProcessOutboxSent},
+		{"ProcessOutbox reschedules failed messages", testProcessOutboxRetry},
+		{"ProcessOutbox never claims dead messages", testProcessOutboxDead},
+		{"ProcessOutbox saves nothing if processing fails", testProcessOutboxError},
+		{"ProcessOutbox is exclusive", testProcessOutboxParallel},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func testOutboxAppendCommits(t *testing.T, store OutboxStore) {
+	now := timestamp()
+	messages := appendMessages(t, store, now, 2)
+
+	claimed := processOwn(t, store, now, messages, func(msg *outbox.Message) {
+		msg.MarkSent(now)
+	})
+	require.Len(t, claimed, len(messages))
+	for i, msg := range messages {
+		assertSameMessage(t, msg, claimed[i])
+	}
+}
+
+func testOutboxAppendRollsBack(t *testing.T, store OutboxStore) {
+	ctx := context.Background()
+	now := timestamp()
+	msg := outbox.NewMessage(randomEvent(now))
+	errAbort := errors.New("abort")
+
+	err := store.Do(ctx, func(tx outbound.Repositories) error {
+		if err := tx.Outbox().Append(ctx, msg); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	assert.Empty(t, processOwn(t, store, now, []*outbox.Message{msg}, nil))
+}
+
+func testProcessOutboxSent(t *testing.T, store OutboxStore) {
+	now := timestamp()
+	messages := appendMessages(t, store, now, 1)
+
+	processOwn(t, store, now, messages, func(msg *outbox.Message) {
+		msg.MarkSent(now)
+	})
+
+	assert.Empty(t, processOwn(t, store, now.Add(time.Hour), messages, nil))
+}
+
+func testProcessOutboxRetry(t *testing.T, store OutboxStore) {
+	now := timestamp()
+	messages := appendMessages(t, store, now, 1)
+	policy := outbox.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	processOwn(t, store, now, messages, func(msg *outbox.Message) {
+		msg.MarkFailed(now, errPublish, policy)
+	})
+
+	assert.Empty(t, processOwn(t, store, now, messages, nil), "claimed before the retry is due")
+
+	retryAt := now.Add(time.Minute)
+	claimed := processOwn(t, store, retryAt, messages, func(msg *outbox.Message) {
+		msg.MarkSent(retryAt)
+	})
+	require.Len(t, claimed, 1)
+	assert.Equal(t, outbox.StatusPending, claimed[0].Status)
+	assert.Equal(t, 1, claimed[0].Attempts)
+	assert.Equal(t, errPublish.Error(), claimed[0].LastError)
+	assert.True(t, retryAt.Equal(claimed[0].NextAttemptAt))
+}
+
+func testProcessOutboxDead(t *testing.T, store OutboxStore) {
+	now := timestamp()
+	messages := appendMessages(t, store, now, 1)
+	policy := outbox.RetryPolicy{MaxAttempts: 1, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	processOwn(t, store, now, messages, func(msg *outbox.Message) {
+		msg.MarkFailed(now, errPublish, policy)
+	})
+
+	assert.Empty(t, processOwn(t, store, now.Add(24*time.Hour), messages, nil))
+}
+
+func testProcessOutboxError(t *testing.T, store OutboxStore) {
+	ctx := context.Background()
+	now := timestamp()
+	messages := appendMessages(t, store, now, 1)
+
+	_, err := store.ProcessOutbox(ctx, now, outboxLimit, func(_ context.Context, claimed []*outbox.Message) error {
+		for _, msg := range own(claimed, messages) {
+			msg.MarkSent(now)
+		}
+		return errPublish
+	})
+	require.ErrorIs(t, err, errPublish)
+
+	claimed := processOwn(t, store, now, messages, func(msg *outbox.Message) {
+		msg.MarkSent(now)
+	})
+	require.Len(t, claimed, 1)
+	assertSameMessage(t, messages[0], claimed[0])
+}
+
+// testProcessOutboxParallel processes messages with concurrent relays, which
+// must each claim different messages.
+func testProcessOutboxParallel(t *testing.T, store OutboxStore) {
+	ctx := context.Background()
+	now := timestamp()
+	messages := appendMessages(t, store, now, 2*parallelWriters)
+
+	var (
+		mu     sync.Mutex
+		claims = make(map[uuid.UUID]int)
+	)
+	errs := runParallel(func(int) error {
+		for {
+			var claimedOwn int
+			_, err := store.ProcessOutbox(ctx, now, 2, func(_ context.Context, claimed []*outbox.Message) error {
+				mine := own(claimed, messages)
+				claimedOwn = len(mine)
+
+				mu.Lock()
+				for _, msg := range mine {
+					claims[msg.Event.ID]++
+				}
+				mu.Unlock()
+
+				// Give concurrent relays time to attempt claiming the messages.
+				time.Sleep(10 * time.Millisecond)
+				for _, msg := range mine {
+					msg.MarkSent(now)
+				}
+				return nil
+			})
+			if err != nil || claimedOwn == 0 {
+				return err
+			}
+		}
+	})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, claims, len(messages))
+	for id, count := range claims {
+		assert.Equal(t, 1, count, "message %s claimed %d times", id, count)
+	}
+}
+
+// appendMessages appends `n` messages for events that occurred at `now` in a
+// unit of work.
+func appendMessages(t *testing.T, store OutboxStore, now time.Time, n int) []*outbox.Message {
+	t.Helper()
+
+	ctx := context.Background()
+	messages := make([]*outbox.Message, n)
+	for i := range messages {
+		messages[i] = outbox.NewMessage(randomEvent(now))
+	}
+
+	err := store.Do(ctx, func(tx outbound.Repositories) error {
+		return tx.Outbox().Append(ctx, messages...)
+	})
+	require.NoError(t, err)
+
+	return messages
+}
+
+// processOwn processes the outbox at `now`, calling `update` with each of the
+// claimed messages that are among `messages`. It returns copies of these
+// messages as they were claimed, in the order of `messages`.
+func processOwn(
+	t *testing.T,
+	store OutboxStore,
+	now time.Time,
+	messages []*outbox.Message,
+	update func(msg *outbox.Message),
+) []outbox.Message {
+	t.Helper()
+
+	var claimed []outbox.Message
+	_, err := store.ProcessOutbox(context.Background(), now, outboxLimit, func(_ context.Context, all []*outbox.Message) error {
+		for _, msg := range own(all, messages) {
+			claimed = append(claimed, *msg)
+			if update != nil {
+				update(msg)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	return claimed
+}
+
+// own returns the messages of `claimed` that are among `messages`, in the
+// order of `messages`.
+func own(claimed, messages []*outbox.Message) []*outbox.Message {
+	byID := make(map[uuid.UUID]*outbox.Message, len(claimed))
+	for _, msg := range claimed {
+		byID[msg.Event.ID] = msg
+	}
+
+	var mine []*outbox.Message
+	for _, msg := range messages {
+		if claimedMsg, ok := byID[msg.Event.ID]; ok {
+			mine = append(mine, claimedMsg)
+		}
+	}
+	return mine
+}
+
+func assertSameMessage(t *testing.T, want *outbox.Message, got outbox.Message) {
+	t.Helper()
+
+	assert.Equal(t, want.Event.ID, got.Event.ID)
+	assert.Equal(t, want.Event.Type, got.Event.Type)
+	assert.Equal(t, want.Event.UserID, got.Event.UserID)
+	assert.True(t, want.Event.OccurredAt.Equal(got.Event.OccurredAt))
+	assert.JSONEq(t, string(want.Event.Payload), string(got.Event.Payload))
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Attempts, got.Attempts)
+	assert.True(t, want.NextAttemptAt.Equal(got.NextAttemptAt))
+}
+
+func randomEvent(now time.Time) event.Event {
+	return event.Event{
+		ID:         uuid.New(),
+		Type:       event.TypeUserUpdated,
+		UserID:     uuid.New(),
+		OccurredAt: now,
+		Payload:    []byte(`{"fields": ["username"], "role": "Reader"}`),
+	}
+}
+
+// timestamp returns the current time at the microsecond resolution of the
+// stores.
+func timestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
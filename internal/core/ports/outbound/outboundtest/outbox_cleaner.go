@@ -0,0 +1,116 @@
+package outboundtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// OutboxCleanupStore is an adapter under test that stores outbox messages and
+// deletes the finished ones.
+type OutboxCleanupStore interface {
+	OutboxStore
+	outbound.OutboxCleaner
+}
+
+// NewOutboxCleanupStore returns the adapter under test, as [NewRepositories]
+// does.
+type NewOutboxCleanupStore func(t *testing.T) OutboxCleanupStore
+
+// cleanupBase is when the messages of the cleanup tests occurred. It is long
+// before the messages of other tests, which are never deleted by the cutoffs
+// the tests use.
+var cleanupBase = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// RunOutboxCleaner runs the [outbound.OutboxCleaner] contract tests. The tests
+// run one after the other, since they count the messages deleted.
+func RunOutboxCleaner(t *testing.T, newStore NewOutboxCleanupStore) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, store OutboxCleanupStore)
+	}{
+		{"DeleteFinishedOutboxMessages deletes sent and dead messages", testDeleteFinishedOutboxMessages},
+		{"DeleteFinishedOutboxMessages deletes in batches", testDeleteFinishedOutboxMessagesBatch},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore(t)
+			drainFinishedOutbox(t, store)
+			tt.test(t, store)
+		})
+	}
+}
+
+func testDeleteFinishedOutboxMessages(t *testing.T, store OutboxCleanupStore) {
+	ctx := context.Background()
+	messages := appendMessages(t, store, cleanupBase, 4)
+	sent, sentLater, dead, pending := messages[0], messages[1], messages[2], messages[3]
+	policy := outbox.RetryPolicy{MaxAttempts: 1, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	processOwn(t, store, cleanupBase, messages, func(msg *outbox.Message) {
+		switch msg.Event.ID {
+		case sent.Event.ID:
+			msg.MarkSent(cleanupBase)
+		case sentLater.Event.ID:
+			msg.MarkSent(cleanupBase.Add(2 * time.Hour))
+		case dead.Event.ID:
+			msg.MarkFailed(cleanupBase, errPublish, policy)
+		}
+	})
+
+	cutoff := cleanupBase.Add(time.Hour)
+	deleted, err := store.DeleteFinishedOutboxMessages(ctx, cutoff, cutoff, outboxLimit)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted, "sent and dead messages before the cutoffs")
+
+	deleted, err = store.DeleteFinishedOutboxMessages(ctx, cutoff.Add(2*time.Hour), cutoff, outboxLimit)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted, "message sent after the first cutoff")
+
+	claimed := processOwn(t, store, cleanupBase, []*outbox.Message{pending}, func(msg *outbox.Message) {
+		msg.MarkSent(cleanupBase)
+	})
+	assert.Len(t, claimed, 1, "pending message")
+}
+
+func testDeleteFinishedOutboxMessagesBatch(t *testing.T, store OutboxCleanupStore) {
+	ctx := context.Background()
+	messages := appendMessages(t, store, cleanupBase, 3)
+	processOwn(t, store, cleanupBase, messages, func(msg *outbox.Message) {
+		msg.MarkSent(cleanupBase)
+	})
+
+	cutoff := cleanupBase.Add(time.Hour)
+	deleted, err := store.DeleteFinishedOutboxMessages(ctx, cutoff, cutoff, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = store.DeleteFinishedOutboxMessages(ctx, cutoff, cutoff, outboxLimit)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
+
+// drainFinishedOutbox deletes the finished messages left around
+// [cleanupBase] by earlier runs of the tests.
+func drainFinishedOutbox(t *testing.T, store OutboxCleanupStore) {
+	t.Helper()
+
+	cutoff := cleanupBase.Add(24 * time.Hour)
+	for {
+		deleted, err := store.DeleteFinishedOutboxMessages(context.Background(), cutoff, cutoff, outboxLimit)
+		require.NoError(t, err)
+		if deleted == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package outboundtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// SessionStore is an adapter under test that stores sessions and deletes the
+// stale ones.
+type SessionStore interface {
+	Repositories
+	outbound.SessionCleaner
+}
+
+// NewSessionStore returns the adapter under test, as [NewRepositories] does.
+type NewSessionStore func(t *testing.T) SessionStore
+
+// RunSessionCleaner runs the [outbound.SessionCleaner] contract tests.
+func RunSessionCleaner(t *testing.T, newStore NewSessionStore) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, store SessionStore)
+	}{
+		{"DeleteStaleSessions deletes expired and blocked sessions", testDeleteStaleSessions},
+		{"DeleteStaleSessions deletes in batches", testDeleteStaleSessionsBatch},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func testDeleteStaleSessions(t *testing.T, store SessionStore) {
+	ctx := context.Background()
+	now := time.Now()
+
+	expired := newSession(createUser(t, store).ID())
+	expired.ExpiresAt = now.Add(-time.Hour)
+	blocked := newSession(createUser(t, store).ID())
+	blocked.IsBlocked = true
+	valid := newSession(createUser(t, store).ID())
+	require.NoError(t, store.CreateSession(ctx, expired))
+	require.NoError(t, store.CreateSession(ctx, blocked))
+	require.NoError(t, store.CreateSession(ctx, valid))
+
+	// Sessions of concurrent tests may be deleted too, so the store is cleaned
+	// until nothing is left.
+	for {
+		deleted, err := store.DeleteStaleSessions(ctx, now, now.Add(time.Minute), 100)
+		require.NoError(t, err)
+		if deleted == 0 {
+			break
+		}
+	}
+
+	_, err := store.GetSessionByUserID(ctx, expired.UserID)
+	assert.Error(t, err, "expired session")
+	_, err = store.GetSessionByUserID(ctx, blocked.UserID)
+	assert.Error(t, err, "blocked session")
+	_, err = store.GetSessionByUserID(ctx, valid.UserID)
+	assert.NoError(t, err, "valid session")
+}
+
+func testDeleteStaleSessionsBatch(t *testing.T, store SessionStore) {
+	ctx := context.Background()
+	now := time.Now()
+	userID := createUser(t, store).ID()
+
+	for i := 0; i < 3; i++ {
+		session := newSession(userID)
+		session.ExpiresAt = now.Add(-time.Hour)
+		require.NoError(t, store.CreateSession(ctx, session))
+	}
+
+	deleted, err := store.DeleteStaleSessions(ctx, now, time.Time{}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
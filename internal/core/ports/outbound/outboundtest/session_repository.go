@@ -0,0 +1,141 @@
+package outboundtest
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/auth"
+)
+
+// RunSessionRepository runs the [outbound.SessionRepository] contract tests.
+func RunSessionRepository(t *testing.T, newRepos NewRepositories) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, repos Repositories)
+	}{
+		{"CreateSession", testCreateSession},
+		{"CreateSession unknown user", testCreateSessionUnknownUser},
+		{"CreateSession parallel writers", testCreateSessionParallel},
+		{"GetSession not found", testGetSessionNotFound},
+		{"GetSessionByUserID latest", testGetSessionByUserIDLatest},
+		{"GetSessionByUserID not found", testGetSessionByUserIDNotFound},
+		{"DeleteSession", testDeleteSession},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, newRepos(t))
+		})
+	}
+}
+
+func testCreateSession(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	session := newSession(createUser(t, repos).ID())
+
+	require.NoError(t, repos.CreateSession(ctx, session))
+
+	stored, err := repos.GetSessionByUserID(ctx, session.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, stored.ID)
+	assert.Equal(t, session.UserID, stored.UserID)
+	assert.Equal(t, session.RefreshToken, stored.RefreshToken)
+	assert.Equal(t, session.UserAgent, stored.UserAgent)
+	assert.Equal(t, session.ClientIP, stored.ClientIP)
+	assert.Equal(t, session.IsBlocked, stored.IsBlocked)
+	assert.WithinDuration(t, session.ExpiresAt, stored.ExpiresAt, time.Microsecond)
+	assert.False(t, stored.CreatedAt.IsZero())
+
+	byID, err := repos.GetSession(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, stored.ID, byID.ID)
+	assert.Equal(t, stored.RefreshToken, byID.RefreshToken)
+}
+
+func testCreateSessionUnknownUser(t *testing.T, repos Repositories) {
+	err := repos.CreateSession(context.Background(), newSession(uuid.New()))
+	assert.Error(t, err)
+}
+
+// testCreateSessionParallel creates sessions for one user from several
+// goroutines. None may be lost.
+func testCreateSessionParallel(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	userID := createUser(t, repos).ID()
+
+	errs := runParallel(func(int) error {
+		return repos.CreateSession(ctx, newSession(userID))
+	})
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	stored, err := repos.GetSessionByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, stored.UserID)
+}
+
+func testGetSessionNotFound(t *testing.T, repos Repositories) {
+	_, err := repos.GetSession(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+}
+
+func testGetSessionByUserIDLatest(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	userID := createUser(t, repos).ID()
+
+	var latest *auth.Sessions
+	for i := 0; i < 3; i++ {
+		latest = newSession(userID)
+		require.NoError(t, repos.CreateSession(ctx, latest))
+	}
+
+	stored, err := repos.GetSessionByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, latest.ID, stored.ID)
+}
+
+func testGetSessionByUserIDNotFound(t *testing.T, repos Repositories) {
+	userID := createUser(t, repos).ID()
+
+	_, err := repos.GetSessionByUserID(context.Background(), userID)
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+}
+
+func testDeleteSession(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	userID := createUser(t, repos).ID()
+	require.NoError(t, repos.CreateSession(ctx, newSession(userID)))
+
+	stored, err := repos.GetSessionByUserID(ctx, userID)
+	require.NoError(t, err)
+
+	require.NoError(t, repos.DeleteSession(ctx, stored.ID))
+
+	_, err = repos.GetSession(ctx, stored.ID)
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+
+	assert.NoError(t, repos.DeleteSession(ctx, stored.ID), "deleting a missing session is not an error")
+}
+
+func newSession(userID uuid.UUID) *auth.Sessions {
+	return &auth.Sessions{
+		ID:           uuid.New(),
+		UserID:       userID,
+		RefreshToken: uuid.NewString(),
+		UserAgent:    "outboundtest",
+		ClientIP:     auth.NewClientIP(netip.MustParseAddr("203.0.113.7")),
+		ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond),
+		CreatedAt:    time.Now().UTC(),
+	}
+}
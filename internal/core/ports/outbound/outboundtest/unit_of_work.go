@@ -0,0 +1,118 @@
+package outboundtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+)
+
+// TransactionalRepositories is an adapter under test that also runs units of
+// work.
+type TransactionalRepositories interface {
+	Repositories
+	outbound.UnitOfWork
+}
+
+// NewTransactionalRepositories returns the adapter under test, as
+// [NewRepositories] does.
+type NewTransactionalRepositories func(t *testing.T) TransactionalRepositories
+
+// RunUnitOfWork runs the [outbound.UnitOfWork] contract tests.
+func RunUnitOfWork(t *testing.T, newRepos NewTransactionalRepositories) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, repos TransactionalRepositories)
+	}{
+		{"Do commits", testUnitOfWorkCommits},
+		{"Do rolls back", testUnitOfWorkRollsBack},
+		{"Do returns domain errors", testUnitOfWorkDomainError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, newRepos(t))
+		})
+	}
+}
+
+// testUnitOfWorkCommits creates a user and a session for them in one unit of
+// work, which must see its own writes.
+func testUnitOfWorkCommits(t *testing.T, repos TransactionalRepositories) {
+	ctx := context.Background()
+	req := randomRegistrationRequest(t)
+
+	var created *user.User
+	err := repos.Do(ctx, func(tx outbound.Repositories) error {
+		var err error
+		created, err = tx.Users().CreateUser(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Users().GetUserByID(ctx, created.ID()); err != nil {
+			return err
+		}
+
+		return tx.Sessions().CreateSession(ctx, newSession(created.ID()))
+	})
+	require.NoError(t, err)
+
+	stored, err := repos.GetUserByEmail(ctx, req.Email())
+	require.NoError(t, err)
+	assertSameUser(t, created, stored)
+
+	_, err = repos.GetSessionByUserID(ctx, created.ID())
+	assert.NoError(t, err)
+}
+
+// testUnitOfWorkRollsBack creates a user and a session, then fails. Neither
+// may be stored.
+func testUnitOfWorkRollsBack(t *testing.T, repos TransactionalRepositories) {
+	ctx := context.Background()
+	req := randomRegistrationRequest(t)
+	errAbort := errors.New("abort")
+
+	var created *user.User
+	err := repos.Do(ctx, func(tx outbound.Repositories) error {
+		var err error
+		created, err = tx.Users().CreateUser(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Sessions().CreateSession(ctx, newSession(created.ID())); err != nil {
+			return err
+		}
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = repos.GetUserByEmail(ctx, req.Email())
+	assert.ErrorIs(t, err, user.NewNotFoundByEmailError(req.Email()))
+
+	_, err = repos.GetSessionByUserID(ctx, created.ID())
+	assert.Error(t, err)
+}
+
+func testUnitOfWorkDomainError(t *testing.T, repos TransactionalRepositories) {
+	ctx := context.Background()
+	existing := createUser(t, repos)
+
+	err := repos.Do(ctx, func(tx outbound.Repositories) error {
+		req := user.NewRegistrationRequest(randomUsername(t), existing.Email(), randomPasswordHash())
+		_, err := tx.Users().CreateUser(ctx, req)
+		return err
+	})
+	assert.ErrorIs(t, err, user.NewDuplicateEmailError(existing.Email()))
+}
@@ -0,0 +1,428 @@
+package outboundtest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/user"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/ports/outbound"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/etag"
+	"github.com/teamkweku/code-odessey-hex-arch/pkg/option"
+)
+
+// parallelWriters is the number of goroutines racing in the concurrency
+// tests.
+const parallelWriters = 8
+
+// RunUserRepository runs the [outbound.UserRepository] contract tests.
+func RunUserRepository(t *testing.T, newRepos NewRepositories) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo outbound.UserRepository)
+	}{
+		{"CreateUser", testCreateUser},
+		{"CreateUser duplicate username", testCreateUserDuplicateUsername},
+		{"CreateUser duplicate email", testCreateUserDuplicateEmail},
+		{"CreateUser parallel writers", testCreateUserParallel},
+		{"GetUserByID not found", testGetUserByIDNotFound},
+		{"GetUserByEmail not found", testGetUserByEmailNotFound},
+		{"UpdateUser", testUpdateUser},
+		{"UpdateUser keeps unset fields", testUpdateUserPartial},
+		{"UpdateUser successive updates", testUpdateUserSuccessive},
+		{"UpdateUser not found", testUpdateUserNotFound},
+		{"UpdateUser stale ETag", testUpdateUserStaleETag},
+		{"UpdateUser duplicate username", testUpdateUserDuplicateUsername},
+		{"UpdateUser duplicate email", testUpdateUserDuplicateEmail},
+		{"UpdateUser parallel writers", testUpdateUserParallel},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, newRepos(t))
+		})
+	}
+}
+
+func testCreateUser(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	req := randomRegistrationRequest(t)
+
+	created, err := repo.CreateUser(ctx, req)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, uuid.Nil, created.ID())
+	assert.Equal(t, req.Username(), created.Username())
+	assert.Equal(t, req.Email(), created.Email())
+	assert.Equal(t, req.PasswordHash(), created.PasswordHash())
+	assert.Equal(t, user.RoleReader, created.Role())
+	assert.Equal(t, created.ID(), created.ETag().ID())
+	assert.True(t, created.ETag().UpdatedAt().Equal(created.UpdatedAt()))
+
+	byID, err := repo.GetUserByID(ctx, created.ID())
+	require.NoError(t, err)
+	assertSameUser(t, created, byID)
+
+	byEmail, err := repo.GetUserByEmail(ctx, req.Email())
+	require.NoError(t, err)
+	assertSameUser(t, created, byEmail)
+}
+
+func testCreateUserDuplicateUsername(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+
+	req := user.NewRegistrationRequest(existing.Username(), randomEmail(t), randomPasswordHash())
+	_, err := repo.CreateUser(ctx, req)
+	assert.ErrorIs(t, err, user.NewDuplicateUsernameError(existing.Username()))
+}
+
+func testCreateUserDuplicateEmail(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+
+	req := user.NewRegistrationRequest(randomUsername(t), existing.Email(), randomPasswordHash())
+	_, err := repo.CreateUser(ctx, req)
+	assert.ErrorIs(t, err, user.NewDuplicateEmailError(existing.Email()))
+}
+
+// testCreateUserParallel registers the same username from several goroutines.
+// Exactly one registration may succeed.
+func testCreateUserParallel(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	username := randomUsername(t)
+	emails := make([]user.EmailAddress, parallelWriters)
+	for i := range emails {
+		emails[i] = randomEmail(t)
+	}
+
+	errs := runParallel(func(i int) error {
+		req := user.NewRegistrationRequest(username, emails[i], randomPasswordHash())
+		_, err := repo.CreateUser(ctx, req)
+		return err
+	})
+
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, user.NewDuplicateUsernameError(username))
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func testGetUserByIDNotFound(t *testing.T, repo outbound.UserRepository) {
+	id := uuid.New()
+
+	_, err := repo.GetUserByID(context.Background(), id)
+	assert.ErrorIs(t, err, user.NewNotFoundByIDError(id))
+}
+
+func testGetUserByEmailNotFound(t *testing.T, repo outbound.UserRepository) {
+	email := randomEmail(t)
+
+	_, err := repo.GetUserByEmail(context.Background(), email)
+	assert.ErrorIs(t, err, user.NewNotFoundByEmailError(email))
+}
+
+func testUpdateUser(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+
+	username := randomUsername(t)
+	email := randomEmail(t)
+	passwordHash := randomPasswordHash()
+	req := user.NewUpdateRequest(
+		existing.ID(),
+		existing.ETag(),
+		option.Some(email),
+		option.Some(passwordHash),
+		option.Some(username),
+		option.Some(user.RoleAdmin),
+	)
+
+	updated, err := repo.UpdateUser(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, existing.ID(), updated.ID())
+	assert.Equal(t, username, updated.Username())
+	assert.Equal(t, email, updated.Email())
+	assert.Equal(t, passwordHash, updated.PasswordHash())
+	assert.Equal(t, user.RoleAdmin, updated.Role())
+	assert.True(t, updated.CreatedAt().Equal(existing.CreatedAt()))
+	assert.True(t, updated.PasswordChangedAt().After(existing.PasswordChangedAt()))
+	assert.True(t, updated.UpdatedAt().After(existing.UpdatedAt()))
+	assert.NotEqual(t, existing.ETag().String(), updated.ETag().String())
+
+	stored, err := repo.GetUserByID(ctx, existing.ID())
+	require.NoError(t, err)
+	assertSameUser(t, updated, stored)
+
+	_, err = repo.GetUserByEmail(ctx, existing.Email())
+	assert.ErrorIs(t, err, user.NewNotFoundByEmailError(existing.Email()))
+
+	byEmail, err := repo.GetUserByEmail(ctx, email)
+	require.NoError(t, err)
+	assertSameUser(t, updated, byEmail)
+}
+
+func testUpdateUserPartial(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+
+	req := user.NewUpdateRequest(
+		existing.ID(),
+		existing.ETag(),
+		option.None[user.EmailAddress](),
+		option.None[user.PasswordHash](),
+		option.None[user.Username](),
+		option.Some(user.RoleAdmin),
+	)
+
+	updated, err := repo.UpdateUser(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, existing.Username(), updated.Username())
+	assert.Equal(t, existing.Email(), updated.Email())
+	assert.Equal(t, existing.PasswordHash(), updated.PasswordHash())
+	assert.True(t, updated.PasswordChangedAt().Equal(existing.PasswordChangedAt()))
+	assert.Equal(t, user.RoleAdmin, updated.Role())
+}
+
+// testUpdateUserSuccessive checks that the ETag returned by an update can be
+// used for the next one.
+func testUpdateUserSuccessive(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	current := createUser(t, repo)
+
+	for i := 0; i < 3; i++ {
+		req := user.NewUpdateRequest(
+			current.ID(),
+			current.ETag(),
+			option.None[user.EmailAddress](),
+			option.None[user.PasswordHash](),
+			option.Some(randomUsername(t)),
+			option.None[user.Role](),
+		)
+
+		updated, err := repo.UpdateUser(ctx, req)
+		require.NoError(t, err, "update %d", i+1)
+		current = updated
+	}
+
+	stored, err := repo.GetUserByID(ctx, current.ID())
+	require.NoError(t, err)
+	assertSameUser(t, current, stored)
+}
+
+func testUpdateUserNotFound(t *testing.T, repo outbound.UserRepository) {
+	id := uuid.New()
+	req := user.NewUpdateRequest(
+		id,
+		etag.Random(),
+		option.None[user.EmailAddress](),
+		option.None[user.PasswordHash](),
+		option.Some(randomUsername(t)),
+		option.None[user.Role](),
+	)
+
+	_, err := repo.UpdateUser(context.Background(), req)
+	assert.ErrorIs(t, err, user.NewNotFoundByIDError(id))
+}
+
+// testUpdateUserStaleETag updates a user with the ETag it had before another
+// update was applied.
+func testUpdateUserStaleETag(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+
+	first := user.NewUpdateRequest(
+		existing.ID(),
+		existing.ETag(),
+		option.None[user.EmailAddress](),
+		option.None[user.PasswordHash](),
+		option.Some(randomUsername(t)),
+		option.None[user.Role](),
+	)
+	updated, err := repo.UpdateUser(ctx, first)
+	require.NoError(t, err)
+
+	stale := user.NewUpdateRequest(
+		existing.ID(),
+		existing.ETag(),
+		option.None[user.EmailAddress](),
+		option.None[user.PasswordHash](),
+		option.Some(randomUsername(t)),
+		option.None[user.Role](),
+	)
+	_, err = repo.UpdateUser(ctx, stale)
+
+	var concurrentErr *user.ConcurrentModificationError
+	require.ErrorAs(t, err, &concurrentErr)
+	assert.Equal(t, existing.ID(), concurrentErr.ID)
+	assert.Equal(t, existing.ETag().String(), concurrentErr.ETag.String())
+
+	stored, err := repo.GetUserByID(ctx, existing.ID())
+	require.NoError(t, err)
+	assertSameUser(t, updated, stored)
+}
+
+func testUpdateUserDuplicateUsername(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+	other := createUser(t, repo)
+
+	req := user.NewUpdateRequest(
+		existing.ID(),
+		existing.ETag(),
+		option.None[user.EmailAddress](),
+		option.None[user.PasswordHash](),
+		option.Some(other.Username()),
+		option.None[user.Role](),
+	)
+
+	_, err := repo.UpdateUser(ctx, req)
+	assert.ErrorIs(t, err, user.NewDuplicateUsernameError(other.Username()))
+}
+
+func testUpdateUserDuplicateEmail(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+	other := createUser(t, repo)
+
+	req := user.NewUpdateRequest(
+		existing.ID(),
+		existing.ETag(),
+		option.Some(other.Email()),
+		option.None[user.PasswordHash](),
+		option.None[user.Username](),
+		option.None[user.Role](),
+	)
+
+	_, err := repo.UpdateUser(ctx, req)
+	assert.ErrorIs(t, err, user.NewDuplicateEmailError(other.Email()))
+}
+
+// testUpdateUserParallel updates a user from several goroutines holding the
+// same ETag. Exactly one update may succeed and the rest must be reported as
+// concurrent modifications.
+func testUpdateUserParallel(t *testing.T, repo outbound.UserRepository) {
+	ctx := context.Background()
+	existing := createUser(t, repo)
+	usernames := make([]user.Username, parallelWriters)
+	for i := range usernames {
+		usernames[i] = randomUsername(t)
+	}
+
+	errs := runParallel(func(i int) error {
+		req := user.NewUpdateRequest(
+			existing.ID(),
+			existing.ETag(),
+			option.None[user.EmailAddress](),
+			option.None[user.PasswordHash](),
+			option.Some(usernames[i]),
+			option.None[user.Role](),
+		)
+		_, err := repo.UpdateUser(ctx, req)
+		return err
+	})
+
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var concurrentErr *user.ConcurrentModificationError
+		assert.ErrorAs(t, err, &concurrentErr)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+// runParallel calls `fn` with the indices of [parallelWriters] goroutines,
+// started at once, and returns their errors.
+func runParallel(fn func(i int) error) []error {
+	var (
+		start = make(chan struct{})
+		wg    sync.WaitGroup
+		errs  = make([]error, parallelWriters)
+	)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+func createUser(t *testing.T, repo outbound.UserRepository) *user.User {
+	t.Helper()
+
+	usr, err := repo.CreateUser(context.Background(), randomRegistrationRequest(t))
+	require.NoError(t, err)
+	return usr
+}
+
+// assertSameUser asserts that two reads of a user agree. Timestamps are
+// compared by instant, since adapters may return them in different locations.
+func assertSameUser(t *testing.T, want, got *user.User) {
+	t.Helper()
+
+	assert.Equal(t, want.ID(), got.ID())
+	assert.Equal(t, want.ETag().String(), got.ETag().String())
+	assert.Equal(t, want.Username(), got.Username())
+	assert.Equal(t, want.Email(), got.Email())
+	assert.Equal(t, want.PasswordHash(), got.PasswordHash())
+	assert.Equal(t, want.Role(), got.Role())
+	assert.True(t, want.CreatedAt().Equal(got.CreatedAt()), "created at %v, want %v", got.CreatedAt(), want.CreatedAt())
+	assert.True(t, want.PasswordChangedAt().Equal(got.PasswordChangedAt()),
+		"password changed at %v, want %v", got.PasswordChangedAt(), want.PasswordChangedAt())
+	assert.True(t, want.UpdatedAt().Equal(got.UpdatedAt()), "updated at %v, want %v", got.UpdatedAt(), want.UpdatedAt())
+}
+
+// randomRegistrationRequest returns a request with a username and email that
+// are unique across runs, so that the suite can share a database with other
+// tests. The password hash is not a real hash, which would only slow the
+// suite down.
+func randomRegistrationRequest(t *testing.T) *user.RegistrationRequest {
+	t.Helper()
+	return user.NewRegistrationRequest(randomUsername(t), randomEmail(t), randomPasswordHash())
+}
+
+func randomUsername(t *testing.T) user.Username {
+	t.Helper()
+
+	raw := strings.ReplaceAll(uuid.NewString(), "-", "")[:user.UsernameMaxLen]
+	username, err := user.ParseUsername(raw)
+	require.NoError(t, err)
+	return username
+}
+
+func randomEmail(t *testing.T) user.EmailAddress {
+	t.Helper()
+
+	email, err := user.ParseEmailAddress(uuid.NewString() + "@example.com")
+	require.NoError(t, err)
+	return email
+}
+
+func randomPasswordHash() user.PasswordHash {
+	return user.NewPasswordHashFromTrustedSource([]byte(uuid.NewString()))
+}
@@ -0,0 +1,49 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/event"
+	"github.com/teamkweku/code-odessey-hex-arch/internal/core/domain/outbox"
+)
+
+// OutboxRepository stores events for publication. It is only available in a
+// [UnitOfWork], so that events are stored if and only if the changes they
+// describe are committed.
+type OutboxRepository interface {
+	Append(ctx context.Context, messages ...*outbox.Message) error
+}
+
+// OutboxRelayStore hands stored outbox messages to the relay that publishes
+// them.
+type OutboxRelayStore interface {
+	// ProcessOutbox claims up to `limit` messages that are due at `now`,
+	// oldest first, and calls `process` with them. Messages claimed by one call
+	// are not claimed by concurrent calls, including those of other replicas.
+	//
+	// The delivery state that `process` sets on each message is saved when it
+	// returns nil. If it returns an error, nothing is saved and the messages are
+	// claimed again by a later call. The number of claimed messages is
+	// returned.
+	ProcessOutbox(
+		ctx context.Context,
+		now time.Time,
+		limit int,
+		process func(ctx context.Context, messages []*outbox.Message) error,
+	) (int, error)
+}
+
+// OutboxCleaner deletes outbox messages that no longer need to be kept.
+type OutboxCleaner interface {
+	// DeleteFinishedOutboxMessages deletes up to `limit` messages that were
+	// sent before `sentBefore`, or are dead and occurred before `deadBefore`,
+	// returning the number deleted. Messages do not record when they died.
+	DeleteFinishedOutboxMessages(ctx context.Context, sentBefore, deadBefore time.Time, limit int) (int64, error)
+}
+
+// EventPublisher delivers events to their consumers. Events may be delivered
+// more than once, so consumers must deduplicate them by ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, e event.Event) error
+}
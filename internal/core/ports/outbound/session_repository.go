@@ -2,6 +2,7 @@ package outbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -9,8 +10,26 @@ import (
 )
 
 type SessionRepository interface {
+	// CreateSession stores `session` under its ID.
 	CreateSession(ctx context.Context, session *auth.Sessions) error
+	// GetSession returns the session with `id`.
+	//
+	// # Errors
+	// 	- [auth.ErrSessionNotFound] if no such session exists.
 	GetSession(ctx context.Context, id uuid.UUID) (*auth.Sessions, error)
+	// GetSessionByUserID returns the most recently created session of the
+	// user.
+	//
+	// # Errors
+	// 	- [auth.ErrSessionNotFound] if the user has no sessions.
 	GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*auth.Sessions, error)
 	DeleteSession(ctx context.Context, id uuid.UUID) error
 }
+
+// SessionCleaner deletes sessions that can no longer be used.
+type SessionCleaner interface {
+	// DeleteStaleSessions deletes up to `limit` sessions that expired before
+	// `expiredBefore`, or are blocked and were created before `blockedBefore`,
+	// returning the number deleted.
+	DeleteStaleSessions(ctx context.Context, expiredBefore, blockedBefore time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,25 @@
+package outbound
+
+import "context"
+
+// Repositories gives access to the repositories taking part in a unit of
+// work.
+type Repositories interface {
+	Users() UserRepository
+	Sessions() SessionRepository
+	Outbox() OutboxRepository
+	// AuditLog records entries that are committed with the unit of work.
+	AuditLog() AuditRecorder
+}
+
+// UnitOfWork runs several repository calls as one atomic operation.
+type UnitOfWork interface {
+	// Do calls `fn` with repositories whose changes are committed together if
+	// `fn` returns nil, and discarded otherwise. The error returned by `fn` is
+	// returned as-is.
+	//
+	// `fn` may be called more than once if the unit of work conflicts with a
+	// concurrent one, so it must not have side effects outside the
+	// repositories. The repositories must not be used after `fn` returns.
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
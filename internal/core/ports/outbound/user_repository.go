@@ -30,7 +30,7 @@ type UserRepository interface {
 	//
 	// # Errors
 	// 	- [NotFoundError] if no such User exists.
-	// 	- [ValidationError] if email is already taken.
+	// 	- [ValidationError] if email or username is already taken.
 	//  - [ConcurrentModificationError] if the user has been modified since the last
 	//    read.
 	UpdateUser(ctx context.Context, req *user.UpdateRequest) (*user.User, error)
@@ -0,0 +1,65 @@
+// Package deadline parses the time limits applied to RPCs.
+package deadline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Deadline bounds the time available to an RPC.
+type Deadline struct {
+	// Default is applied when the caller sets no deadline. Zero leaves such
+	// calls unbounded.
+	Default time.Duration
+	// Max caps the caller's deadline. Zero accepts any deadline.
+	Max time.Duration
+}
+
+// ParseDeadline parses a deadline of the form "default:max", e.g. "5s:30s".
+// Either part may be "0" to disable it.
+func ParseDeadline(raw string) (Deadline, error) {
+	defaultStr, maxStr, ok := strings.Cut(strings.TrimSpace(raw), ":")
+	if !ok {
+		return Deadline{}, fmt.Errorf("deadline %q is not of the form default:max", raw)
+	}
+
+	defaultTimeout, err := time.ParseDuration(defaultStr)
+	if err != nil || defaultTimeout < 0 {
+		return Deadline{}, fmt.Errorf("deadline %q has invalid default %q", raw, defaultStr)
+	}
+
+	maxTimeout, err := time.ParseDuration(maxStr)
+	if err != nil || maxTimeout < 0 {
+		return Deadline{}, fmt.Errorf("deadline %q has invalid max %q", raw, maxStr)
+	}
+
+	if maxTimeout > 0 && defaultTimeout > maxTimeout {
+		return Deadline{}, fmt.Errorf("deadline %q has default greater than max", raw)
+	}
+
+	return Deadline{Default: defaultTimeout, Max: maxTimeout}, nil
+}
+
+// ParseDeadlines parses a comma-separated list of "method=default:max" pairs.
+func ParseDeadlines(raw string) (map[string]Deadline, error) {
+	deadlines := make(map[string]Deadline)
+	if strings.TrimSpace(raw) == "" {
+		return deadlines, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		method, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("deadline %q is not of the form method=default:max", pair)
+		}
+
+		deadline, err := ParseDeadline(value)
+		if err != nil {
+			return nil, err
+		}
+		deadlines[method] = deadline
+	}
+
+	return deadlines, nil
+}
@@ -0,0 +1,55 @@
+package deadline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeadline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw     string
+		want    Deadline
+		wantErr bool
+	}{
+		{raw: "5s:30s", want: Deadline{Default: 5 * time.Second, Max: 30 * time.Second}},
+		{raw: "0:1m", want: Deadline{Max: time.Minute}},
+		{raw: "5s:0", want: Deadline{Default: 5 * time.Second}},
+		{raw: "30s:5s", wantErr: true},
+		{raw: "5s", wantErr: true},
+		{raw: "soon:later", wantErr: true},
+		{raw: "-1s:5s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseDeadline(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseDeadlines(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseDeadlines("/svc/A=1s:2s, /svc/B=0:10s")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Deadline{
+		"/svc/A": {Default: time.Second, Max: 2 * time.Second},
+		"/svc/B": {Max: 10 * time.Second},
+	}, got)
+
+	_, err = ParseDeadlines("/svc/A")
+	assert.Error(t, err)
+}
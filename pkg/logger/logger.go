@@ -8,6 +8,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Logger interface {
@@ -69,8 +70,24 @@ func (z *ZerologLogger) WithContext(ctx context.Context) Logger {
 	return &ZerologLogger{logger: logger}
 }
 
+// withSpanContext adds the IDs of the OpenTelemetry span active in `ctx`, if
+// any, so that log lines can be joined with their traces.
+func withSpanContext(ctx context.Context) func(e *zerolog.Event) {
+	return func(e *zerolog.Event) {
+		if ctx == nil {
+			return
+		}
+		spanCtx := trace.SpanContextFromContext(ctx)
+		if !spanCtx.IsValid() {
+			return
+		}
+		e.Str("trace_id", spanCtx.TraceID().String()).
+			Str("span_id", spanCtx.SpanID().String())
+	}
+}
+
 func (z *ZerologLogger) Info(ctx context.Context, msg string, fields map[string]interface{}) {
-	z.logger.Info().Fields(fields).Msg(msg)
+	z.logger.Info().Fields(fields).Func(withSpanContext(ctx)).Msg(msg)
 }
 
 func (z *ZerologLogger) Error(
@@ -82,6 +99,7 @@ func (z *ZerologLogger) Error(
 	z.logger.Error().
 		Err(err).
 		Fields(fields).
+		Func(withSpanContext(ctx)).
 		Str("stack_trace", getStackTrace()).
 		Msg(msg)
 }
@@ -89,18 +107,21 @@ func (z *ZerologLogger) Error(
 func (z *ZerologLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
 	z.logger.Debug().
 		Fields(fields).
+		Func(withSpanContext(ctx)).
 		Msg(msg)
 }
 
 func (z *ZerologLogger) Warn(ctx context.Context, msg string, fields map[string]interface{}) {
 	z.logger.Warn().
 		Fields(fields).
+		Func(withSpanContext(ctx)).
 		Msg(msg)
 }
 
 func (z *ZerologLogger) Fatal(ctx context.Context, msg string, fields map[string]interface{}) {
 	z.logger.Fatal().
 		Fields(fields).
+		Func(withSpanContext(ctx)).
 		Str("stack_trace", getStackTrace()).
 		Msg(msg)
 }
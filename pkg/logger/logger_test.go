@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNewZerologLogger(t *testing.T) {
@@ -84,6 +85,58 @@ func TestZerologLogger_WithContext(t *testing.T) {
 	}
 }
 
+func TestZerologLogger_SpanContext(t *testing.T) {
+	t.Parallel()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantTrace bool
+	}{
+		{
+			name:      "context with active span",
+			ctx:       trace.ContextWithSpanContext(context.Background(), spanCtx),
+			wantTrace: true,
+		},
+		{
+			name:      "context without span",
+			ctx:       context.Background(),
+			wantTrace: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			zLogger := &ZerologLogger{logger: zerolog.New(&buf)}
+
+			zLogger.Info(tt.ctx, "test message", nil)
+			output := buf.String()
+
+			if tt.wantTrace {
+				assert.Contains(t, output, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+				assert.Contains(t, output, `"span_id":"00f067aa0ba902b7"`)
+			} else {
+				assert.NotContains(t, output, "trace_id")
+				assert.NotContains(t, output, "span_id")
+			}
+		})
+	}
+}
+
 func TestZerologLogger_LogLevels(t *testing.T) {
 	t.Parallel()
 
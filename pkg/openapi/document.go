@@ -0,0 +1,168 @@
+// Package openapi builds OpenAPI 3 documents from route definitions and
+// validates request bodies against them.
+package openapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Version is the version of the OpenAPI specification documents conform to.
+const Version = "3.0.3"
+
+const jsonContentType = "application/json"
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Route describes an operation with a JSON request and response body.
+type Route struct {
+	// Method is the HTTP method. Empty means POST.
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	// Request is the schema of the request body, against which requests are
+	// validated. Nil means the operation takes no body.
+	Request *Schema
+	// Response is the schema of successful responses.
+	Response *Schema
+}
+
+// Spec is the input from which a [Document] is generated.
+type Spec struct {
+	Info   Info
+	Routes []Route
+	// Schemas are shared schemas that routes may refer to with [Ref].
+	Schemas map[string]*Schema
+	// Error is the schema of error responses, common to every route.
+	Error *Schema
+}
+
+// Document is an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+	requests   map[operationKey]*Schema
+}
+
+// PathItem maps lower-case HTTP methods to the operations of a path.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// RequestBody describes the body of an operation's requests.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType gives the schema of a body in a given content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the shared schemas of a document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+type operationKey struct {
+	method string
+	path   string
+}
+
+// NewDocument generates the document described by `spec`.
+func NewDocument(spec Spec) *Document {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       spec.Info,
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: make(map[string]*Schema, len(spec.Schemas)+1)},
+		requests:   make(map[operationKey]*Schema),
+	}
+
+	for name, schema := range spec.Schemas {
+		doc.Components.Schemas[name] = schema
+	}
+	if spec.Error != nil {
+		doc.Components.Schemas["Error"] = spec.Error
+	}
+
+	for _, route := range spec.Routes {
+		method := route.Method
+		if method == "" {
+			method = http.MethodPost
+		}
+
+		op := &Operation{
+			OperationID: route.OperationID,
+			Summary:     route.Summary,
+			Description: route.Description,
+			Tags:        route.Tags,
+			Responses:   map[string]*Response{"200": {Description: "Success"}},
+		}
+		if route.Request != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(route.Request)}
+			doc.requests[operationKey{method: method, path: route.Path}] = route.Request
+		}
+		if route.Response != nil {
+			op.Responses["200"].Content = jsonContent(route.Response)
+		}
+		if spec.Error != nil {
+			op.Responses["default"] = &Response{Description: "Error", Content: jsonContent(Ref("Error"))}
+		}
+
+		item, ok := doc.Paths[route.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[route.Path] = item
+		}
+		item[strings.ToLower(method)] = op
+	}
+
+	return doc
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{jsonContentType: {Schema: schema}}
+}
+
+// ValidateRequest validates a JSON request body, as decoded by encoding/json,
+// against the operation's request schema. It returns a [*ValidationError] if
+// the body is invalid, and nil for operations without a request schema.
+func (d *Document) ValidateRequest(method, path string, body interface{}) error {
+	schema, ok := d.requests[operationKey{method: method, path: path}]
+	if !ok {
+		return nil
+	}
+
+	v := &validator{schemas: d.Components.Schemas}
+	v.validate(schema, body, "")
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument() *Document {
+	return NewDocument(Spec{
+		Info: Info{Title: "Test", Version: "v1"},
+		Routes: []Route{
+			{
+				Path:        "/pets.PetService/Create",
+				OperationID: "createPet",
+				Request: &Schema{
+					Type:     "object",
+					Required: []string{"name", "owner"},
+					Properties: map[string]*Schema{
+						"id":    {Type: "string", Format: "uuid", ReadOnly: true},
+						"name":  {Type: "string", MinLength: Int(3), MaxLength: Int(8), Pattern: "^[a-z]+$"},
+						"owner": Ref("Owner"),
+						"tags":  {Type: "array", Items: &Schema{Type: "string", Enum: []string{"cat", "dog"}}},
+						"age":   {Type: "integer"},
+					},
+				},
+				Response: Ref("Owner"),
+			},
+			{Path: "/pets.PetService/List"},
+		},
+		Schemas: map[string]*Schema{
+			"Owner": {
+				Type:     "object",
+				Required: []string{"email"},
+				Properties: map[string]*Schema{
+					"email": {Type: "string", Format: "email"},
+				},
+			},
+		},
+		Error: &Schema{Type: "object"},
+	})
+}
+
+func TestNewDocument(t *testing.T) {
+	t.Parallel()
+
+	doc := testDocument()
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, Version, decoded["openapi"])
+
+	paths := decoded["paths"].(map[string]interface{})
+	require.Contains(t, paths, "/pets.PetService/Create")
+	create := paths["/pets.PetService/Create"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "createPet", create["operationId"])
+	assert.Contains(t, create, "requestBody")
+	assert.Contains(t, create["responses"], "default")
+
+	list := paths["/pets.PetService/List"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.NotContains(t, list, "requestBody")
+
+	schemas := decoded["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Contains(t, schemas, "Owner")
+	assert.Contains(t, schemas, "Error")
+}
+
+func TestDocument_ValidateRequest(t *testing.T) {
+	t.Parallel()
+
+	doc := testDocument()
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		wantViolations []Violation
+	}{
+		{
+			name: "Valid request",
+			path: "/pets.PetService/Create",
+			body: `{"name": "rex", "owner": {"email": "a@b.com"}, "tags": ["dog"], "age": 3}`,
+		},
+		{
+			name: "Missing required fields",
+			path: "/pets.PetService/Create",
+			body: `{}`,
+			wantViolations: []Violation{
+				{Field: "name", Description: "is required"},
+				{Field: "owner", Description: "is required"},
+			},
+		},
+		{
+			name: "String constraints",
+			path: "/pets.PetService/Create",
+			body: `{"name": "Rexy", "owner": {"email": "nope"}}`,
+			wantViolations: []Violation{
+				{Field: "name", Description: `must match "^[a-z]+$"`},
+				{Field: "owner.email", Description: "is not a valid email address"},
+			},
+		},
+		{
+			name: "Length bounds",
+			path: "/pets.PetService/Create",
+			body: `{"name": "ab", "owner": {"email": "a@b.com"}, "tags": ["cat", "fish"]}`,
+			wantViolations: []Violation{
+				{Field: "name", Description: "must be at least 3 characters long"},
+				{Field: "tags[1]", Description: "must be one of cat, dog"},
+			},
+		},
+		{
+			name: "Wrong types",
+			path: "/pets.PetService/Create",
+			body: `{"name": 7, "owner": "me", "age": 1.5}`,
+			wantViolations: []Violation{
+				{Field: "age", Description: "must be an integer"},
+				{Field: "name", Description: "must be a string"},
+				{Field: "owner", Description: "must be an object"},
+			},
+		},
+		{
+			name: "Read-only field",
+			path: "/pets.PetService/Create",
+			body: `{"id": "5c2b7d4e-8f5a-4a43-9e0f-0d1c5b0e2a11", "name": "rex", "owner": {"email": "a@b.com"}}`,
+			wantViolations: []Violation{
+				{Field: "id", Description: "is read-only"},
+			},
+		},
+		{
+			name: "Body not an object",
+			path: "/pets.PetService/Create",
+			body: `[]`,
+			wantViolations: []Violation{
+				{Field: "", Description: "must be an object"},
+			},
+		},
+		{
+			name: "Route without request schema",
+			path: "/pets.PetService/List",
+			body: `"anything"`,
+		},
+		{
+			name: "Unknown route",
+			path: "/pets.PetService/Delete",
+			body: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &body))
+
+			err := doc.ValidateRequest(http.MethodPost, tt.path, body)
+			if tt.wantViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantViolations, validationErr.Violations)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of the OpenAPI 3 schema object used to describe and
+// validate JSON request and response bodies.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	ReadOnly    bool               `json:"readOnly,omitempty"`
+	WriteOnly   bool               `json:"writeOnly,omitempty"`
+}
+
+// Ref returns a schema referring to the shared schema `name`.
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// Int returns a pointer to `n`, for setting the length bounds of a [Schema].
+func Int(n int) *int {
+	return &n
+}
+
+// Violation describes why a field of a request is invalid.
+type Violation struct {
+	// Field is the path to the invalid field, such as "user.email" or
+	// "tags[2]". It is empty if the body itself is invalid.
+	Field       string
+	Description string
+}
+
+// ValidationError lists every violation found in a request.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var builder strings.Builder
+	builder.WriteString("request does not match schema:")
+	for _, v := range e.Violations {
+		if v.Field == "" {
+			builder.WriteString(fmt.Sprintf(" %s;", v.Description))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf(" %s %s;", v.Field, v.Description))
+	}
+	return strings.TrimSuffix(builder.String(), ";")
+}
+
+// patterns caches compiled schema patterns, which are shared by every request.
+var patterns sync.Map
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// validator checks decoded JSON values against schemas, resolving references
+// to shared schemas.
+type validator struct {
+	schemas    map[string]*Schema
+	violations []Violation
+}
+
+func (v *validator) fail(field, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = v.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// validate checks `value`, as decoded by encoding/json, against `schema`.
+func (v *validator) validate(schema *Schema, value interface{}, field string) {
+	schema = v.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		v.validateObject(schema, value, field)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.fail(field, "must be an array")
+			return
+		}
+		for i, item := range items {
+			v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", field, i))
+		}
+	case "string":
+		v.validateString(schema, value, field)
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			// protojson encodes 64-bit integers as strings.
+			if _, isString := value.(string); !isString {
+				v.fail(field, "must be a number")
+			}
+			return
+		}
+		if schema.Type == "integer" && n != float64(int64(n)) {
+			v.fail(field, "must be an integer")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(field, "must be a boolean")
+		}
+	}
+}
+
+func (v *validator) validateObject(schema *Schema, value interface{}, field string) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		v.fail(field, "must be an object")
+		return
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			v.fail(joinField(field, name), "is required")
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		if v.resolve(property).ReadOnly {
+			v.fail(joinField(field, name), "is read-only")
+			continue
+		}
+		v.validate(property, object[name], joinField(field, name))
+	}
+}
+
+func (v *validator) validateString(schema *Schema, value interface{}, field string) {
+	s, ok := value.(string)
+	if !ok {
+		v.fail(field, "must be a string")
+		return
+	}
+
+	length := utf8.RuneCountInString(s)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		v.fail(field, "must be at least %d characters long", *schema.MinLength)
+		return
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		v.fail(field, "must be at most %d characters long", *schema.MaxLength)
+		return
+	}
+
+	if schema.Pattern != "" {
+		re, err := compilePattern(schema.Pattern)
+		if err != nil || !re.MatchString(s) {
+			v.fail(field, "must match %q", schema.Pattern)
+			return
+		}
+	}
+
+	if len(schema.Enum) > 0 && !contains(schema.Enum, s) {
+		v.fail(field, "must be one of %s", strings.Join(schema.Enum, ", "))
+		return
+	}
+
+	switch schema.Format {
+	case "email":
+		if _, err := mail.ParseAddress(s); err != nil {
+			v.fail(field, "is not a valid email address")
+		}
+	case "uuid":
+		if _, err := uuid.Parse(s); err != nil {
+			v.fail(field, "is not a valid UUID")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			v.fail(field, "is not a valid RFC 3339 timestamp")
+		}
+	}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func contains(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pruneThreshold is the number of tracked buckets above which a [MemoryStore]
+// discards buckets that have refilled completely.
+const pruneThreshold = 10_000
+
+type memoryEntry struct {
+	bucket Bucket
+	limit  Limit
+}
+
+// MemoryStore is a [Store] that keeps buckets in process memory. Limits are
+// enforced per replica.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.entries) >= pruneThreshold {
+		s.prune(now)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry.bucket = NewBucket(limit, now)
+	}
+
+	bucket, result := entry.bucket.Take(limit, now)
+	s.entries[key] = memoryEntry{bucket: bucket, limit: limit}
+
+	return result, nil
+}
+
+// prune removes buckets that would be full by `now` and are therefore
+// indistinguishable from new ones. It bounds memory use when many distinct
+// clients are seen.
+func (s *MemoryStore) prune(now time.Time) {
+	for key, entry := range s.entries {
+		if !entry.bucket.FullAt(entry.limit).After(now) {
+			delete(s.entries, key)
+		}
+	}
+}
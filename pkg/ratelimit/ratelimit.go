@@ -0,0 +1,128 @@
+// Package ratelimit implements token-bucket rate limiting over pluggable bucket
+// stores.
+//
+// The bucket arithmetic lives in [Bucket.Take] so that every [Store]
+// implementation, whether in memory or in a shared database, grants and
+// refuses tokens identically.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit describes a token bucket: `Burst` tokens of capacity, refilled at
+// `Rate` tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses a limit of the form "rate:burst", e.g. "0.5:10" for one
+// request every two seconds with bursts of up to ten.
+func ParseLimit(raw string) (Limit, error) {
+	rateStr, burstStr, ok := strings.Cut(strings.TrimSpace(raw), ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("limit %q is not of the form rate:burst", raw)
+	}
+
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+		return Limit{}, fmt.Errorf("limit %q has invalid rate %q", raw, rateStr)
+	}
+
+	burst, err := strconv.Atoi(burstStr)
+	if err != nil || burst < 1 {
+		return Limit{}, fmt.Errorf("limit %q has invalid burst %q", raw, burstStr)
+	}
+
+	return Limit{Rate: rate, Burst: burst}, nil
+}
+
+// ParseLimits parses a comma-separated list of "key=rate:burst" pairs, such as
+// "/user.UserService/Register=0.1:5,/user.UserService/Authenticate=1:10".
+func ParseLimits(raw string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	if strings.TrimSpace(raw) == "" {
+		return limits, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("rate limit %q is not of the form key=rate:burst", pair)
+		}
+
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		limits[key] = limit
+	}
+
+	return limits, nil
+}
+
+// Result is the outcome of an attempt to take a token.
+type Result struct {
+	// Allowed is true if a token was available.
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// RetryAfter is how long the caller must wait before a token becomes
+	// available. It is zero when Allowed is true.
+	RetryAfter time.Duration
+}
+
+// Bucket is the persisted state of a token bucket.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewBucket returns a full bucket for `limit`.
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{Tokens: float64(limit.Burst), UpdatedAt: now}
+}
+
+// Take refills the bucket for the time elapsed since it was last updated and
+// attempts to consume a single token, returning the new bucket state.
+func (b Bucket) Take(limit Limit, now time.Time) (Bucket, Result) {
+	elapsed := now.Sub(b.UpdatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	tokens := math.Min(float64(limit.Burst), b.Tokens+elapsed*limit.Rate)
+	next := Bucket{Tokens: tokens, UpdatedAt: now}
+
+	if tokens < 1 {
+		wait := time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+		return next, Result{Allowed: false, Remaining: 0, RetryAfter: wait}
+	}
+
+	next.Tokens = tokens - 1
+	return next, Result{Allowed: true, Remaining: int(next.Tokens)}
+}
+
+// FullAt returns the time by which the bucket will have refilled completely
+// for `limit`. A full bucket is indistinguishable from a new one, so stores
+// may discard it from then on.
+func (b Bucket) FullAt(limit Limit) time.Time {
+	missing := float64(limit.Burst) - b.Tokens
+	if missing <= 0 {
+		return b.UpdatedAt
+	}
+	return b.UpdatedAt.Add(time.Duration(missing / limit.Rate * float64(time.Second)))
+}
+
+// Store persists token buckets.
+type Store interface {
+	// Take attempts to consume a token from the bucket identified by `key`,
+	// creating a full bucket if none exists.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
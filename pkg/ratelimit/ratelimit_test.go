@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    Limit
+		wantErr bool
+	}{
+		{name: "Valid", raw: "0.5:10", want: Limit{Rate: 0.5, Burst: 10}},
+		{name: "Surrounding whitespace", raw: " 2:3 ", want: Limit{Rate: 2, Burst: 3}},
+		{name: "Missing separator", raw: "10", wantErr: true},
+		{name: "Zero rate", raw: "0:10", wantErr: true},
+		{name: "Zero burst", raw: "1:0", wantErr: true},
+		{name: "Non-numeric burst", raw: "1:many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseLimit(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseLimits("/svc/A=1:5, /svc/B=0.1:2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Limit{
+		"/svc/A": {Rate: 1, Burst: 5},
+		"/svc/B": {Rate: 0.1, Burst: 2},
+	}, got)
+
+	got, err = ParseLimits("")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = ParseLimits("/svc/A")
+	assert.Error(t, err)
+}
+
+func TestBucket_Take(t *testing.T) {
+	t.Parallel()
+
+	limit := Limit{Rate: 2, Burst: 2}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := NewBucket(limit, start)
+
+	bucket, result := bucket.Take(limit, start)
+	assert.Equal(t, Result{Allowed: true, Remaining: 1}, result)
+
+	bucket, result = bucket.Take(limit, start)
+	assert.Equal(t, Result{Allowed: true, Remaining: 0}, result)
+
+	bucket, result = bucket.Take(limit, start)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	// Half a token per 250ms: after 500ms one token has been refilled.
+	_, result = bucket.Take(limit, start.Add(500*time.Millisecond))
+	assert.True(t, result.Allowed)
+}
+
+func TestBucket_Take_CapsAtBurst(t *testing.T) {
+	t.Parallel()
+
+	limit := Limit{Rate: 1, Burst: 3}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bucket := Bucket{Tokens: 0, UpdatedAt: start}
+	bucket, result := bucket.Take(limit, start.Add(time.Hour))
+
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+	assert.Equal(t, 2.0, bucket.Tokens)
+}
+
+func TestBucket_FullAt(t *testing.T) {
+	t.Parallel()
+
+	limit := Limit{Rate: 2, Burst: 4}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, start, NewBucket(limit, start).FullAt(limit))
+
+	empty := Bucket{Tokens: 0, UpdatedAt: start}
+	assert.Equal(t, start.Add(2*time.Second), empty.FullAt(limit))
+
+	bucket, _ := NewBucket(limit, start).Take(limit, start)
+	assert.Equal(t, start.Add(500*time.Millisecond), bucket.FullAt(limit))
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 1, Burst: 1}
+	ctx := context.Background()
+
+	result, err := store.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = store.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+
+	result, err = store.Take(ctx, "b", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "buckets are independent per key")
+
+	now = now.Add(time.Second)
+	result, err = store.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryStore_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 1, Burst: 1}
+	store.entries["full"] = memoryEntry{bucket: NewBucket(limit, now), limit: limit}
+	store.entries["empty"] = memoryEntry{bucket: Bucket{Tokens: 0, UpdatedAt: now}, limit: limit}
+
+	store.prune(now)
+
+	assert.NotContains(t, store.entries, "full")
+	assert.Contains(t, store.entries, "empty")
+}
@@ -0,0 +1,226 @@
+// Package tlsconfig builds server TLS configurations from certificate files on
+// disk, reloading them when they change so that rotated certificates are
+// picked up without a restart.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultCheckInterval is the minimum time between checks of the certificate
+// files for changes.
+const DefaultCheckInterval = 10 * time.Second
+
+// Options configures a [Reloader].
+type Options struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is a PEM bundle of CAs used to verify client certificates.
+	// When set, clients must present a valid certificate (mutual TLS).
+	ClientCAFile string
+	// MinVersion is the minimum accepted TLS version, TLS 1.2 or 1.3. Zero
+	// selects TLS 1.2.
+	MinVersion Version
+	// NextProtos lists the supported ALPN protocols, such as "h2" for gRPC.
+	// They must be set here rather than on the config returned by
+	// [Reloader.TLSConfig], because the per-handshake config replaces it.
+	NextProtos []string
+	// CheckInterval overrides [DefaultCheckInterval].
+	CheckInterval time.Duration
+	// OnReloadError, if set, is called with the error from each failed
+	// reload, so that it can be logged. It runs during the handshake that
+	// triggered the reload and must not block.
+	OnReloadError func(error)
+}
+
+// Version is a TLS protocol version, such as [tls.VersionTLS13].
+type Version uint16
+
+// ParseVersion converts a version such as "1.3" to its [tls] constant. An
+// empty version is TLS 1.2.
+func ParseVersion(version string) (Version, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q, must be 1.2 or 1.3", version)
+	}
+}
+
+// Reloader serves the certificates named by its [Options], re-reading them
+// from disk when their modification times change. Checks happen lazily
+// during handshakes, at most once per check interval. A failed reload is
+// reported by [Reloader.Err] and [Options.OnReloadError], and leaves the
+// previous certificates in place.
+type Reloader struct {
+	opts       Options
+	minVersion uint16
+	now        func() time.Time
+
+	mu          sync.Mutex
+	config      *tls.Config
+	modTimes    map[string]time.Time
+	lastChecked time.Time
+	lastErr     error
+}
+
+// New loads the certificates named by `opts`, failing if they cannot be read.
+func New(opts Options) (*Reloader, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.New("TLS certificate and key files are required")
+	}
+
+	minVersion := opts.MinVersion
+	switch minVersion {
+	case 0:
+		minVersion = tls.VersionTLS12
+	case tls.VersionTLS12, tls.VersionTLS13:
+	default:
+		return nil, fmt.Errorf("unsupported TLS version %#04x, must be 1.2 or 1.3", uint16(minVersion))
+	}
+
+	if opts.CheckInterval == 0 {
+		opts.CheckInterval = DefaultCheckInterval
+	}
+
+	r := &Reloader{
+		opts:       opts,
+		minVersion: uint16(minVersion),
+		now:        time.Now,
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	r.lastChecked = r.now()
+
+	return r, nil
+}
+
+// TLSConfig returns a configuration for servers, which consults the reloader
+// on every handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: r.minVersion,
+		NextProtos: r.opts.NextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+// Err returns the error from the most recent failed reload, or nil if the
+// certificates in use are the latest on disk.
+func (r *Reloader) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
+
+func (r *Reloader) current() *tls.Config {
+	config, err := r.check()
+	if err != nil && r.opts.OnReloadError != nil {
+		r.opts.OnReloadError(err)
+	}
+	return config
+}
+
+// check reloads the certificates if they are due a check and have changed,
+// returning the config to serve and the error from this check's reload, if
+// any.
+func (r *Reloader) check() (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastChecked) < r.opts.CheckInterval {
+		return r.config, nil
+	}
+
+	r.lastChecked = now
+	if !r.changed() {
+		return r.config, nil
+	}
+
+	r.lastErr = r.reloadLocked()
+	return r.config, r.lastErr
+}
+
+func (r *Reloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reloadLocked()
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.opts.ClientCAFile != "" {
+		files = append(files, r.opts.ClientCAFile)
+	}
+	return files
+}
+
+// changed reports whether any file has a different modification time to when
+// it was last loaded. Files that cannot be stat'ed, perhaps because they are
+// mid-rotation, are treated as unchanged.
+func (r *Reloader) changed() bool {
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) reloadLocked() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("stat %q: %w", file, err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS key pair %q, %q: %w", r.opts.CertFile, r.opts.KeyFile, err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   r.minVersion,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   r.opts.NextProtos,
+	}
+
+	if r.opts.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA file %q: %w", r.opts.ClientCAFile, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("client CA file %q contains no certificates", r.opts.ClientCAFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.config = config
+	r.modTimes = modTimes
+	return nil
+}
@@ -0,0 +1,278 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert issues a certificate for `commonName`, signed by `parent` or
+// self-signed if `parent` is nil.
+func newTestCert(t *testing.T, commonName string, parent *testCert, isCA bool) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+
+	if keyFile != "" {
+		keyDER, err := x509.MarshalECPrivateKey(c.key)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+		require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+// handshake connects a client to a server using `serverConfig` and returns the
+// certificate presented by the server, or the server's handshake error.
+func handshake(t *testing.T, serverConfig *tls.Config, clientCert *tls.Certificate) (*x509.Certificate, error) {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	clientConfig := &tls.Config{InsecureSkipVerify: true} //nolint:gosec // the test inspects the certificate itself
+	if clientCert != nil {
+		clientConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	client := tls.Client(clientConn, clientConfig)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		server := tls.Server(serverConn, serverConfig)
+		err := server.Handshake()
+		if err == nil {
+			// Complete the client's read of the session tickets under TLS 1.3.
+			_, _ = server.Write([]byte{0})
+		}
+		serverErr <- err
+		_ = server.Close()
+	}()
+
+	clientErr := client.Handshake()
+	if clientErr == nil {
+		_, clientErr = client.Read(make([]byte, 1))
+	}
+	if err := <-serverErr; err != nil {
+		return nil, err
+	}
+	require.NoError(t, clientErr)
+
+	return client.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    Version
+		wantErr bool
+	}{
+		{version: "", want: tls.VersionTLS12},
+		{version: "1.2", want: tls.VersionTLS12},
+		{version: "1.3", want: tls.VersionTLS13},
+		{version: "1.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseVersion(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	newTestCert(t, "server", nil, false).write(t, certFile, keyFile)
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "Missing files", opts: Options{}},
+		{name: "Unreadable key pair", opts: Options{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.key")}},
+		{name: "Invalid version", opts: Options{CertFile: certFile, KeyFile: keyFile, MinVersion: tls.VersionTLS10}},
+		{name: "Empty client CA", opts: Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New(tt.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	newTestCert(t, "first", nil, false).write(t, certFile, keyFile)
+
+	reloader, err := New(Options{CertFile: certFile, KeyFile: keyFile, CheckInterval: time.Minute})
+	require.NoError(t, err)
+
+	now := time.Now()
+	reloader.now = func() time.Time { return now }
+
+	served, err := handshake(t, reloader.TLSConfig(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", served.Subject.CommonName)
+
+	newTestCert(t, "second", nil, false).write(t, certFile, keyFile)
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	require.NoError(t, os.Chtimes(keyFile, future, future))
+
+	served, err = handshake(t, reloader.TLSConfig(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", served.Subject.CommonName, "files are not checked before the interval elapses")
+
+	now = now.Add(time.Minute)
+	served, err = handshake(t, reloader.TLSConfig(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", served.Subject.CommonName)
+	assert.NoError(t, reloader.Err())
+}
+
+func TestReloader_FailedReloadKeepsCertificate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	newTestCert(t, "first", nil, false).write(t, certFile, keyFile)
+
+	reloadErrs := make(chan error, 1)
+	reloader, err := New(Options{
+		CertFile:      certFile,
+		KeyFile:       keyFile,
+		CheckInterval: time.Minute,
+		OnReloadError: func(err error) { reloadErrs <- err },
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	reloader.now = func() time.Time { return now.Add(time.Minute) }
+
+	// Rotate the certificate without its key, as if mid-deployment.
+	newTestCert(t, "second", nil, false).write(t, certFile, "")
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	served, err := handshake(t, reloader.TLSConfig(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", served.Subject.CommonName)
+	assert.Error(t, reloader.Err())
+
+	select {
+	case err := <-reloadErrs:
+		assert.Equal(t, reloader.Err(), err)
+	default:
+		t.Fatal("failed reload was not reported")
+	}
+}
+
+func TestReloader_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca := newTestCert(t, "ca", nil, true)
+	ca.write(t, caFile, "")
+	newTestCert(t, "server", ca, false).write(t, certFile, keyFile)
+
+	reloader, err := New(Options{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		MinVersion:   tls.VersionTLS13,
+	})
+	require.NoError(t, err)
+
+	trusted := newTestCert(t, "billing-service", ca, false).tlsCertificate()
+	untrusted := newTestCert(t, "intruder", nil, false).tlsCertificate()
+
+	_, err = handshake(t, reloader.TLSConfig(), &trusted)
+	assert.NoError(t, err)
+
+	_, err = handshake(t, reloader.TLSConfig(), &untrusted)
+	assert.Error(t, err)
+
+	_, err = handshake(t, reloader.TLSConfig(), nil)
+	assert.Error(t, err)
+}
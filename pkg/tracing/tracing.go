@@ -0,0 +1,159 @@
+// Package tracing configures OpenTelemetry tracing for the service and provides
+// small helpers for instrumenting code with spans.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporter selects where finished spans are sent.
+type Exporter string
+
+const (
+	// ExporterNone disables tracing. Spans are still created, but discarded.
+	ExporterNone Exporter = "none"
+
+	// ExporterOTLP sends spans to an OTLP/gRPC collector.
+	ExporterOTLP Exporter = "otlp"
+
+	// ExporterStdout writes spans as JSON to stdout, for local development.
+	ExporterStdout Exporter = "stdout"
+
+	// ExporterFile writes spans as JSON to a file, for local development.
+	ExporterFile Exporter = "file"
+)
+
+// Options configures the tracer provider.
+type Options struct {
+	ServiceName string
+	Exporter    Exporter
+	// Endpoint is the host:port of the OTLP collector. Used with [ExporterOTLP].
+	Endpoint string
+	// Insecure disables TLS to the OTLP collector.
+	Insecure bool
+	// FilePath is the destination of the spans. Used with [ExporterFile].
+	FilePath string
+}
+
+// Provider wraps the SDK tracer provider together with any resources that must
+// be released on shutdown.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+	closer   io.Closer
+}
+
+// NewProvider creates a tracer provider from `opts` and installs it, along with
+// the W3C trace context propagator, as the global OpenTelemetry provider.
+func NewProvider(ctx context.Context, opts Options) (*Provider, error) {
+	exporter, closer, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(opts.ServiceName),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create tracing resource: %w", err)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if exporter != nil {
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return &Provider{provider: provider, closer: closer}, nil
+}
+
+func newExporter(
+	ctx context.Context,
+	opts Options,
+) (sdktrace.SpanExporter, io.Closer, error) {
+	switch opts.Exporter {
+	case ExporterNone, "":
+		return nil, nil, nil
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create OTLP trace exporter for %q: %w", opts.Endpoint, err)
+		}
+		return exporter, nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("create stdout trace exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case ExporterFile:
+		file, err := os.OpenFile(opts.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open trace file %q: %w", opts.FilePath, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, nil, fmt.Errorf("create file trace exporter: %w", err)
+		}
+		return exporter, file, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+	}
+}
+
+// Shutdown flushes any buffered spans and releases the exporter.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if err := p.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutdown tracer provider: %w", err)
+	}
+	if p.closer != nil {
+		if err := p.closer.Close(); err != nil {
+			return fmt.Errorf("close trace exporter: %w", err)
+		}
+	}
+	return nil
+}
+
+// End records `*err`, if any, on `span` and ends it. It is intended to be
+// deferred with a pointer to a named error return:
+//
+//	func (s *Service) Do(ctx context.Context) (err error) {
+//		ctx, span := tracer.Start(ctx, "Service.Do")
+//		defer tracing.End(span, &err)
+//		...
+//	}
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
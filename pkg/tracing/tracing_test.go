@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnd(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+		wantEvents int
+	}{
+		{
+			name:       "no error",
+			err:        nil,
+			wantStatus: codes.Unset,
+			wantEvents: 0,
+		},
+		{
+			name:       "error",
+			err:        errors.New("boom"),
+			wantStatus: codes.Error,
+			wantEvents: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			_, span := provider.Tracer("test").Start(context.Background(), "op")
+
+			err := tc.err
+			End(span, &err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.wantStatus, spans[0].Status().Code)
+			assert.Len(t, spans[0].Events(), tc.wantEvents)
+		})
+	}
+}
+
+//nolint:paralleltest // NewProvider sets the global tracer provider.
+func TestNewProvider(t *testing.T) {
+	t.Run("file exporter", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.json")
+
+		provider, err := NewProvider(context.Background(), Options{
+			ServiceName: "test",
+			Exporter:    ExporterFile,
+			FilePath:    path,
+		})
+		require.NoError(t, err)
+
+		_, span := provider.provider.Tracer("test").Start(context.Background(), "op")
+		span.End()
+
+		require.NoError(t, provider.Shutdown(context.Background()))
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `"Name":"op"`)
+	})
+
+	t.Run("none exporter", func(t *testing.T) {
+		provider, err := NewProvider(context.Background(), Options{
+			ServiceName: "test",
+			Exporter:    ExporterNone,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		provider, err := NewProvider(context.Background(), Options{
+			ServiceName: "test",
+			Exporter:    Exporter("zipkin"),
+		})
+		assert.Error(t, err)
+		assert.Nil(t, provider)
+	})
+}
@@ -0,0 +1,48 @@
+// Package trustedproxy parses the reverse proxies trusted to report the
+// addresses of the clients they forward. Addresses are normalized as they are
+// when compared with a connection's peer: IPv4-mapped IPv6 addresses are
+// unmapped and zones are dropped.
+package trustedproxy
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// Parse parses a comma-separated list of CIDRs or bare IP addresses.
+func Parse(raw string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("parse trusted proxy %q: %w", entry, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(prefix.Addr().Unmap(), unmappedBits(prefix)).Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("parse trusted proxy %q: %w", entry, err)
+		}
+		addr = addr.Unmap().WithZone("")
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// unmappedBits adjusts the length of an IPv4-mapped IPv6 prefix such as
+// ::ffff:10.0.0.0/104 to that of the equivalent IPv4 prefix.
+func unmappedBits(prefix netip.Prefix) int {
+	if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+		return prefix.Bits() - 96
+	}
+	return prefix.Bits()
+}
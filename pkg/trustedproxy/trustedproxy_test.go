@@ -0,0 +1,44 @@
+package trustedproxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "Empty", raw: "", want: nil},
+		{name: "CIDRs and addresses", raw: "10.0.0.0/8, 192.168.1.10,::1", want: []string{"10.0.0.0/8", "192.168.1.10/32", "::1/128"}},
+		{name: "Masks host bits", raw: "172.16.5.4/12", want: []string{"172.16.0.0/12"}},
+		{name: "Unmaps IPv4-mapped prefixes", raw: "::ffff:10.0.0.0/104", want: []string{"10.0.0.0/8"}},
+		{name: "Invalid", raw: "not-an-ip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			prefixes, err := Parse(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for _, prefix := range prefixes {
+				got = append(got, prefix.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}